@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+
+	"github.com/spf13/pflag"
+
+	"iohk-golang-backend/internal/customergen"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/customerfile"
+	"iohk-golang-backend/internal/infra/db"
+)
+
+var commands = map[string]command{
+	"list":    listCommand,
+	"get":     getCommand,
+	"create":  createCommand,
+	"update":  updateCommand,
+	"delete":  deleteCommand,
+	"export":  exportCommand,
+	"import":  importCommand,
+	"seed":    seedCommand,
+	"migrate": migrateCommand,
+}
+
+func listCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	output := outputFlag(fs)
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		customers, err := e.service.GetAllCustomers(ctx)
+		if err != nil {
+			return err
+		}
+		return printCustomers(os.Stdout, *output, customers)
+	}
+}
+
+func getCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	output := outputFlag(fs)
+	return func(ctx context.Context, e *env, args []string) error {
+		id, err := idArg(args)
+		if err != nil {
+			return err
+		}
+		c, err := e.service.GetCustomer(ctx, id)
+		if err != nil {
+			return err
+		}
+		return printCustomer(os.Stdout, *output, c)
+	}
+}
+
+func createCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	output := outputFlag(fs)
+	fields := customerFlags(fs)
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		for _, name := range []string{"name", "surname", "number", "gender", "country", "birth-date"} {
+			if !fs.Changed(name) {
+				return fmt.Errorf("--%s is required", name)
+			}
+		}
+		c := &domainmodel.Customer{}
+		if err := fields.apply(fs, c); err != nil {
+			return err
+		}
+		created, err := e.service.CreateCustomer(ctx, c)
+		if err != nil {
+			return err
+		}
+		return printCustomer(os.Stdout, *output, created)
+	}
+}
+
+// updateCommand only changes the fields given as flags, the others keep
+// their stored values.
+func updateCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	output := outputFlag(fs)
+	fields := customerFlags(fs)
+	return func(ctx context.Context, e *env, args []string) error {
+		id, err := idArg(args)
+		if err != nil {
+			return err
+		}
+		c, err := e.service.GetCustomer(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fields.apply(fs, c); err != nil {
+			return err
+		}
+		updated, err := e.service.UpdateCustomer(ctx, id, c)
+		if err != nil {
+			return err
+		}
+		return printCustomer(os.Stdout, *output, updated)
+	}
+}
+
+func deleteCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	return func(ctx context.Context, e *env, args []string) error {
+		id, err := idArg(args)
+		if err != nil {
+			return err
+		}
+		if _, err := e.service.DeleteCustomer(ctx, id); err != nil {
+			return err
+		}
+		fmt.Println("Deleted customer", id)
+		return nil
+	}
+}
+
+func exportCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	file := fs.String("file", "-", "file to write, - for standard output")
+	format := fs.String("format", "", "json or csv, by default from the extension of --file")
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		f, err := customerfile.ParseFormat(*format, *file)
+		if err != nil {
+			return err
+		}
+		customers, err := e.service.GetAllCustomers(ctx)
+		if err != nil {
+			return err
+		}
+		if *file == "-" {
+			return customerfile.Write(os.Stdout, f, customers)
+		}
+		out, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		if err := customerfile.Write(out, f, customers); err != nil {
+			out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d customers to %s\n", len(customers), *file)
+		return nil
+	}
+}
+
+// importCommand creates every customer of a file written by export, with new
+// IDs. It stops at the first customer that fails validation, leaving the
+// ones before it created.
+func importCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	format := fs.String("format", "", "json or csv, by default from the extension of FILE")
+	return func(ctx context.Context, e *env, args []string) error {
+		if len(args) != 1 {
+			return errors.New("expected one FILE argument")
+		}
+		f, err := customerfile.ParseFormat(*format, args[0])
+		if err != nil {
+			return err
+		}
+		in := io.Reader(os.Stdin)
+		if args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			in = file
+		}
+		customers, err := customerfile.Read(in, f)
+		if err != nil {
+			return err
+		}
+		if _, err := createAll(ctx, e, slices.Values(customers)); err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d customers\n", len(customers))
+		return nil
+	}
+}
+
+// seedCommand generates customers, by default the same 32 every time, into
+// an empty database, so it is safe to run on every start.
+func seedCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	count := fs.Int("count", 32, "number of customers to generate")
+	seed := fs.Uint64("seed", 1, "random seed, the same seed always generates the same customers")
+	countries := fs.String("countries", customergen.DefaultCountries, "country weights")
+	genders := fs.String("genders", customergen.DefaultGenders, "gender weights")
+	ages := fs.String("ages", customergen.DefaultAges, "age range weights")
+	dependants := fs.String("dependants", customergen.DefaultDependants, "dependants weights")
+	asOf := fs.String("as-of", customergen.DefaultAsOf.Format("2006-01-02"), "date the ages are reached by, as YYYY-MM-DD")
+	useCopy := fs.Bool("copy", false, "bulk-load with COPY instead of creating customers one by one")
+	appendTo := fs.Bool("append", false, "also seed when there are customers already")
+	return func(ctx context.Context, e *env, args []string) error {
+		if err := noArgs(args); err != nil {
+			return err
+		}
+		date, err := customerfile.ParseBirthDate(*asOf)
+		if err != nil {
+			return fmt.Errorf("invalid --as-of: %w", err)
+		}
+		generator, err := customergen.New(customergen.Options{
+			Seed:       *seed,
+			Countries:  *countries,
+			Genders:    *genders,
+			Ages:       *ages,
+			Dependants: *dependants,
+			AsOf:       date,
+		})
+		if err != nil {
+			return err
+		}
+		if !*appendTo {
+			var exists bool
+			if err := e.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM customers)").Scan(&exists); err != nil {
+				return err
+			}
+			if exists {
+				fmt.Println("Customers exist already, not seeding")
+				return nil
+			}
+		}
+
+		if *useCopy {
+			n, err := db.CopyCustomers(ctx, e.pool, generator.All(*count))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Copied %d generated customers\n", n)
+			return nil
+		}
+		n, err := createAll(ctx, e, generator.All(*count))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created %d generated customers\n", n)
+		return nil
+	}
+}
+
+// createAll creates customers one by one through the service, with new IDs,
+// and returns how many it created.
+func createAll(ctx context.Context, e *env, customers iter.Seq[*domainmodel.Customer]) (int, error) {
+	n := 0
+	for c := range customers {
+		c.ID = 0
+		if _, err := e.service.CreateCustomer(ctx, c); err != nil {
+			return n, fmt.Errorf("customer %d: %w", n+1, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// fieldFlags holds the flags of the customer fields.
+type fieldFlags struct {
+	name, surname, gender, country, birthDate *string
+	number, dependants                        *int
+}
+
+func customerFlags(fs *pflag.FlagSet) *fieldFlags {
+	return &fieldFlags{
+		name:       fs.String("name", "", "first name"),
+		surname:    fs.String("surname", "", "surname"),
+		number:     fs.Int("number", 0, "customer number"),
+		gender:     fs.String("gender", "", "MALE or FEMALE"),
+		country:    fs.String("country", "", "country"),
+		dependants: fs.Int("dependants", 0, "number of dependants"),
+		birthDate:  fs.String("birth-date", "", "birth date as YYYY-MM-DD"),
+	}
+}
+
+// apply sets the fields of c whose flags were given.
+func (f *fieldFlags) apply(fs *pflag.FlagSet, c *domainmodel.Customer) error {
+	if fs.Changed("name") {
+		c.Name = *f.name
+	}
+	if fs.Changed("surname") {
+		c.Surname = *f.surname
+	}
+	if fs.Changed("number") {
+		c.Number = *f.number
+	}
+	if fs.Changed("gender") {
+		gender, err := customerfile.ParseGender(*f.gender)
+		if err != nil {
+			return err
+		}
+		c.Gender = gender
+	}
+	if fs.Changed("country") {
+		c.Country = *f.country
+	}
+	if fs.Changed("dependants") {
+		c.Dependants = *f.dependants
+	}
+	if fs.Changed("birth-date") {
+		birthDate, err := customerfile.ParseBirthDate(*f.birthDate)
+		if err != nil {
+			return err
+		}
+		c.BirthDate = birthDate
+	}
+	return nil
+}
+
+func idArg(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("expected one ID argument")
+	}
+	return args[0], nil
+}
+
+func noArgs(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments %q", args)
+	}
+	return nil
+}
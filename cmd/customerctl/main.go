@@ -0,0 +1,154 @@
+// Command customerctl runs one-off customer operations through the same
+// service, validation and encryption as the GraphQL API.
+//
+//	customerctl COMMAND [FLAGS] [ARGS] [-- CONFIG FLAGS]
+//
+// Configuration is read like the server's, from .env, config files, the
+// environment and the flags after --.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/spf13/pflag"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/domain/repository"
+	"iohk-golang-backend/internal/domain/service"
+	"iohk-golang-backend/internal/infra/db"
+	"iohk-golang-backend/internal/infra/piicrypto"
+	"iohk-golang-backend/internal/logging"
+)
+
+const usage = `Usage: customerctl COMMAND [FLAGS] [ARGS] [-- CONFIG FLAGS]
+
+Commands:
+  list                          list customers
+  get ID                        show a customer
+  create --name ... --birth-date YYYY-MM-DD
+                                create a customer
+  update ID [--name ...]        change the given fields of a customer
+  delete ID                     delete a customer
+  export [--file F] [--format json|csv]
+                                write all customers to F or standard output
+  import FILE [--format json|csv]
+                                create the customers in FILE, - for standard input
+  seed                          create sample customers in an empty database
+  migrate up [N]|down [N]|baseline VERSION|upgrade|status
+                                apply, roll back, record, upgrade or list schema migrations
+
+Run customerctl COMMAND --help for the flags of a command. list, get, create,
+update and migrate status print a table, or JSON with --output json.
+`
+
+// A command defines its flags on fs and returns the function running it with
+// the remaining arguments, once the flags were parsed.
+type command func(fs *pflag.FlagSet) func(ctx context.Context, e *env, args []string) error
+
+// env is what commands run with.
+type env struct {
+	cfg     *config.Config
+	pool    *pgxpool.Pool
+	service service.CustomerService
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	define, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+	args, configArgs := splitConfigArgs(args)
+	flags := pflag.NewFlagSet("customerctl "+name, pflag.ContinueOnError)
+	run := define(flags)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+	if output, err := flags.GetString("output"); err == nil && output != outputTable && output != outputJSON {
+		fmt.Fprintf(os.Stderr, "unknown output %q, expected table or json\n", output)
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig(configArgs)
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fatal("Failed to load configuration", err)
+	}
+	setupLogging(cfg)
+	keyring, err := piicrypto.NewKeyring(cfg.PIIEncryptionKeys, cfg.PIIActiveKeyVersion, cfg.PIIBlindIndexKey)
+	if err != nil {
+		fatal("Failed to set up PII encryption", err)
+	}
+	piicrypto.SetDefault(keyring)
+
+	if !cfg.UsesPostgres() {
+		fatal("customerctl needs PostgreSQL storage", fmt.Errorf("STORAGE is %s", cfg.Storage))
+	}
+	pool, err := db.NewDBPool(ctx, cfg)
+	if err != nil {
+		fatal("Failed to set up database pool", err)
+	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, stdlib.OpenDBFromPool(pool))))
+	e := &env{
+		cfg:     cfg,
+		pool:    pool,
+		service: service.NewCustomerService(repository.NewCustomerRepository(client)),
+	}
+
+	err = run(ctx, e, flags.Args())
+	if err := client.Close(); err != nil {
+		slog.Warn("Failed to close ent client", "error", err)
+	}
+	db.CloseDBPool(pool)
+	if err != nil {
+		fatal("Command failed", err, "command", name)
+	}
+}
+
+// fatal logs err and exits, like the server does.
+func fatal(msg string, err error, args ...any) {
+	slog.Error(msg, append(args, "error", err)...)
+	os.Exit(1)
+}
+
+// splitConfigArgs separates the arguments of the command from the
+// configuration flags after --.
+func splitConfigArgs(args []string) ([]string, []string) {
+	if i := slices.Index(args, "--"); i >= 0 {
+		return args[:i], args[i+1:]
+	}
+	return args, nil
+}
+
+func setupLogging(cfg *config.Config) {
+	logger, err := logging.New(os.Stderr, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		fatal("Failed to set up logging", err)
+	}
+	slog.SetDefault(logger)
+}
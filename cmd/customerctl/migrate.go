@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"iohk-golang-backend/ent/migrate/migrations"
+	"iohk-golang-backend/internal/infra/db"
+)
+
+// migrationStatus is a line of `migrate status --output json`.
+type migrationStatus struct {
+	Version   string     `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// migrateCommand applies, rolls back, baselines, upgrades or lists the
+// migrations embedded in the binary, with the same db.RunMigrateCommand as
+// the server.
+func migrateCommand(fs *pflag.FlagSet) func(context.Context, *env, []string) error {
+	output := outputFlag(fs)
+	return func(ctx context.Context, e *env, args []string) error {
+		loaded, err := db.LoadMigrations(migrations.FS)
+		if err != nil {
+			return err
+		}
+		return db.RunMigrateCommand(ctx, os.Stdout, db.NewMigrator(e.pool, loaded), args, func(status []db.MigrationStatus) error {
+			return printMigrationStatus(*output, status)
+		})
+	}
+}
+
+func printMigrationStatus(output string, status []db.MigrationStatus) error {
+	rows := make([][]string, len(status))
+	lines := make([]migrationStatus, len(status))
+	for i, s := range status {
+		rows[i] = []string{s.Version, s.Name, "pending", "-"}
+		lines[i] = migrationStatus{Version: s.Version, Name: s.Name, Applied: s.Applied}
+		if s.Applied {
+			at := s.AppliedAt.UTC()
+			rows[i][2], rows[i][3] = "applied", at.Format(time.RFC3339)
+			lines[i].AppliedAt = &at
+		}
+	}
+	return printTable(os.Stdout, output, []string{"VERSION", "NAME", "STATUS", "APPLIED AT"}, rows, func() any { return lines })
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/mapper"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func outputFlag(fs *pflag.FlagSet) *string {
+	return fs.StringP("output", "o", outputTable, "table or json")
+}
+
+// printJSON writes v indented, in the shape of the customer data export.
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes rows aligned under header, or an error for an unknown
+// output format.
+func printTable(w io.Writer, output string, header []string, rows [][]string, asJSON func() any) error {
+	switch output {
+	case outputJSON:
+		return printJSON(w, asJSON())
+	case outputTable:
+	default:
+		return fmt.Errorf("unknown output %q, expected table or json", output)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, cell)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+var customerHeader = []string{"ID", "NAME", "SURNAME", "NUMBER", "GENDER", "COUNTRY", "DEPENDANTS", "BIRTH DATE"}
+
+func customerRow(c *domainmodel.Customer) []string {
+	return []string{strconv.Itoa(c.ID), c.Name, c.Surname, strconv.Itoa(c.Number), string(c.Gender),
+		c.Country, strconv.Itoa(c.Dependants), c.BirthDate.Format("2006-01-02")}
+}
+
+func printCustomers(w io.Writer, output string, customers []*domainmodel.Customer) error {
+	rows := make([][]string, len(customers))
+	for i, c := range customers {
+		rows[i] = customerRow(c)
+	}
+	return printTable(w, output, customerHeader, rows, func() any {
+		exports := make([]domainmodel.CustomerExport, len(customers))
+		for i, c := range customers {
+			exports[i] = mapper.DomainToCustomerExport(c)
+		}
+		return exports
+	})
+}
+
+func printCustomer(w io.Writer, output string, c *domainmodel.Customer) error {
+	return printTable(w, output, customerHeader, [][]string{customerRow(c)}, func() any {
+		return mapper.DomainToCustomerExport(c)
+	})
+}
@@ -2,70 +2,567 @@ package main
 
 import (
 	"context"
-	"log"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"iohk-golang-backend/ent"
 	"iohk-golang-backend/graph"
+	"iohk-golang-backend/internal/allowlist"
+	"iohk-golang-backend/internal/auth"
 	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/cors"
+	"iohk-golang-backend/internal/customergen"
+	domainmodel "iohk-golang-backend/internal/domain/model"
 	"iohk-golang-backend/internal/domain/repository"
 	"iohk-golang-backend/internal/domain/service"
+	"iohk-golang-backend/internal/health"
 	"iohk-golang-backend/internal/infra/db"
+	"iohk-golang-backend/internal/infra/piicrypto"
+	"iohk-golang-backend/internal/lifecycle"
+	"iohk-golang-backend/internal/logging"
+	"iohk-golang-backend/internal/metrics"
+	"iohk-golang-backend/internal/querylimit"
+	"iohk-golang-backend/internal/ratelimit"
+	"iohk-golang-backend/internal/requestid"
+	"iohk-golang-backend/internal/servertls"
+	"iohk-golang-backend/internal/tracing"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/websocket"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/spf13/pflag"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
+// sampleCustomers is how many generated customers the storage for local
+// development starts with.
+const sampleCustomers = 32
+
 func main() {
+	// Docker sends SIGTERM on stop, Ctrl+C sends SIGINT
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Setup Configuration, Database and ORM
-	cfg := loadConfig()
-	pool := setupDatabasePool(cfg)
-	defer db.CloseDBPool(pool)
-	client := setupEntgoConnection(pool)
-	defer client.Close()
+	command, args := splitCommand(os.Args[1:])
+	var operands []string
+	if command == "migrate" {
+		operands, args = splitOperands(args)
+	}
+	cfg := loadConfig(args)
+	level := setupLogging(cfg)
+	if command == "migrate" {
+		runMigrate(ctx, cfg, operands)
+		return
+	}
+	setupEncryption(cfg)
+	setupErasureLog(cfg)
+	shutdownTracing := setupTracing(ctx, cfg)
+	m := metrics.New()
+
+	var err error
+	switch cfg.Storage {
+	case config.StorageSQLite:
+		err = runWithSQLite(ctx, cfg, command, args, level, m)
+	case config.StorageMemory:
+		err = runInMemory(ctx, cfg, command, args, level, m)
+	default:
+		err = runWithPostgres(ctx, cfg, command, args, level, m)
+	}
+
+	// Flush the spans of the last requests
+	shutdownTracing()
+	if err != nil {
+		fatal("GraphQL server failed", err)
+	}
+}
+
+// runWithPostgres runs command, or the server, with customers stored in
+// PostgreSQL.
+func runWithPostgres(ctx context.Context, cfg *config.Config, command string, args []string, level *slog.LevelVar, m *metrics.Metrics) error {
+	pool := setupDatabasePool(cfg, m)
+	client := setupEntgoConnection(pool, m)
+	checkMigrations(ctx, cfg, pool)
+
+	switch command {
+	case "":
+	case "reencrypt":
+		reencryptCustomers(ctx, client)
+		closeDatabase(client, pool)
+		return nil
+	default:
+		fatal("Unknown command", errors.New("expected no command, reencrypt or migrate"), "command", command)
+	}
 
 	// Setup Repository, Service and GraphQL server
+	replicaPool := setupReplicaPool(cfg, m)
+	router, replicaClient := setupReadRouting(ctx, cfg, client, replicaPool, m)
+	customerRepo := repository.NewTracedCustomerRepository(repository.NewRoutedCustomerRepository(router))
+	customerService := service.NewTracedCustomerService(service.NewCustomerService(customerRepo))
+	err := setupAndRunGraphQLServer(ctx, cfg, args, level, customerService, pool, setupSessions(cfg), m)
+
+	// Only close the database once the server has drained
+	closeDatabase(replicaClient, replicaPool)
+	closeDatabase(client, pool)
+	return err
+}
+
+// runWithSQLite runs command, or the server, with customers stored in a
+// SQLite file for local development. The ent schema is applied with
+// auto-migration instead of the PostgreSQL migrations, and an empty database
+// is seeded with generated sample customers.
+func runWithSQLite(ctx context.Context, cfg *config.Config, command string, args []string, level *slog.LevelVar, m *metrics.Metrics) error {
+	sqlDB, err := db.NewSQLiteDB(ctx, cfg.SQLitePath)
+	if err != nil {
+		fatal("Failed to open SQLite database", err)
+	}
+	client := ent.NewClient(ent.Driver(m.InstrumentDriver(entsql.OpenDB(dialect.SQLite, sqlDB))))
+	defer func() {
+		if err := client.Close(); err != nil {
+			slog.Warn("Failed to close ent client", "error", err)
+		}
+	}()
+	if err := client.Schema.Create(ctx); err != nil {
+		fatal("Failed to create SQLite schema", err)
+	}
+
+	switch command {
+	case "":
+	case "reencrypt":
+		reencryptCustomers(ctx, client)
+		return nil
+	default:
+		fatal("Unknown command", errors.New("expected no command or reencrypt"), "command", command)
+	}
+
+	slog.Warn("Using SQLite storage for development, the erasure log is not protected against changes by the database")
 	customerRepo := repository.NewCustomerRepository(client)
-	customerService := service.NewCustomerService(customerRepo)
-	setupAndRunGraphQLServer(cfg, customerService)
+	seeded, err := client.Customer.Query().Exist(ctx)
+	if err != nil {
+		fatal("Failed to check for customers", err)
+	}
+	if !seeded {
+		seedCustomers(ctx, customerRepo)
+	}
+	customerService := service.NewTracedCustomerService(service.NewCustomerService(repository.NewTracedCustomerRepository(customerRepo)))
+	return setupAndRunGraphQLServer(ctx, cfg, args, level, customerService, health.PingerFunc(sqlDB.PingContext), db.Middleware, m)
 }
 
-func loadConfig() *config.Config {
-	cfg, err := config.LoadConfig()
+// runInMemory runs the server with customers kept in memory, seeded with
+// generated sample customers. Everything is lost when it exits.
+func runInMemory(ctx context.Context, cfg *config.Config, command string, args []string, level *slog.LevelVar, m *metrics.Metrics) error {
+	if command != "" {
+		fatal("Unknown command", errors.New("STORAGE=memory only serves, expected no command"), "command", command)
+	}
+	slog.Warn("Customers are kept in memory and lost when the server stops")
+	customerRepo := repository.NewMemoryCustomerRepository()
+	seedCustomers(ctx, customerRepo)
+	customerService := service.NewTracedCustomerService(service.NewCustomerService(repository.NewTracedCustomerRepository(customerRepo)))
+	return setupAndRunGraphQLServer(ctx, cfg, args, level, customerService, nil, db.Middleware, m)
+}
+
+// seedCustomers creates the same generated customers as `customerctl seed`.
+func seedCustomers(ctx context.Context, repo repository.CustomerRepository) {
+	generator, err := customergen.New(customergen.Options{Seed: 1})
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		fatal("Failed to generate sample customers", err)
+	}
+	count := 0
+	for c := range generator.All(sampleCustomers) {
+		if _, err := repo.Create(ctx, c); err != nil {
+			fatal("Failed to seed sample customers", err)
+		}
+		count++
+	}
+	slog.Info("Seeded sample customers", "count", count)
+}
+
+// fatal logs err and exits, like log.Fatal does for the standard logger.
+func fatal(msg string, err error, args ...any) {
+	slog.Error(msg, append(args, "error", err)...)
+	os.Exit(1)
+}
+
+// splitCommand separates an optional subcommand, such as reencrypt, from the
+// configuration flags following it.
+func splitCommand(args []string) (string, []string) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return args[0], args[1:]
+	}
+	return "", args
+}
+
+func loadConfig(args []string) *config.Config {
+	cfg, err := config.LoadConfig(args)
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fatal("Failed to load configuration", err)
 	}
 	return cfg
 }
 
-func setupDatabasePool(cfg *config.Config) *pgxpool.Pool {
+// setupLogging makes the configured logger the default, which also routes
+// the standard log package through it. The returned level can be changed
+// while the server runs.
+func setupLogging(cfg *config.Config) *slog.LevelVar {
+	lvl, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		fatal("Failed to set up logging", err)
+	}
+	level := new(slog.LevelVar)
+	level.Set(lvl)
+	logger, err := logging.NewWithLevel(os.Stderr, level, cfg.LogFormat)
+	if err != nil {
+		fatal("Failed to set up logging", err)
+	}
+	slog.SetDefault(logger)
+	return level
+}
+
+func setupEncryption(cfg *config.Config) {
+	keyring, err := piicrypto.NewKeyring(cfg.PIIEncryptionKeys, cfg.PIIActiveKeyVersion, cfg.PIIBlindIndexKey)
+	if err != nil {
+		fatal("Failed to set up PII encryption", err)
+	}
+	if keyring == nil {
+		slog.Warn("No PII encryption keys configured, customer PII is stored in plaintext")
+	}
+	piicrypto.SetDefault(keyring)
+}
+
+func setupErasureLog(cfg *config.Config) {
+	if err := domainmodel.SetErasureLogKey(cfg.ErasureLogKey); err != nil {
+		fatal("Failed to set up erasure log", err)
+	}
+	if cfg.ErasureLogKey == "" {
+		slog.Warn("No erasure log key configured, the erasure log hash chain is not keyed")
+	}
+}
+
+func reencryptCustomers(ctx context.Context, client *ent.Client) {
+	count, err := db.ReencryptCustomers(ctx, client, 500)
+	if err != nil {
+		fatal("Failed to re-encrypt customers", err, "count", count)
+	}
+	slog.Info("Re-encrypted customers", "count", count)
+}
+
+// setupTracing installs the configured trace exporter and returns a function
+// flushing it, which is safe to call after the signal context is cancelled.
+func setupTracing(ctx context.Context, cfg *config.Config) func() {
+	shutdown, err := tracing.Setup(ctx, cfg.TracingExporter, cfg.TracingSampleRatio)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			slog.Warn("Failed to flush traces", "error", err)
+		}
+	}
+}
+
+func setupDatabasePool(cfg *config.Config, m *metrics.Metrics) *pgxpool.Pool {
 	ctx := context.Background()
-	pool, err := db.NewDBPool(ctx, cfg)
+	pool, err := db.NewDBPool(ctx, cfg, metrics.PgxTracer{Metrics: m}, tracing.PgxTracer{})
+	if err != nil {
+		fatal("Failed to set up database pool", err)
+	}
+	m.RegisterPool(pool, "primary")
+	return pool
+}
+
+// setupReplicaPool returns the read replica pool, or nil if none is
+// configured.
+func setupReplicaPool(cfg *config.Config, m *metrics.Metrics) *pgxpool.Pool {
+	pool, err := db.NewReplicaPool(context.Background(), cfg, metrics.PgxTracer{Metrics: m}, tracing.PgxTracer{})
 	if err != nil {
-		log.Fatalf("Failed to set up database pool: %v", err)
+		fatal("Failed to set up read replica pool", err)
+	}
+	if pool != nil {
+		m.RegisterPool(pool, "replica")
 	}
 	return pool
 }
 
-func setupEntgoConnection(pool *pgxpool.Pool) *ent.Client {
+// setupReadRouting returns the router sending customer reads to the replica
+// while it is healthy, and the replica's ent client, if there is a replica.
+// Without one, everything goes to the primary.
+func setupReadRouting(ctx context.Context, cfg *config.Config, primary *ent.Client, replicaPool *pgxpool.Pool, m *metrics.Metrics) (*db.Router, *ent.Client) {
+	if replicaPool == nil {
+		return db.NewRouter(primary, nil, nil), nil
+	}
+	replica := setupEntgoConnection(replicaPool, m)
+	router := db.NewRouter(primary, replica, replicaPool.Ping)
+	checkCtx, cancel := context.WithTimeout(ctx, cfg.DBReplicaCheckInterval)
+	router.Check(checkCtx)
+	cancel()
+	go router.Watch(ctx, cfg.DBReplicaCheckInterval)
+	m.RegisterReplicaHealth(router.Healthy)
+	return router, replica
+}
+
+// setupSessions returns the middleware starting the session of each request.
+// With a replica, clients stay on the primary for DB_REPLICA_PIN_WINDOW after
+// they wrote.
+func setupSessions(cfg *config.Config) func(http.Handler) http.Handler {
+	if cfg.ReplicaDatabaseURL == "" || cfg.DBReplicaPinWindow == 0 {
+		return db.Middleware
+	}
+	key := []byte(cfg.DBReplicaPinKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			fatal("Failed to generate the replica pin key", err)
+		}
+		slog.Warn("DB_REPLICA_PIN_KEY is not set, clients are only kept on the primary after a write by this instance")
+	}
+	return db.NewSessionPinning(key, cfg.DBReplicaPinWindow).Middleware
+}
+
+func setupEntgoConnection(pool *pgxpool.Pool, m *metrics.Metrics) *ent.Client {
 	db := stdlib.OpenDBFromPool(pool)
 	drv := entsql.OpenDB(dialect.Postgres, db)
-	client := ent.NewClient(ent.Driver(drv))
+	client := ent.NewClient(ent.Driver(m.InstrumentDriver(drv)))
 	return client
 }
 
-func setupAndRunGraphQLServer(cfg *config.Config, customerService service.CustomerService) {
+// closeDatabase closes the ent client before the pool it borrows connections from.
+func closeDatabase(client *ent.Client, pool *pgxpool.Pool) {
+	if client == nil {
+		return
+	}
+	if err := client.Close(); err != nil {
+		slog.Warn("Failed to close ent client", "error", err)
+	}
+	db.CloseDBPool(pool)
+}
+
+func setupAndRunGraphQLServer(ctx context.Context, cfg *config.Config, args []string, level *slog.LevelVar, customerService service.CustomerService, database health.Pinger, sessions func(http.Handler) http.Handler, m *metrics.Metrics) error {
 	// Create NewResolver with the initialized service
-	resolver := graph.NewResolver(customerService)
+	a := &app{
+		cfg:        cfg,
+		level:      level,
+		resolver:   graph.NewResolver(customerService),
+		queryCache: lru.New[*ast.QueryDocument](1000),
+		apqCache:   lru.New[string](cfg.GraphQLAPQCacheSize),
+		limiter:    ratelimit.New(cfg.RateLimitRPS, cfg.RateLimitBurst),
+		ipLimiter:  ratelimit.New(cfg.RateLimitIPRPS, cfg.RateLimitIPBurst),
+		websockets: lifecycle.NewWebsockets(),
+		database:   database,
+		m:          m,
+		draining:   func() bool { return ctx.Err() != nil },
+	}
+
+	var err error
+	a.apiKeys, err = auth.ParseAPIKeys(cfg.AuthAPIKeys)
+	if err != nil {
+		fatal("Failed to parse API keys", err)
+	}
+	a.clientCerts, err = auth.ParseClientCerts(cfg.AuthClientCerts)
+	if err != nil {
+		fatal("Failed to parse client certificates", err)
+	}
+	if len(a.apiKeys) == 0 && len(a.clientCerts) == 0 {
+		slog.Warn("No API keys configured, authentication is disabled")
+	}
+	// Outside production the allowlist is optional and only names the
+	// operations recorded in metrics
+	if cfg.GraphQLAllowlist != "" {
+		a.allowed, err = allowlist.Load(cfg.GraphQLAllowlist)
+		if err != nil {
+			fatal("Failed to load GraphQL allowlist", err)
+		}
+	}
+	if cfg.IsProduction() {
+		slog.Info("Serving allowlisted GraphQL operations only, introspection is disabled", "operations", a.allowed.Len())
+	}
+
+	h, err := a.newHandler(cfg)
+	if err != nil {
+		fatal("Failed to set up HTTP handler", err)
+	}
+	a.handler.Store(h)
+	if cfg.ConfigFile != "" {
+		a.watchConfig(ctx, args)
+	}
+
+	scheme := "http"
+	if cfg.TLSEnabled() {
+		scheme = "https"
+	}
+	if cfg.IsProduction() {
+		slog.Info("Serving GraphQL", "url", fmt.Sprintf("%s://%s:%s/query", scheme, cfg.AppHost, cfg.AppPort))
+	} else {
+		slog.Info("Serving GraphQL playground", "url", fmt.Sprintf("%s://%s:%s/", scheme, cfg.AppHost, cfg.AppPort))
+	}
+
+	server := &http.Server{
+		Handler:           tracing.HTTPHandler(requestid.Middleware(logging.Middleware(sessions(&a.handler)))),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if cfg.TLSEnabled() {
+		server.TLSConfig = setupTLS(ctx, cfg)
+	}
+	if err := a.serveAdmin(ctx, cfg); err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", ":"+cfg.AppPort)
+	if err != nil {
+		return err
+	}
+	return lifecycle.Serve(ctx, server, ln, a.websockets, cfg.ShutdownTimeout)
+}
+
+// serveAdmin serves /metrics and /debug/info on ADMIN_ADDR until ctx is
+// cancelled, apart from the API listener where anyone could read them.
+func (a *app) serveAdmin(ctx context.Context, cfg *config.Config) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", a.m.Handler())
+	health.NewHandler(a.database, cfg.ReadinessTimeout, a.draining).RegisterDiagnostics(mux)
+	ln, err := net.Listen("tcp", cfg.AdminAddr)
+	if err != nil {
+		return err
+	}
+	slog.Info("Serving metrics and diagnostics", "url", fmt.Sprintf("http://%s/metrics", ln.Addr()))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := lifecycle.Serve(ctx, server, ln, lifecycle.NewWebsockets(), cfg.ShutdownTimeout); err != nil {
+			slog.Error("Admin listener failed", "error", err)
+		}
+	}()
+	return nil
+}
+
+// setupTLS loads the server certificate, and the client CA bundle in mTLS
+// mode, and reloads them whenever the files change until ctx is cancelled.
+func setupTLS(ctx context.Context, cfg *config.Config) *tls.Config {
+	reloader, err := servertls.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+	if err != nil {
+		fatal("Failed to set up TLS", err)
+	}
+	go reloader.Watch(ctx, cfg.TLSReloadInterval)
+	if cfg.TLSClientCAFile != "" {
+		slog.Info("Requiring TLS client certificates", "clientCA", cfg.TLSClientCAFile)
+	}
+	return reloader.TLSConfig()
+}
+
+// app holds what the HTTP handler is built from. The handler is rebuilt when
+// the configuration is reloaded, while the caches, the rate limit buckets and
+// the websocket connections are kept.
+type app struct {
+	cfg         *config.Config
+	level       *slog.LevelVar
+	resolver    *graph.Resolver
+	apiKeys     map[string]*auth.Principal
+	clientCerts map[string]*auth.Principal
+	allowed     *allowlist.Allowlist
+	queryCache  *lru.LRU[*ast.QueryDocument]
+	apqCache    *lru.LRU[string]
+	limiter     *ratelimit.Limiter
+	ipLimiter   *ratelimit.Limiter // before authentication
+	websockets  *lifecycle.Websockets
+	database    health.Pinger // nil with memory storage
+	m           *metrics.Metrics
+	draining    func() bool
+	handler     swapHandler
+}
+
+// newHandler builds the routes for cfg.
+func (a *app) newHandler(cfg *config.Config) (http.Handler, error) {
+	corsPolicy, err := cors.NewPolicy(cors.Options{
+		AllowedOrigins:   cors.ParseList(cfg.CORSAllowedOrigins),
+		AllowedMethods:   cors.ParseList(cfg.CORSAllowedMethods),
+		AllowedHeaders:   cors.ParseList(cfg.CORSAllowedHeaders),
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid CORS settings: %w", err)
+	}
 
 	// Set up GraphQL server
-	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
-	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
-	log.Printf("Connect to http://%s:%s/ for GraphQL playground", cfg.AppHost, cfg.AppPort)
-	log.Fatal(http.ListenAndServe(":"+cfg.AppPort, nil))
+	srv := a.newGraphQLServer(cfg, corsPolicy)
+	var operationNames map[string]bool
+	if a.allowed != nil {
+		operationNames = a.allowed.OperationNames()
+	}
+	srv.Use(metrics.Extension{Metrics: a.m, OperationNames: operationNames})
+	srv.Use(tracing.Extension{})
+	srv.Use(logging.Extension{})
+	var query http.Handler = srv
+	if cfg.RateLimitEnabled {
+		srv.Use(ratelimit.CostExtension{TokenComplexity: cfg.RateLimitTokenComplexity})
+		query = a.limiter.Middleware(ratelimit.KeyByPrincipalOrIP(cfg.RateLimitTrustProxy))(query)
+	}
+	query = auth.Middleware(a.apiKeys, a.clientCerts)(query)
+	if cfg.RateLimitEnabled {
+		query = a.ipLimiter.Middleware(ratelimit.KeyByIP(cfg.RateLimitTrustProxy))(query)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/query", a.m.InFlight(query))
+	health.NewHandler(a.database, cfg.ReadinessTimeout, a.draining).Register(mux)
+	if !cfg.IsProduction() {
+		// Only the root, so paths such as /metrics are not answered with it
+		mux.Handle("/{$}", playground.Handler("GraphQL playground", "/query"))
+	}
+	return corsPolicy.Middleware(mux), nil
+}
+
+// newGraphQLServer sets up the same transports as handler.NewDefaultServer.
+// Outside production it serves introspection and caches automatic persisted
+// queries, in production it only runs operations from the allowlist.
+// Subscriptions are only upgraded for origins the CORS policy allows.
+func (a *app) newGraphQLServer(cfg *config.Config, corsPolicy *cors.Policy) *handler.Server {
+	srv := handler.New(graph.NewExecutableSchema(graph.NewConfig(a.resolver)))
+
+	srv.AddTransport(transport.Websocket{
+		Upgrader:              websocket.Upgrader{CheckOrigin: corsPolicy.CheckOrigin},
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              a.websockets.InitFunc,
+		CloseFunc:             a.websockets.CloseFunc,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(a.queryCache)
+	srv.SetErrorPresenter(requestid.ErrorPresenter)
+
+	if cfg.IsProduction() {
+		srv.Use(a.allowed)
+	} else {
+		srv.Use(extension.Introspection{})
+		srv.Use(extension.AutomaticPersistedQuery{Cache: a.apqCache})
+	}
+
+	srv.Use(extension.FixedComplexityLimit(cfg.GraphQLMaxComplexity))
+	srv.Use(querylimit.DepthLimit{MaxDepth: cfg.GraphQLMaxDepth})
+
+	return srv
 }
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	entmigrate "iohk-golang-backend/ent/migrate"
+	"iohk-golang-backend/ent/migrate/migrations"
+	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/infra/db"
+)
+
+// splitOperands separates the leading operands of a command, such as the
+// `up` in `migrate up 2`, from the configuration flags following them.
+func splitOperands(args []string) ([]string, []string) {
+	i := 0
+	for i < len(args) && !strings.HasPrefix(args[i], "-") {
+		i++
+	}
+	return args[:i], args[i:]
+}
+
+func loadMigrations() []db.Migration {
+	m, err := db.LoadMigrations(migrations.FS)
+	if err != nil {
+		fatal("Failed to load schema migrations", err)
+	}
+	return m
+}
+
+// runMigrate runs `migrate up [N]`, `migrate down [N]`, `migrate status`,
+// `migrate baseline VERSION`, `migrate upgrade`, `migrate drift [json]` or
+// `migrate new NAME`.
+func runMigrate(ctx context.Context, cfg *config.Config, operands []string) {
+	if !cfg.UsesPostgres() {
+		fatal("Migrations need PostgreSQL storage", fmt.Errorf("STORAGE is %s", cfg.Storage))
+	}
+	if len(operands) == 0 {
+		fatal("Missing migrate command", errors.New("expected up, down, status, baseline, upgrade, drift or new"))
+	}
+	command, operands := operands[0], operands[1:]
+
+	if command == "new" {
+		if len(operands) != 1 {
+			fatal("Missing migration name", errors.New("usage: migrate new NAME"))
+		}
+		if cfg.DevDatabaseURL == "" {
+			fatal("Missing dev database", errors.New("DEV_DATABASE_URL must point to an empty database"))
+		}
+		files, err := db.NewMigration(ctx, db.MigrationsDir, cfg.DevDatabaseURL, operands[0])
+		if err != nil {
+			fatal("Failed to create migration", err)
+		}
+		for _, file := range files {
+			fmt.Println("Created", file)
+		}
+		return
+	}
+
+	pool, err := db.NewDBPool(ctx, cfg)
+	if err != nil {
+		fatal("Failed to set up database pool", err)
+	}
+	defer db.CloseDBPool(pool)
+	migrator := db.NewMigrator(pool, loadMigrations())
+
+	switch command {
+	case "drift":
+		asJSON := len(operands) > 0 && operands[0] == "json"
+		if len(operands) > 0 && !asJSON {
+			fatal("Unknown drift output format", errors.New("expected json"), "format", operands[0])
+		}
+		drift, err := db.DetectDrift(ctx, pool, entmigrate.Tables)
+		if err != nil {
+			fatal("Failed to detect schema drift", err)
+		}
+		if asJSON {
+			printDriftJSON(drift)
+		} else {
+			printDrift(drift)
+		}
+		if len(drift) > 0 {
+			fatal("Database schema differs from the ent schema", fmt.Errorf("%d differences", len(drift)))
+		}
+	default:
+		err := db.RunMigrateCommand(ctx, os.Stdout, migrator, append([]string{command}, operands...), printMigrationStatus)
+		if errors.Is(err, db.ErrUnknownMigrateCommand) {
+			fatal("Unknown migrate command", errors.New("expected up, down, status, baseline, upgrade, drift or new"), "command", command)
+		}
+		if err != nil {
+			fatal("Migration failed", err, "command", command)
+		}
+	}
+}
+
+func printMigrationStatus(status []db.MigrationStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range status {
+		state, at := "pending", "-"
+		if s.Applied {
+			state, at = "applied", s.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Version, s.Name, state, at)
+	}
+	return w.Flush()
+}
+
+func printDrift(drift []db.Drift) {
+	if len(drift) == 0 {
+		fmt.Println("No schema drift")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tOBJECT\tDRIFT\tEXPECTED\tACTUAL")
+	for _, d := range drift {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Table, orDash(d.Object), d.Kind, orDash(d.Expected), orDash(d.Actual))
+	}
+	w.Flush()
+}
+
+func printDriftJSON(drift []db.Drift) {
+	if drift == nil {
+		drift = []db.Drift{}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(drift); err != nil {
+		fatal("Failed to write schema drift", err)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// checkMigrations refuses to start, or only warns if so configured, when the
+// database lacks migrations this binary was built with.
+func checkMigrations(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool) {
+	migrator := db.NewMigrator(pool, loadMigrations())
+	unversioned, err := migrator.Unversioned(ctx)
+	if err != nil {
+		fatal("Failed to check schema migrations", err)
+	}
+	if unversioned {
+		fatal("Database schema has no recorded migrations", db.ErrUnversionedSchema)
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		fatal("Failed to check schema migrations", err)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	versions := make([]string, len(pending))
+	for i, m := range pending {
+		versions[i] = m.Version + "_" + m.Name
+	}
+	if cfg.DBPendingMigrations == config.PendingMigrationsWarn {
+		slog.Warn("Database has pending schema migrations, run migrate up", "pending", versions)
+		return
+	}
+	fatal("Database has pending schema migrations", errors.New("run migrate up, or set DB_PENDING_MIGRATIONS=warn to start anyway"), "pending", versions)
+}
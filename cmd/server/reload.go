@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+
+	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/logging"
+	"iohk-golang-backend/internal/metrics"
+)
+
+// swapHandler serves requests with the last handler stored in it, so a
+// reload takes effect for new requests without touching those in flight.
+type swapHandler struct {
+	current atomic.Pointer[http.Handler]
+}
+
+func (s *swapHandler) Store(h http.Handler) {
+	s.current.Store(&h)
+}
+
+func (s *swapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*s.current.Load()).ServeHTTP(w, r)
+}
+
+// watchConfig reloads the configuration whenever the config file changes.
+func (a *app) watchConfig(ctx context.Context, args []string) {
+	err := config.Watch(ctx, a.cfg.ConfigFile, func() { a.reload(args) })
+	if err != nil {
+		slog.Warn("Failed to watch config file, changes need a restart", "file", a.cfg.ConfigFile, "error", err)
+		return
+	}
+	slog.Info("Watching config file for changes", "file", a.cfg.ConfigFile)
+}
+
+// reload loads the configuration again and applies it if only settings that
+// are safe to change at runtime differ. Otherwise the running configuration
+// is kept and a warning is logged. Reloads run one at a time on the watcher
+// goroutine.
+func (a *app) reload(args []string) {
+	next, err := config.LoadConfig(args)
+	if err != nil {
+		slog.Warn("Failed to reload configuration, keeping the running one", "error", err)
+		a.m.ObserveConfigReload(metrics.ReloadFailed)
+		return
+	}
+	changed, err := config.CheckReload(a.cfg, next)
+	if err != nil {
+		slog.Warn("Rejected configuration reload, keeping the running one", "error", err)
+		a.m.ObserveConfigReload(metrics.ReloadRejected)
+		return
+	}
+	if len(changed) == 0 {
+		// Environment variables and flags outrank the file
+		slog.Info("Config file changed, but no effective setting differs", "file", next.ConfigFile)
+		return
+	}
+
+	lvl, err := logging.ParseLevel(next.LogLevel)
+	if err != nil {
+		slog.Warn("Failed to reload configuration, keeping the running one", "error", err)
+		a.m.ObserveConfigReload(metrics.ReloadFailed)
+		return
+	}
+	h, err := a.newHandler(next)
+	if err != nil {
+		slog.Warn("Failed to reload configuration, keeping the running one", "error", err)
+		a.m.ObserveConfigReload(metrics.ReloadFailed)
+		return
+	}
+
+	a.level.Set(lvl)
+	if next.RateLimitEnabled {
+		a.limiter.SetLimits(next.RateLimitRPS, next.RateLimitBurst)
+		a.ipLimiter.SetLimits(next.RateLimitIPRPS, next.RateLimitIPBurst)
+	}
+	a.handler.Store(h)
+	a.cfg = next
+	a.m.ObserveConfigReload(metrics.ReloadApplied)
+	slog.Info("Configuration reloaded", "event", "config.reloaded", "file", next.ConfigFile, "changed", changed)
+}
@@ -12,6 +12,7 @@ import (
 	"iohk-golang-backend/ent/migrate"
 
 	"iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/ent/erasurelog"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
@@ -25,6 +26,8 @@ type Client struct {
 	Schema *migrate.Schema
 	// Customer is the client for interacting with the Customer builders.
 	Customer *CustomerClient
+	// ErasureLog is the client for interacting with the ErasureLog builders.
+	ErasureLog *ErasureLogClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -37,6 +40,7 @@ func NewClient(opts ...Option) *Client {
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.Customer = NewCustomerClient(c.config)
+	c.ErasureLog = NewErasureLogClient(c.config)
 }
 
 type (
@@ -127,9 +131,10 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:      ctx,
-		config:   cfg,
-		Customer: NewCustomerClient(cfg),
+		ctx:        ctx,
+		config:     cfg,
+		Customer:   NewCustomerClient(cfg),
+		ErasureLog: NewErasureLogClient(cfg),
 	}, nil
 }
 
@@ -147,9 +152,10 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:      ctx,
-		config:   cfg,
-		Customer: NewCustomerClient(cfg),
+		ctx:        ctx,
+		config:     cfg,
+		Customer:   NewCustomerClient(cfg),
+		ErasureLog: NewErasureLogClient(cfg),
 	}, nil
 }
 
@@ -179,12 +185,14 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	c.Customer.Use(hooks...)
+	c.ErasureLog.Use(hooks...)
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	c.Customer.Intercept(interceptors...)
+	c.ErasureLog.Intercept(interceptors...)
 }
 
 // Mutate implements the ent.Mutator interface.
@@ -192,6 +200,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
 	case *CustomerMutation:
 		return c.Customer.mutate(ctx, m)
+	case *ErasureLogMutation:
+		return c.ErasureLog.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
@@ -307,7 +317,8 @@ func (c *CustomerClient) GetX(ctx context.Context, id int) *Customer {
 
 // Hooks returns the client hooks.
 func (c *CustomerClient) Hooks() []Hook {
-	return c.hooks.Customer
+	hooks := c.hooks.Customer
+	return append(hooks[:len(hooks):len(hooks)], customer.Hooks[:]...)
 }
 
 // Interceptors returns the client interceptors.
@@ -330,12 +341,145 @@ func (c *CustomerClient) mutate(ctx context.Context, m *CustomerMutation) (Value
 	}
 }
 
+// ErasureLogClient is a client for the ErasureLog schema.
+type ErasureLogClient struct {
+	config
+}
+
+// NewErasureLogClient returns a client for the ErasureLog from the given config.
+func NewErasureLogClient(c config) *ErasureLogClient {
+	return &ErasureLogClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `erasurelog.Hooks(f(g(h())))`.
+func (c *ErasureLogClient) Use(hooks ...Hook) {
+	c.hooks.ErasureLog = append(c.hooks.ErasureLog, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `erasurelog.Intercept(f(g(h())))`.
+func (c *ErasureLogClient) Intercept(interceptors ...Interceptor) {
+	c.inters.ErasureLog = append(c.inters.ErasureLog, interceptors...)
+}
+
+// Create returns a builder for creating a ErasureLog entity.
+func (c *ErasureLogClient) Create() *ErasureLogCreate {
+	mutation := newErasureLogMutation(c.config, OpCreate)
+	return &ErasureLogCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ErasureLog entities.
+func (c *ErasureLogClient) CreateBulk(builders ...*ErasureLogCreate) *ErasureLogCreateBulk {
+	return &ErasureLogCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *ErasureLogClient) MapCreateBulk(slice any, setFunc func(*ErasureLogCreate, int)) *ErasureLogCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &ErasureLogCreateBulk{err: fmt.Errorf("calling to ErasureLogClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*ErasureLogCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &ErasureLogCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ErasureLog.
+func (c *ErasureLogClient) Update() *ErasureLogUpdate {
+	mutation := newErasureLogMutation(c.config, OpUpdate)
+	return &ErasureLogUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ErasureLogClient) UpdateOne(el *ErasureLog) *ErasureLogUpdateOne {
+	mutation := newErasureLogMutation(c.config, OpUpdateOne, withErasureLog(el))
+	return &ErasureLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ErasureLogClient) UpdateOneID(id int) *ErasureLogUpdateOne {
+	mutation := newErasureLogMutation(c.config, OpUpdateOne, withErasureLogID(id))
+	return &ErasureLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ErasureLog.
+func (c *ErasureLogClient) Delete() *ErasureLogDelete {
+	mutation := newErasureLogMutation(c.config, OpDelete)
+	return &ErasureLogDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ErasureLogClient) DeleteOne(el *ErasureLog) *ErasureLogDeleteOne {
+	return c.DeleteOneID(el.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *ErasureLogClient) DeleteOneID(id int) *ErasureLogDeleteOne {
+	builder := c.Delete().Where(erasurelog.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ErasureLogDeleteOne{builder}
+}
+
+// Query returns a query builder for ErasureLog.
+func (c *ErasureLogClient) Query() *ErasureLogQuery {
+	return &ErasureLogQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeErasureLog},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a ErasureLog entity by its id.
+func (c *ErasureLogClient) Get(ctx context.Context, id int) (*ErasureLog, error) {
+	return c.Query().Where(erasurelog.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ErasureLogClient) GetX(ctx context.Context, id int) *ErasureLog {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ErasureLogClient) Hooks() []Hook {
+	return c.hooks.ErasureLog
+}
+
+// Interceptors returns the client interceptors.
+func (c *ErasureLogClient) Interceptors() []Interceptor {
+	return c.inters.ErasureLog
+}
+
+func (c *ErasureLogClient) mutate(ctx context.Context, m *ErasureLogMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&ErasureLogCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&ErasureLogUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&ErasureLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&ErasureLogDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown ErasureLog mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		Customer []ent.Hook
+		Customer, ErasureLog []ent.Hook
 	}
 	inters struct {
-		Customer []ent.Interceptor
+		Customer, ErasureLog []ent.Interceptor
 	}
 )
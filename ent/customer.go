@@ -21,6 +21,8 @@ type Customer struct {
 	Name string `json:"name,omitempty"`
 	// Surname holds the value of the "surname" field.
 	Surname string `json:"surname,omitempty"`
+	// SurnameBidx holds the value of the "surname_bidx" field.
+	SurnameBidx string `json:"-"`
 	// Number holds the value of the "number" field.
 	Number int `json:"number,omitempty"`
 	// Gender holds the value of the "gender" field.
@@ -41,10 +43,14 @@ func (*Customer) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case customer.FieldID, customer.FieldNumber, customer.FieldDependants:
 			values[i] = new(sql.NullInt64)
-		case customer.FieldName, customer.FieldSurname, customer.FieldGender, customer.FieldCountry:
+		case customer.FieldSurnameBidx, customer.FieldGender, customer.FieldCountry:
 			values[i] = new(sql.NullString)
+		case customer.FieldName:
+			values[i] = customer.ValueScanner.Name.ScanValue()
+		case customer.FieldSurname:
+			values[i] = customer.ValueScanner.Surname.ScanValue()
 		case customer.FieldBirthDate:
-			values[i] = new(sql.NullTime)
+			values[i] = customer.ValueScanner.BirthDate.ScanValue()
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -67,16 +73,22 @@ func (c *Customer) assignValues(columns []string, values []any) error {
 			}
 			c.ID = int(value.Int64)
 		case customer.FieldName:
-			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field name", values[i])
-			} else if value.Valid {
-				c.Name = value.String
+			if value, err := customer.ValueScanner.Name.FromValue(values[i]); err != nil {
+				return err
+			} else {
+				c.Name = value
 			}
 		case customer.FieldSurname:
+			if value, err := customer.ValueScanner.Surname.FromValue(values[i]); err != nil {
+				return err
+			} else {
+				c.Surname = value
+			}
+		case customer.FieldSurnameBidx:
 			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field surname", values[i])
+				return fmt.Errorf("unexpected type %T for field surname_bidx", values[i])
 			} else if value.Valid {
-				c.Surname = value.String
+				c.SurnameBidx = value.String
 			}
 		case customer.FieldNumber:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
@@ -103,10 +115,10 @@ func (c *Customer) assignValues(columns []string, values []any) error {
 				c.Dependants = int(value.Int64)
 			}
 		case customer.FieldBirthDate:
-			if value, ok := values[i].(*sql.NullTime); !ok {
-				return fmt.Errorf("unexpected type %T for field birth_date", values[i])
-			} else if value.Valid {
-				c.BirthDate = value.Time
+			if value, err := customer.ValueScanner.BirthDate.FromValue(values[i]); err != nil {
+				return err
+			} else {
+				c.BirthDate = value
 			}
 		default:
 			c.selectValues.Set(columns[i], values[i])
@@ -150,6 +162,8 @@ func (c *Customer) String() string {
 	builder.WriteString("surname=")
 	builder.WriteString(c.Surname)
 	builder.WriteString(", ")
+	builder.WriteString("surname_bidx=<sensitive>")
+	builder.WriteString(", ")
 	builder.WriteString("number=")
 	builder.WriteString(fmt.Sprintf("%v", c.Number))
 	builder.WriteString(", ")
@@ -163,7 +177,7 @@ func (c *Customer) String() string {
 	builder.WriteString(fmt.Sprintf("%v", c.Dependants))
 	builder.WriteString(", ")
 	builder.WriteString("birth_date=")
-	builder.WriteString(c.BirthDate.Format(time.ANSIC))
+	builder.WriteString(fmt.Sprintf("%v", c.BirthDate))
 	builder.WriteByte(')')
 	return builder.String()
 }
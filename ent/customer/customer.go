@@ -4,8 +4,11 @@ package customer
 
 import (
 	"fmt"
+	"time"
 
+	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
 )
 
 const (
@@ -17,6 +20,8 @@ const (
 	FieldName = "name"
 	// FieldSurname holds the string denoting the surname field in the database.
 	FieldSurname = "surname"
+	// FieldSurnameBidx holds the string denoting the surname_bidx field in the database.
+	FieldSurnameBidx = "surname_bidx"
 	// FieldNumber holds the string denoting the number field in the database.
 	FieldNumber = "number"
 	// FieldGender holds the string denoting the gender field in the database.
@@ -36,6 +41,7 @@ var Columns = []string{
 	FieldID,
 	FieldName,
 	FieldSurname,
+	FieldSurnameBidx,
 	FieldNumber,
 	FieldGender,
 	FieldCountry,
@@ -53,7 +59,13 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "iohk-golang-backend/ent/runtime"
 var (
+	Hooks [2]ent.Hook
 	// NameValidator is a validator for the "name" field. It is called by the builders before save.
 	NameValidator func(string) error
 	// SurnameValidator is a validator for the "surname" field. It is called by the builders before save.
@@ -68,6 +80,12 @@ var (
 	DependantsValidator func(int) error
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(int) error
+	// ValueScanner of all Customer fields.
+	ValueScanner struct {
+		Name      field.TypeValueScanner[string]
+		Surname   field.TypeValueScanner[string]
+		BirthDate field.TypeValueScanner[time.Time]
+	}
 )
 
 // Gender defines the type for the "gender" enum field.
@@ -111,6 +129,11 @@ func BySurname(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSurname, opts...).ToFunc()
 }
 
+// BySurnameBidx orders the results by the surname_bidx field.
+func BySurnameBidx(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSurnameBidx, opts...).ToFunc()
+}
+
 // ByNumber orders the results by the number field.
 func ByNumber(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldNumber, opts...).ToFunc()
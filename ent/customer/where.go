@@ -3,6 +3,7 @@
 package customer
 
 import (
+	"fmt"
 	"iohk-golang-backend/ent/predicate"
 	"time"
 
@@ -56,12 +57,19 @@ func IDLTE(id int) predicate.Customer {
 
 // Name applies equality check predicate on the "name" field. It's identical to NameEQ.
 func Name(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldEQ(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldEQ(FieldName, vc), err)
 }
 
 // Surname applies equality check predicate on the "surname" field. It's identical to SurnameEQ.
 func Surname(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldEQ(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldEQ(FieldSurname, vc), err)
+}
+
+// SurnameBidx applies equality check predicate on the "surname_bidx" field. It's identical to SurnameBidxEQ.
+func SurnameBidx(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldEQ(FieldSurnameBidx, v))
 }
 
 // Number applies equality check predicate on the "number" field. It's identical to NumberEQ.
@@ -81,137 +89,311 @@ func Dependants(v int) predicate.Customer {
 
 // BirthDate applies equality check predicate on the "birth_date" field. It's identical to BirthDateEQ.
 func BirthDate(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldEQ(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldEQ(FieldBirthDate, vc), err)
 }
 
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldEQ(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldEQ(FieldName, vc), err)
 }
 
 // NameNEQ applies the NEQ predicate on the "name" field.
 func NameNEQ(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldNEQ(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldNEQ(FieldName, vc), err)
 }
 
 // NameIn applies the In predicate on the "name" field.
 func NameIn(vs ...string) predicate.Customer {
-	return predicate.Customer(sql.FieldIn(FieldName, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.Name.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.CustomerOrErr(sql.FieldIn(FieldName, v...), err)
 }
 
 // NameNotIn applies the NotIn predicate on the "name" field.
 func NameNotIn(vs ...string) predicate.Customer {
-	return predicate.Customer(sql.FieldNotIn(FieldName, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.Name.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.CustomerOrErr(sql.FieldNotIn(FieldName, v...), err)
 }
 
 // NameGT applies the GT predicate on the "name" field.
 func NameGT(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldGT(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldGT(FieldName, vc), err)
 }
 
 // NameGTE applies the GTE predicate on the "name" field.
 func NameGTE(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldGTE(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldGTE(FieldName, vc), err)
 }
 
 // NameLT applies the LT predicate on the "name" field.
 func NameLT(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldLT(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldLT(FieldName, vc), err)
 }
 
 // NameLTE applies the LTE predicate on the "name" field.
 func NameLTE(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldLTE(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	return predicate.CustomerOrErr(sql.FieldLTE(FieldName, vc), err)
 }
 
 // NameContains applies the Contains predicate on the "name" field.
 func NameContains(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldContains(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("name value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldContains(FieldName, vcs), err)
 }
 
 // NameHasPrefix applies the HasPrefix predicate on the "name" field.
 func NameHasPrefix(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldHasPrefix(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("name value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldHasPrefix(FieldName, vcs), err)
 }
 
 // NameHasSuffix applies the HasSuffix predicate on the "name" field.
 func NameHasSuffix(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldHasSuffix(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("name value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldHasSuffix(FieldName, vcs), err)
 }
 
 // NameEqualFold applies the EqualFold predicate on the "name" field.
 func NameEqualFold(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldEqualFold(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("name value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldEqualFold(FieldName, vcs), err)
 }
 
 // NameContainsFold applies the ContainsFold predicate on the "name" field.
 func NameContainsFold(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldContainsFold(FieldName, v))
+	vc, err := ValueScanner.Name.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("name value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldContainsFold(FieldName, vcs), err)
 }
 
 // SurnameEQ applies the EQ predicate on the "surname" field.
 func SurnameEQ(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldEQ(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldEQ(FieldSurname, vc), err)
 }
 
 // SurnameNEQ applies the NEQ predicate on the "surname" field.
 func SurnameNEQ(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldNEQ(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldNEQ(FieldSurname, vc), err)
 }
 
 // SurnameIn applies the In predicate on the "surname" field.
 func SurnameIn(vs ...string) predicate.Customer {
-	return predicate.Customer(sql.FieldIn(FieldSurname, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.Surname.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.CustomerOrErr(sql.FieldIn(FieldSurname, v...), err)
 }
 
 // SurnameNotIn applies the NotIn predicate on the "surname" field.
 func SurnameNotIn(vs ...string) predicate.Customer {
-	return predicate.Customer(sql.FieldNotIn(FieldSurname, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.Surname.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.CustomerOrErr(sql.FieldNotIn(FieldSurname, v...), err)
 }
 
 // SurnameGT applies the GT predicate on the "surname" field.
 func SurnameGT(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldGT(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldGT(FieldSurname, vc), err)
 }
 
 // SurnameGTE applies the GTE predicate on the "surname" field.
 func SurnameGTE(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldGTE(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldGTE(FieldSurname, vc), err)
 }
 
 // SurnameLT applies the LT predicate on the "surname" field.
 func SurnameLT(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldLT(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldLT(FieldSurname, vc), err)
 }
 
 // SurnameLTE applies the LTE predicate on the "surname" field.
 func SurnameLTE(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldLTE(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	return predicate.CustomerOrErr(sql.FieldLTE(FieldSurname, vc), err)
 }
 
 // SurnameContains applies the Contains predicate on the "surname" field.
 func SurnameContains(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldContains(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("surname value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldContains(FieldSurname, vcs), err)
 }
 
 // SurnameHasPrefix applies the HasPrefix predicate on the "surname" field.
 func SurnameHasPrefix(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldHasPrefix(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("surname value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldHasPrefix(FieldSurname, vcs), err)
 }
 
 // SurnameHasSuffix applies the HasSuffix predicate on the "surname" field.
 func SurnameHasSuffix(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldHasSuffix(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("surname value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldHasSuffix(FieldSurname, vcs), err)
 }
 
 // SurnameEqualFold applies the EqualFold predicate on the "surname" field.
 func SurnameEqualFold(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldEqualFold(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("surname value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldEqualFold(FieldSurname, vcs), err)
 }
 
 // SurnameContainsFold applies the ContainsFold predicate on the "surname" field.
 func SurnameContainsFold(v string) predicate.Customer {
-	return predicate.Customer(sql.FieldContainsFold(FieldSurname, v))
+	vc, err := ValueScanner.Surname.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("surname value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldContainsFold(FieldSurname, vcs), err)
+}
+
+// SurnameBidxEQ applies the EQ predicate on the "surname_bidx" field.
+func SurnameBidxEQ(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldEQ(FieldSurnameBidx, v))
+}
+
+// SurnameBidxNEQ applies the NEQ predicate on the "surname_bidx" field.
+func SurnameBidxNEQ(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldNEQ(FieldSurnameBidx, v))
+}
+
+// SurnameBidxIn applies the In predicate on the "surname_bidx" field.
+func SurnameBidxIn(vs ...string) predicate.Customer {
+	return predicate.Customer(sql.FieldIn(FieldSurnameBidx, vs...))
+}
+
+// SurnameBidxNotIn applies the NotIn predicate on the "surname_bidx" field.
+func SurnameBidxNotIn(vs ...string) predicate.Customer {
+	return predicate.Customer(sql.FieldNotIn(FieldSurnameBidx, vs...))
+}
+
+// SurnameBidxGT applies the GT predicate on the "surname_bidx" field.
+func SurnameBidxGT(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldGT(FieldSurnameBidx, v))
+}
+
+// SurnameBidxGTE applies the GTE predicate on the "surname_bidx" field.
+func SurnameBidxGTE(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldGTE(FieldSurnameBidx, v))
+}
+
+// SurnameBidxLT applies the LT predicate on the "surname_bidx" field.
+func SurnameBidxLT(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldLT(FieldSurnameBidx, v))
+}
+
+// SurnameBidxLTE applies the LTE predicate on the "surname_bidx" field.
+func SurnameBidxLTE(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldLTE(FieldSurnameBidx, v))
+}
+
+// SurnameBidxContains applies the Contains predicate on the "surname_bidx" field.
+func SurnameBidxContains(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldContains(FieldSurnameBidx, v))
+}
+
+// SurnameBidxHasPrefix applies the HasPrefix predicate on the "surname_bidx" field.
+func SurnameBidxHasPrefix(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldHasPrefix(FieldSurnameBidx, v))
+}
+
+// SurnameBidxHasSuffix applies the HasSuffix predicate on the "surname_bidx" field.
+func SurnameBidxHasSuffix(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldHasSuffix(FieldSurnameBidx, v))
+}
+
+// SurnameBidxIsNil applies the IsNil predicate on the "surname_bidx" field.
+func SurnameBidxIsNil() predicate.Customer {
+	return predicate.Customer(sql.FieldIsNull(FieldSurnameBidx))
+}
+
+// SurnameBidxNotNil applies the NotNil predicate on the "surname_bidx" field.
+func SurnameBidxNotNil() predicate.Customer {
+	return predicate.Customer(sql.FieldNotNull(FieldSurnameBidx))
+}
+
+// SurnameBidxEqualFold applies the EqualFold predicate on the "surname_bidx" field.
+func SurnameBidxEqualFold(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldEqualFold(FieldSurnameBidx, v))
+}
+
+// SurnameBidxContainsFold applies the ContainsFold predicate on the "surname_bidx" field.
+func SurnameBidxContainsFold(v string) predicate.Customer {
+	return predicate.Customer(sql.FieldContainsFold(FieldSurnameBidx, v))
 }
 
 // NumberEQ applies the EQ predicate on the "number" field.
@@ -381,42 +563,116 @@ func DependantsLTE(v int) predicate.Customer {
 
 // BirthDateEQ applies the EQ predicate on the "birth_date" field.
 func BirthDateEQ(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldEQ(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldEQ(FieldBirthDate, vc), err)
 }
 
 // BirthDateNEQ applies the NEQ predicate on the "birth_date" field.
 func BirthDateNEQ(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldNEQ(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldNEQ(FieldBirthDate, vc), err)
 }
 
 // BirthDateIn applies the In predicate on the "birth_date" field.
 func BirthDateIn(vs ...time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldIn(FieldBirthDate, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.BirthDate.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.CustomerOrErr(sql.FieldIn(FieldBirthDate, v...), err)
 }
 
 // BirthDateNotIn applies the NotIn predicate on the "birth_date" field.
 func BirthDateNotIn(vs ...time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldNotIn(FieldBirthDate, vs...))
+	var (
+		err error
+		v   = make([]any, len(vs))
+	)
+	for i := range v {
+		if v[i], err = ValueScanner.BirthDate.Value(vs[i]); err != nil {
+			break
+		}
+	}
+	return predicate.CustomerOrErr(sql.FieldNotIn(FieldBirthDate, v...), err)
 }
 
 // BirthDateGT applies the GT predicate on the "birth_date" field.
 func BirthDateGT(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldGT(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldGT(FieldBirthDate, vc), err)
 }
 
 // BirthDateGTE applies the GTE predicate on the "birth_date" field.
 func BirthDateGTE(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldGTE(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldGTE(FieldBirthDate, vc), err)
 }
 
 // BirthDateLT applies the LT predicate on the "birth_date" field.
 func BirthDateLT(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldLT(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldLT(FieldBirthDate, vc), err)
 }
 
 // BirthDateLTE applies the LTE predicate on the "birth_date" field.
 func BirthDateLTE(v time.Time) predicate.Customer {
-	return predicate.Customer(sql.FieldLTE(FieldBirthDate, v))
+	vc, err := ValueScanner.BirthDate.Value(v)
+	return predicate.CustomerOrErr(sql.FieldLTE(FieldBirthDate, vc), err)
+}
+
+// BirthDateContains applies the Contains predicate on the "birth_date" field.
+func BirthDateContains(v time.Time) predicate.Customer {
+	vc, err := ValueScanner.BirthDate.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("birth_date value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldContains(FieldBirthDate, vcs), err)
+}
+
+// BirthDateHasPrefix applies the HasPrefix predicate on the "birth_date" field.
+func BirthDateHasPrefix(v time.Time) predicate.Customer {
+	vc, err := ValueScanner.BirthDate.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("birth_date value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldHasPrefix(FieldBirthDate, vcs), err)
+}
+
+// BirthDateHasSuffix applies the HasSuffix predicate on the "birth_date" field.
+func BirthDateHasSuffix(v time.Time) predicate.Customer {
+	vc, err := ValueScanner.BirthDate.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("birth_date value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldHasSuffix(FieldBirthDate, vcs), err)
+}
+
+// BirthDateEqualFold applies the EqualFold predicate on the "birth_date" field.
+func BirthDateEqualFold(v time.Time) predicate.Customer {
+	vc, err := ValueScanner.BirthDate.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("birth_date value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldEqualFold(FieldBirthDate, vcs), err)
+}
+
+// BirthDateContainsFold applies the ContainsFold predicate on the "birth_date" field.
+func BirthDateContainsFold(v time.Time) predicate.Customer {
+	vc, err := ValueScanner.BirthDate.Value(v)
+	vcs, ok := vc.(string)
+	if err == nil && !ok {
+		err = fmt.Errorf("birth_date value is not a string: %T", vc)
+	}
+	return predicate.CustomerOrErr(sql.FieldContainsFold(FieldBirthDate, vcs), err)
 }
 
 // And groups predicates with the AND operator between them.
@@ -32,6 +32,20 @@ func (cc *CustomerCreate) SetSurname(s string) *CustomerCreate {
 	return cc
 }
 
+// SetSurnameBidx sets the "surname_bidx" field.
+func (cc *CustomerCreate) SetSurnameBidx(s string) *CustomerCreate {
+	cc.mutation.SetSurnameBidx(s)
+	return cc
+}
+
+// SetNillableSurnameBidx sets the "surname_bidx" field if the given value is not nil.
+func (cc *CustomerCreate) SetNillableSurnameBidx(s *string) *CustomerCreate {
+	if s != nil {
+		cc.SetSurnameBidx(*s)
+	}
+	return cc
+}
+
 // SetNumber sets the "number" field.
 func (cc *CustomerCreate) SetNumber(i int) *CustomerCreate {
 	cc.mutation.SetNumber(i)
@@ -83,7 +97,9 @@ func (cc *CustomerCreate) Mutation() *CustomerMutation {
 
 // Save creates the Customer in the database.
 func (cc *CustomerCreate) Save(ctx context.Context) (*Customer, error) {
-	cc.defaults()
+	if err := cc.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
 }
 
@@ -110,11 +126,12 @@ func (cc *CustomerCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (cc *CustomerCreate) defaults() {
+func (cc *CustomerCreate) defaults() error {
 	if _, ok := cc.mutation.Dependants(); !ok {
 		v := customer.DefaultDependants
 		cc.mutation.SetDependants(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -182,7 +199,10 @@ func (cc *CustomerCreate) sqlSave(ctx context.Context) (*Customer, error) {
 	if err := cc.check(); err != nil {
 		return nil, err
 	}
-	_node, _spec := cc.createSpec()
+	_node, _spec, err := cc.createSpec()
+	if err != nil {
+		return nil, err
+	}
 	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
 		if sqlgraph.IsConstraintError(err) {
 			err = &ConstraintError{msg: err.Error(), wrap: err}
@@ -198,7 +218,7 @@ func (cc *CustomerCreate) sqlSave(ctx context.Context) (*Customer, error) {
 	return _node, nil
 }
 
-func (cc *CustomerCreate) createSpec() (*Customer, *sqlgraph.CreateSpec) {
+func (cc *CustomerCreate) createSpec() (*Customer, *sqlgraph.CreateSpec, error) {
 	var (
 		_node = &Customer{config: cc.config}
 		_spec = sqlgraph.NewCreateSpec(customer.Table, sqlgraph.NewFieldSpec(customer.FieldID, field.TypeInt))
@@ -208,13 +228,25 @@ func (cc *CustomerCreate) createSpec() (*Customer, *sqlgraph.CreateSpec) {
 		_spec.ID.Value = id
 	}
 	if value, ok := cc.mutation.Name(); ok {
-		_spec.SetField(customer.FieldName, field.TypeString, value)
+		vv, err := customer.ValueScanner.Name.Value(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		_spec.SetField(customer.FieldName, field.TypeString, vv)
 		_node.Name = value
 	}
 	if value, ok := cc.mutation.Surname(); ok {
-		_spec.SetField(customer.FieldSurname, field.TypeString, value)
+		vv, err := customer.ValueScanner.Surname.Value(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		_spec.SetField(customer.FieldSurname, field.TypeString, vv)
 		_node.Surname = value
 	}
+	if value, ok := cc.mutation.SurnameBidx(); ok {
+		_spec.SetField(customer.FieldSurnameBidx, field.TypeString, value)
+		_node.SurnameBidx = value
+	}
 	if value, ok := cc.mutation.Number(); ok {
 		_spec.SetField(customer.FieldNumber, field.TypeInt, value)
 		_node.Number = value
@@ -232,10 +264,14 @@ func (cc *CustomerCreate) createSpec() (*Customer, *sqlgraph.CreateSpec) {
 		_node.Dependants = value
 	}
 	if value, ok := cc.mutation.BirthDate(); ok {
-		_spec.SetField(customer.FieldBirthDate, field.TypeTime, value)
+		vv, err := customer.ValueScanner.BirthDate.Value(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		_spec.SetField(customer.FieldBirthDate, field.TypeString, vv)
 		_node.BirthDate = value
 	}
-	return _node, _spec
+	return _node, _spec, nil
 }
 
 // CustomerCreateBulk is the builder for creating many Customer entities in bulk.
@@ -267,7 +303,10 @@ func (ccb *CustomerCreateBulk) Save(ctx context.Context) ([]*Customer, error) {
 				}
 				builder.mutation = mutation
 				var err error
-				nodes[i], specs[i] = builder.createSpec()
+				nodes[i], specs[i], err = builder.createSpec()
+				if err != nil {
+					return nil, err
+				}
 				if i < len(mutators)-1 {
 					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
 				} else {
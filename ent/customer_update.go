@@ -56,6 +56,26 @@ func (cu *CustomerUpdate) SetNillableSurname(s *string) *CustomerUpdate {
 	return cu
 }
 
+// SetSurnameBidx sets the "surname_bidx" field.
+func (cu *CustomerUpdate) SetSurnameBidx(s string) *CustomerUpdate {
+	cu.mutation.SetSurnameBidx(s)
+	return cu
+}
+
+// SetNillableSurnameBidx sets the "surname_bidx" field if the given value is not nil.
+func (cu *CustomerUpdate) SetNillableSurnameBidx(s *string) *CustomerUpdate {
+	if s != nil {
+		cu.SetSurnameBidx(*s)
+	}
+	return cu
+}
+
+// ClearSurnameBidx clears the value of the "surname_bidx" field.
+func (cu *CustomerUpdate) ClearSurnameBidx() *CustomerUpdate {
+	cu.mutation.ClearSurnameBidx()
+	return cu
+}
+
 // SetNumber sets the "number" field.
 func (cu *CustomerUpdate) SetNumber(i int) *CustomerUpdate {
 	cu.mutation.ResetNumber()
@@ -220,10 +240,24 @@ func (cu *CustomerUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 	}
 	if value, ok := cu.mutation.Name(); ok {
-		_spec.SetField(customer.FieldName, field.TypeString, value)
+		vv, err := customer.ValueScanner.Name.Value(value)
+		if err != nil {
+			return 0, err
+		}
+		_spec.SetField(customer.FieldName, field.TypeString, vv)
 	}
 	if value, ok := cu.mutation.Surname(); ok {
-		_spec.SetField(customer.FieldSurname, field.TypeString, value)
+		vv, err := customer.ValueScanner.Surname.Value(value)
+		if err != nil {
+			return 0, err
+		}
+		_spec.SetField(customer.FieldSurname, field.TypeString, vv)
+	}
+	if value, ok := cu.mutation.SurnameBidx(); ok {
+		_spec.SetField(customer.FieldSurnameBidx, field.TypeString, value)
+	}
+	if cu.mutation.SurnameBidxCleared() {
+		_spec.ClearField(customer.FieldSurnameBidx, field.TypeString)
 	}
 	if value, ok := cu.mutation.Number(); ok {
 		_spec.SetField(customer.FieldNumber, field.TypeInt, value)
@@ -244,7 +278,11 @@ func (cu *CustomerUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		_spec.AddField(customer.FieldDependants, field.TypeInt, value)
 	}
 	if value, ok := cu.mutation.BirthDate(); ok {
-		_spec.SetField(customer.FieldBirthDate, field.TypeTime, value)
+		vv, err := customer.ValueScanner.BirthDate.Value(value)
+		if err != nil {
+			return 0, err
+		}
+		_spec.SetField(customer.FieldBirthDate, field.TypeString, vv)
 	}
 	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
@@ -294,6 +332,26 @@ func (cuo *CustomerUpdateOne) SetNillableSurname(s *string) *CustomerUpdateOne {
 	return cuo
 }
 
+// SetSurnameBidx sets the "surname_bidx" field.
+func (cuo *CustomerUpdateOne) SetSurnameBidx(s string) *CustomerUpdateOne {
+	cuo.mutation.SetSurnameBidx(s)
+	return cuo
+}
+
+// SetNillableSurnameBidx sets the "surname_bidx" field if the given value is not nil.
+func (cuo *CustomerUpdateOne) SetNillableSurnameBidx(s *string) *CustomerUpdateOne {
+	if s != nil {
+		cuo.SetSurnameBidx(*s)
+	}
+	return cuo
+}
+
+// ClearSurnameBidx clears the value of the "surname_bidx" field.
+func (cuo *CustomerUpdateOne) ClearSurnameBidx() *CustomerUpdateOne {
+	cuo.mutation.ClearSurnameBidx()
+	return cuo
+}
+
 // SetNumber sets the "number" field.
 func (cuo *CustomerUpdateOne) SetNumber(i int) *CustomerUpdateOne {
 	cuo.mutation.ResetNumber()
@@ -488,10 +546,24 @@ func (cuo *CustomerUpdateOne) sqlSave(ctx context.Context) (_node *Customer, err
 		}
 	}
 	if value, ok := cuo.mutation.Name(); ok {
-		_spec.SetField(customer.FieldName, field.TypeString, value)
+		vv, err := customer.ValueScanner.Name.Value(value)
+		if err != nil {
+			return nil, err
+		}
+		_spec.SetField(customer.FieldName, field.TypeString, vv)
 	}
 	if value, ok := cuo.mutation.Surname(); ok {
-		_spec.SetField(customer.FieldSurname, field.TypeString, value)
+		vv, err := customer.ValueScanner.Surname.Value(value)
+		if err != nil {
+			return nil, err
+		}
+		_spec.SetField(customer.FieldSurname, field.TypeString, vv)
+	}
+	if value, ok := cuo.mutation.SurnameBidx(); ok {
+		_spec.SetField(customer.FieldSurnameBidx, field.TypeString, value)
+	}
+	if cuo.mutation.SurnameBidxCleared() {
+		_spec.ClearField(customer.FieldSurnameBidx, field.TypeString)
 	}
 	if value, ok := cuo.mutation.Number(); ok {
 		_spec.SetField(customer.FieldNumber, field.TypeInt, value)
@@ -512,7 +584,11 @@ func (cuo *CustomerUpdateOne) sqlSave(ctx context.Context) (_node *Customer, err
 		_spec.AddField(customer.FieldDependants, field.TypeInt, value)
 	}
 	if value, ok := cuo.mutation.BirthDate(); ok {
-		_spec.SetField(customer.FieldBirthDate, field.TypeTime, value)
+		vv, err := customer.ValueScanner.BirthDate.Value(value)
+		if err != nil {
+			return nil, err
+		}
+		_spec.SetField(customer.FieldBirthDate, field.TypeString, vv)
 	}
 	_node = &Customer{config: cuo.config}
 	_spec.Assign = _node.assignValues
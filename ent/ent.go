@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/ent/erasurelog"
 	"reflect"
 	"sync"
 
@@ -73,7 +74,8 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			customer.Table: customer.ValidColumn,
+			customer.Table:   customer.ValidColumn,
+			erasurelog.Table: erasurelog.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -0,0 +1,161 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"iohk-golang-backend/ent/erasurelog"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ErasureLog is the model entity for the ErasureLog schema.
+type ErasureLog struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// CustomerID holds the value of the "customer_id" field.
+	CustomerID int `json:"customer_id,omitempty"`
+	// Mode holds the value of the "mode" field.
+	Mode erasurelog.Mode `json:"mode,omitempty"`
+	// RequestedBy holds the value of the "requested_by" field.
+	RequestedBy string `json:"requested_by,omitempty"`
+	// ErasedAt holds the value of the "erased_at" field.
+	ErasedAt time.Time `json:"erased_at,omitempty"`
+	// PrevHash holds the value of the "prev_hash" field.
+	PrevHash string `json:"prev_hash,omitempty"`
+	// Hash holds the value of the "hash" field.
+	Hash         string `json:"hash,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ErasureLog) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case erasurelog.FieldID, erasurelog.FieldCustomerID:
+			values[i] = new(sql.NullInt64)
+		case erasurelog.FieldMode, erasurelog.FieldRequestedBy, erasurelog.FieldPrevHash, erasurelog.FieldHash:
+			values[i] = new(sql.NullString)
+		case erasurelog.FieldErasedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ErasureLog fields.
+func (el *ErasureLog) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case erasurelog.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			el.ID = int(value.Int64)
+		case erasurelog.FieldCustomerID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field customer_id", values[i])
+			} else if value.Valid {
+				el.CustomerID = int(value.Int64)
+			}
+		case erasurelog.FieldMode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field mode", values[i])
+			} else if value.Valid {
+				el.Mode = erasurelog.Mode(value.String)
+			}
+		case erasurelog.FieldRequestedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field requested_by", values[i])
+			} else if value.Valid {
+				el.RequestedBy = value.String
+			}
+		case erasurelog.FieldErasedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field erased_at", values[i])
+			} else if value.Valid {
+				el.ErasedAt = value.Time
+			}
+		case erasurelog.FieldPrevHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prev_hash", values[i])
+			} else if value.Valid {
+				el.PrevHash = value.String
+			}
+		case erasurelog.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				el.Hash = value.String
+			}
+		default:
+			el.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ErasureLog.
+// This includes values selected through modifiers, order, etc.
+func (el *ErasureLog) Value(name string) (ent.Value, error) {
+	return el.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ErasureLog.
+// Note that you need to call ErasureLog.Unwrap() before calling this method if this ErasureLog
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (el *ErasureLog) Update() *ErasureLogUpdateOne {
+	return NewErasureLogClient(el.config).UpdateOne(el)
+}
+
+// Unwrap unwraps the ErasureLog entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (el *ErasureLog) Unwrap() *ErasureLog {
+	_tx, ok := el.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ErasureLog is not a transactional entity")
+	}
+	el.config.driver = _tx.drv
+	return el
+}
+
+// String implements the fmt.Stringer.
+func (el *ErasureLog) String() string {
+	var builder strings.Builder
+	builder.WriteString("ErasureLog(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", el.ID))
+	builder.WriteString("customer_id=")
+	builder.WriteString(fmt.Sprintf("%v", el.CustomerID))
+	builder.WriteString(", ")
+	builder.WriteString("mode=")
+	builder.WriteString(fmt.Sprintf("%v", el.Mode))
+	builder.WriteString(", ")
+	builder.WriteString("requested_by=")
+	builder.WriteString(el.RequestedBy)
+	builder.WriteString(", ")
+	builder.WriteString("erased_at=")
+	builder.WriteString(el.ErasedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("prev_hash=")
+	builder.WriteString(el.PrevHash)
+	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(el.Hash)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ErasureLogs is a parsable slice of ErasureLog.
+type ErasureLogs []*ErasureLog
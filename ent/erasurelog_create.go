@@ -0,0 +1,274 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iohk-golang-backend/ent/erasurelog"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ErasureLogCreate is the builder for creating a ErasureLog entity.
+type ErasureLogCreate struct {
+	config
+	mutation *ErasureLogMutation
+	hooks    []Hook
+}
+
+// SetCustomerID sets the "customer_id" field.
+func (elc *ErasureLogCreate) SetCustomerID(i int) *ErasureLogCreate {
+	elc.mutation.SetCustomerID(i)
+	return elc
+}
+
+// SetMode sets the "mode" field.
+func (elc *ErasureLogCreate) SetMode(e erasurelog.Mode) *ErasureLogCreate {
+	elc.mutation.SetMode(e)
+	return elc
+}
+
+// SetRequestedBy sets the "requested_by" field.
+func (elc *ErasureLogCreate) SetRequestedBy(s string) *ErasureLogCreate {
+	elc.mutation.SetRequestedBy(s)
+	return elc
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (elc *ErasureLogCreate) SetErasedAt(t time.Time) *ErasureLogCreate {
+	elc.mutation.SetErasedAt(t)
+	return elc
+}
+
+// SetPrevHash sets the "prev_hash" field.
+func (elc *ErasureLogCreate) SetPrevHash(s string) *ErasureLogCreate {
+	elc.mutation.SetPrevHash(s)
+	return elc
+}
+
+// SetHash sets the "hash" field.
+func (elc *ErasureLogCreate) SetHash(s string) *ErasureLogCreate {
+	elc.mutation.SetHash(s)
+	return elc
+}
+
+// Mutation returns the ErasureLogMutation object of the builder.
+func (elc *ErasureLogCreate) Mutation() *ErasureLogMutation {
+	return elc.mutation
+}
+
+// Save creates the ErasureLog in the database.
+func (elc *ErasureLogCreate) Save(ctx context.Context) (*ErasureLog, error) {
+	return withHooks(ctx, elc.sqlSave, elc.mutation, elc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (elc *ErasureLogCreate) SaveX(ctx context.Context) *ErasureLog {
+	v, err := elc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (elc *ErasureLogCreate) Exec(ctx context.Context) error {
+	_, err := elc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (elc *ErasureLogCreate) ExecX(ctx context.Context) {
+	if err := elc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (elc *ErasureLogCreate) check() error {
+	if _, ok := elc.mutation.CustomerID(); !ok {
+		return &ValidationError{Name: "customer_id", err: errors.New(`ent: missing required field "ErasureLog.customer_id"`)}
+	}
+	if v, ok := elc.mutation.CustomerID(); ok {
+		if err := erasurelog.CustomerIDValidator(v); err != nil {
+			return &ValidationError{Name: "customer_id", err: fmt.Errorf(`ent: validator failed for field "ErasureLog.customer_id": %w`, err)}
+		}
+	}
+	if _, ok := elc.mutation.Mode(); !ok {
+		return &ValidationError{Name: "mode", err: errors.New(`ent: missing required field "ErasureLog.mode"`)}
+	}
+	if v, ok := elc.mutation.Mode(); ok {
+		if err := erasurelog.ModeValidator(v); err != nil {
+			return &ValidationError{Name: "mode", err: fmt.Errorf(`ent: validator failed for field "ErasureLog.mode": %w`, err)}
+		}
+	}
+	if _, ok := elc.mutation.RequestedBy(); !ok {
+		return &ValidationError{Name: "requested_by", err: errors.New(`ent: missing required field "ErasureLog.requested_by"`)}
+	}
+	if v, ok := elc.mutation.RequestedBy(); ok {
+		if err := erasurelog.RequestedByValidator(v); err != nil {
+			return &ValidationError{Name: "requested_by", err: fmt.Errorf(`ent: validator failed for field "ErasureLog.requested_by": %w`, err)}
+		}
+	}
+	if _, ok := elc.mutation.ErasedAt(); !ok {
+		return &ValidationError{Name: "erased_at", err: errors.New(`ent: missing required field "ErasureLog.erased_at"`)}
+	}
+	if _, ok := elc.mutation.PrevHash(); !ok {
+		return &ValidationError{Name: "prev_hash", err: errors.New(`ent: missing required field "ErasureLog.prev_hash"`)}
+	}
+	if v, ok := elc.mutation.PrevHash(); ok {
+		if err := erasurelog.PrevHashValidator(v); err != nil {
+			return &ValidationError{Name: "prev_hash", err: fmt.Errorf(`ent: validator failed for field "ErasureLog.prev_hash": %w`, err)}
+		}
+	}
+	if _, ok := elc.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`ent: missing required field "ErasureLog.hash"`)}
+	}
+	if v, ok := elc.mutation.Hash(); ok {
+		if err := erasurelog.HashValidator(v); err != nil {
+			return &ValidationError{Name: "hash", err: fmt.Errorf(`ent: validator failed for field "ErasureLog.hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (elc *ErasureLogCreate) sqlSave(ctx context.Context) (*ErasureLog, error) {
+	if err := elc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := elc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, elc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	elc.mutation.id = &_node.ID
+	elc.mutation.done = true
+	return _node, nil
+}
+
+func (elc *ErasureLogCreate) createSpec() (*ErasureLog, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ErasureLog{config: elc.config}
+		_spec = sqlgraph.NewCreateSpec(erasurelog.Table, sqlgraph.NewFieldSpec(erasurelog.FieldID, field.TypeInt))
+	)
+	if value, ok := elc.mutation.CustomerID(); ok {
+		_spec.SetField(erasurelog.FieldCustomerID, field.TypeInt, value)
+		_node.CustomerID = value
+	}
+	if value, ok := elc.mutation.Mode(); ok {
+		_spec.SetField(erasurelog.FieldMode, field.TypeEnum, value)
+		_node.Mode = value
+	}
+	if value, ok := elc.mutation.RequestedBy(); ok {
+		_spec.SetField(erasurelog.FieldRequestedBy, field.TypeString, value)
+		_node.RequestedBy = value
+	}
+	if value, ok := elc.mutation.ErasedAt(); ok {
+		_spec.SetField(erasurelog.FieldErasedAt, field.TypeTime, value)
+		_node.ErasedAt = value
+	}
+	if value, ok := elc.mutation.PrevHash(); ok {
+		_spec.SetField(erasurelog.FieldPrevHash, field.TypeString, value)
+		_node.PrevHash = value
+	}
+	if value, ok := elc.mutation.Hash(); ok {
+		_spec.SetField(erasurelog.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
+	return _node, _spec
+}
+
+// ErasureLogCreateBulk is the builder for creating many ErasureLog entities in bulk.
+type ErasureLogCreateBulk struct {
+	config
+	err      error
+	builders []*ErasureLogCreate
+}
+
+// Save creates the ErasureLog entities in the database.
+func (elcb *ErasureLogCreateBulk) Save(ctx context.Context) ([]*ErasureLog, error) {
+	if elcb.err != nil {
+		return nil, elcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(elcb.builders))
+	nodes := make([]*ErasureLog, len(elcb.builders))
+	mutators := make([]Mutator, len(elcb.builders))
+	for i := range elcb.builders {
+		func(i int, root context.Context) {
+			builder := elcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ErasureLogMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, elcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, elcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, elcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (elcb *ErasureLogCreateBulk) SaveX(ctx context.Context) []*ErasureLog {
+	v, err := elcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (elcb *ErasureLogCreateBulk) Exec(ctx context.Context) error {
+	_, err := elcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (elcb *ErasureLogCreateBulk) ExecX(ctx context.Context) {
+	if err := elcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
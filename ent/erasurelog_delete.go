@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"iohk-golang-backend/ent/erasurelog"
+	"iohk-golang-backend/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ErasureLogDelete is the builder for deleting a ErasureLog entity.
+type ErasureLogDelete struct {
+	config
+	hooks    []Hook
+	mutation *ErasureLogMutation
+}
+
+// Where appends a list predicates to the ErasureLogDelete builder.
+func (eld *ErasureLogDelete) Where(ps ...predicate.ErasureLog) *ErasureLogDelete {
+	eld.mutation.Where(ps...)
+	return eld
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (eld *ErasureLogDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, eld.sqlExec, eld.mutation, eld.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eld *ErasureLogDelete) ExecX(ctx context.Context) int {
+	n, err := eld.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (eld *ErasureLogDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(erasurelog.Table, sqlgraph.NewFieldSpec(erasurelog.FieldID, field.TypeInt))
+	if ps := eld.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, eld.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	eld.mutation.done = true
+	return affected, err
+}
+
+// ErasureLogDeleteOne is the builder for deleting a single ErasureLog entity.
+type ErasureLogDeleteOne struct {
+	eld *ErasureLogDelete
+}
+
+// Where appends a list predicates to the ErasureLogDelete builder.
+func (eldo *ErasureLogDeleteOne) Where(ps ...predicate.ErasureLog) *ErasureLogDeleteOne {
+	eldo.eld.mutation.Where(ps...)
+	return eldo
+}
+
+// Exec executes the deletion query.
+func (eldo *ErasureLogDeleteOne) Exec(ctx context.Context) error {
+	n, err := eldo.eld.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{erasurelog.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eldo *ErasureLogDeleteOne) ExecX(ctx context.Context) {
+	if err := eldo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,527 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"iohk-golang-backend/ent/erasurelog"
+	"iohk-golang-backend/ent/predicate"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ErasureLogQuery is the builder for querying ErasureLog entities.
+type ErasureLogQuery struct {
+	config
+	ctx        *QueryContext
+	order      []erasurelog.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ErasureLog
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ErasureLogQuery builder.
+func (elq *ErasureLogQuery) Where(ps ...predicate.ErasureLog) *ErasureLogQuery {
+	elq.predicates = append(elq.predicates, ps...)
+	return elq
+}
+
+// Limit the number of records to be returned by this query.
+func (elq *ErasureLogQuery) Limit(limit int) *ErasureLogQuery {
+	elq.ctx.Limit = &limit
+	return elq
+}
+
+// Offset to start from.
+func (elq *ErasureLogQuery) Offset(offset int) *ErasureLogQuery {
+	elq.ctx.Offset = &offset
+	return elq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (elq *ErasureLogQuery) Unique(unique bool) *ErasureLogQuery {
+	elq.ctx.Unique = &unique
+	return elq
+}
+
+// Order specifies how the records should be ordered.
+func (elq *ErasureLogQuery) Order(o ...erasurelog.OrderOption) *ErasureLogQuery {
+	elq.order = append(elq.order, o...)
+	return elq
+}
+
+// First returns the first ErasureLog entity from the query.
+// Returns a *NotFoundError when no ErasureLog was found.
+func (elq *ErasureLogQuery) First(ctx context.Context) (*ErasureLog, error) {
+	nodes, err := elq.Limit(1).All(setContextOp(ctx, elq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{erasurelog.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (elq *ErasureLogQuery) FirstX(ctx context.Context) *ErasureLog {
+	node, err := elq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ErasureLog ID from the query.
+// Returns a *NotFoundError when no ErasureLog ID was found.
+func (elq *ErasureLogQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = elq.Limit(1).IDs(setContextOp(ctx, elq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{erasurelog.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (elq *ErasureLogQuery) FirstIDX(ctx context.Context) int {
+	id, err := elq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ErasureLog entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ErasureLog entity is found.
+// Returns a *NotFoundError when no ErasureLog entities are found.
+func (elq *ErasureLogQuery) Only(ctx context.Context) (*ErasureLog, error) {
+	nodes, err := elq.Limit(2).All(setContextOp(ctx, elq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{erasurelog.Label}
+	default:
+		return nil, &NotSingularError{erasurelog.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (elq *ErasureLogQuery) OnlyX(ctx context.Context) *ErasureLog {
+	node, err := elq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ErasureLog ID in the query.
+// Returns a *NotSingularError when more than one ErasureLog ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (elq *ErasureLogQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = elq.Limit(2).IDs(setContextOp(ctx, elq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{erasurelog.Label}
+	default:
+		err = &NotSingularError{erasurelog.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (elq *ErasureLogQuery) OnlyIDX(ctx context.Context) int {
+	id, err := elq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ErasureLogs.
+func (elq *ErasureLogQuery) All(ctx context.Context) ([]*ErasureLog, error) {
+	ctx = setContextOp(ctx, elq.ctx, ent.OpQueryAll)
+	if err := elq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ErasureLog, *ErasureLogQuery]()
+	return withInterceptors[[]*ErasureLog](ctx, elq, qr, elq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (elq *ErasureLogQuery) AllX(ctx context.Context) []*ErasureLog {
+	nodes, err := elq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ErasureLog IDs.
+func (elq *ErasureLogQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if elq.ctx.Unique == nil && elq.path != nil {
+		elq.Unique(true)
+	}
+	ctx = setContextOp(ctx, elq.ctx, ent.OpQueryIDs)
+	if err = elq.Select(erasurelog.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (elq *ErasureLogQuery) IDsX(ctx context.Context) []int {
+	ids, err := elq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (elq *ErasureLogQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, elq.ctx, ent.OpQueryCount)
+	if err := elq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, elq, querierCount[*ErasureLogQuery](), elq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (elq *ErasureLogQuery) CountX(ctx context.Context) int {
+	count, err := elq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (elq *ErasureLogQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, elq.ctx, ent.OpQueryExist)
+	switch _, err := elq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (elq *ErasureLogQuery) ExistX(ctx context.Context) bool {
+	exist, err := elq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ErasureLogQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (elq *ErasureLogQuery) Clone() *ErasureLogQuery {
+	if elq == nil {
+		return nil
+	}
+	return &ErasureLogQuery{
+		config:     elq.config,
+		ctx:        elq.ctx.Clone(),
+		order:      append([]erasurelog.OrderOption{}, elq.order...),
+		inters:     append([]Interceptor{}, elq.inters...),
+		predicates: append([]predicate.ErasureLog{}, elq.predicates...),
+		// clone intermediate query.
+		sql:  elq.sql.Clone(),
+		path: elq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		CustomerID int `json:"customer_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ErasureLog.Query().
+//		GroupBy(erasurelog.FieldCustomerID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (elq *ErasureLogQuery) GroupBy(field string, fields ...string) *ErasureLogGroupBy {
+	elq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ErasureLogGroupBy{build: elq}
+	grbuild.flds = &elq.ctx.Fields
+	grbuild.label = erasurelog.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		CustomerID int `json:"customer_id,omitempty"`
+//	}
+//
+//	client.ErasureLog.Query().
+//		Select(erasurelog.FieldCustomerID).
+//		Scan(ctx, &v)
+func (elq *ErasureLogQuery) Select(fields ...string) *ErasureLogSelect {
+	elq.ctx.Fields = append(elq.ctx.Fields, fields...)
+	sbuild := &ErasureLogSelect{ErasureLogQuery: elq}
+	sbuild.label = erasurelog.Label
+	sbuild.flds, sbuild.scan = &elq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ErasureLogSelect configured with the given aggregations.
+func (elq *ErasureLogQuery) Aggregate(fns ...AggregateFunc) *ErasureLogSelect {
+	return elq.Select().Aggregate(fns...)
+}
+
+func (elq *ErasureLogQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range elq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, elq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range elq.ctx.Fields {
+		if !erasurelog.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if elq.path != nil {
+		prev, err := elq.path(ctx)
+		if err != nil {
+			return err
+		}
+		elq.sql = prev
+	}
+	return nil
+}
+
+func (elq *ErasureLogQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ErasureLog, error) {
+	var (
+		nodes = []*ErasureLog{}
+		_spec = elq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ErasureLog).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ErasureLog{config: elq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, elq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (elq *ErasureLogQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := elq.querySpec()
+	_spec.Node.Columns = elq.ctx.Fields
+	if len(elq.ctx.Fields) > 0 {
+		_spec.Unique = elq.ctx.Unique != nil && *elq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, elq.driver, _spec)
+}
+
+func (elq *ErasureLogQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(erasurelog.Table, erasurelog.Columns, sqlgraph.NewFieldSpec(erasurelog.FieldID, field.TypeInt))
+	_spec.From = elq.sql
+	if unique := elq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if elq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := elq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, erasurelog.FieldID)
+		for i := range fields {
+			if fields[i] != erasurelog.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := elq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := elq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := elq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := elq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (elq *ErasureLogQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(elq.driver.Dialect())
+	t1 := builder.Table(erasurelog.Table)
+	columns := elq.ctx.Fields
+	if len(columns) == 0 {
+		columns = erasurelog.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if elq.sql != nil {
+		selector = elq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if elq.ctx.Unique != nil && *elq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range elq.predicates {
+		p(selector)
+	}
+	for _, p := range elq.order {
+		p(selector)
+	}
+	if offset := elq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := elq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ErasureLogGroupBy is the group-by builder for ErasureLog entities.
+type ErasureLogGroupBy struct {
+	selector
+	build *ErasureLogQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (elgb *ErasureLogGroupBy) Aggregate(fns ...AggregateFunc) *ErasureLogGroupBy {
+	elgb.fns = append(elgb.fns, fns...)
+	return elgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (elgb *ErasureLogGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, elgb.build.ctx, ent.OpQueryGroupBy)
+	if err := elgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ErasureLogQuery, *ErasureLogGroupBy](ctx, elgb.build, elgb, elgb.build.inters, v)
+}
+
+func (elgb *ErasureLogGroupBy) sqlScan(ctx context.Context, root *ErasureLogQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(elgb.fns))
+	for _, fn := range elgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*elgb.flds)+len(elgb.fns))
+		for _, f := range *elgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*elgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := elgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ErasureLogSelect is the builder for selecting fields of ErasureLog entities.
+type ErasureLogSelect struct {
+	*ErasureLogQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (els *ErasureLogSelect) Aggregate(fns ...AggregateFunc) *ErasureLogSelect {
+	els.fns = append(els.fns, fns...)
+	return els
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (els *ErasureLogSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, els.ctx, ent.OpQuerySelect)
+	if err := els.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ErasureLogQuery, *ErasureLogSelect](ctx, els.ErasureLogQuery, els, els.inters, v)
+}
+
+func (els *ErasureLogSelect) sqlScan(ctx context.Context, root *ErasureLogQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(els.fns))
+	for _, fn := range els.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*els.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := els.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,175 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iohk-golang-backend/ent/erasurelog"
+	"iohk-golang-backend/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ErasureLogUpdate is the builder for updating ErasureLog entities.
+type ErasureLogUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ErasureLogMutation
+}
+
+// Where appends a list predicates to the ErasureLogUpdate builder.
+func (elu *ErasureLogUpdate) Where(ps ...predicate.ErasureLog) *ErasureLogUpdate {
+	elu.mutation.Where(ps...)
+	return elu
+}
+
+// Mutation returns the ErasureLogMutation object of the builder.
+func (elu *ErasureLogUpdate) Mutation() *ErasureLogMutation {
+	return elu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (elu *ErasureLogUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, elu.sqlSave, elu.mutation, elu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (elu *ErasureLogUpdate) SaveX(ctx context.Context) int {
+	affected, err := elu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (elu *ErasureLogUpdate) Exec(ctx context.Context) error {
+	_, err := elu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (elu *ErasureLogUpdate) ExecX(ctx context.Context) {
+	if err := elu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (elu *ErasureLogUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(erasurelog.Table, erasurelog.Columns, sqlgraph.NewFieldSpec(erasurelog.FieldID, field.TypeInt))
+	if ps := elu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, elu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{erasurelog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	elu.mutation.done = true
+	return n, nil
+}
+
+// ErasureLogUpdateOne is the builder for updating a single ErasureLog entity.
+type ErasureLogUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ErasureLogMutation
+}
+
+// Mutation returns the ErasureLogMutation object of the builder.
+func (eluo *ErasureLogUpdateOne) Mutation() *ErasureLogMutation {
+	return eluo.mutation
+}
+
+// Where appends a list predicates to the ErasureLogUpdate builder.
+func (eluo *ErasureLogUpdateOne) Where(ps ...predicate.ErasureLog) *ErasureLogUpdateOne {
+	eluo.mutation.Where(ps...)
+	return eluo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (eluo *ErasureLogUpdateOne) Select(field string, fields ...string) *ErasureLogUpdateOne {
+	eluo.fields = append([]string{field}, fields...)
+	return eluo
+}
+
+// Save executes the query and returns the updated ErasureLog entity.
+func (eluo *ErasureLogUpdateOne) Save(ctx context.Context) (*ErasureLog, error) {
+	return withHooks(ctx, eluo.sqlSave, eluo.mutation, eluo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (eluo *ErasureLogUpdateOne) SaveX(ctx context.Context) *ErasureLog {
+	node, err := eluo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (eluo *ErasureLogUpdateOne) Exec(ctx context.Context) error {
+	_, err := eluo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eluo *ErasureLogUpdateOne) ExecX(ctx context.Context) {
+	if err := eluo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (eluo *ErasureLogUpdateOne) sqlSave(ctx context.Context) (_node *ErasureLog, err error) {
+	_spec := sqlgraph.NewUpdateSpec(erasurelog.Table, erasurelog.Columns, sqlgraph.NewFieldSpec(erasurelog.FieldID, field.TypeInt))
+	id, ok := eluo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ErasureLog.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := eluo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, erasurelog.FieldID)
+		for _, f := range fields {
+			if !erasurelog.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != erasurelog.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := eluo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_node = &ErasureLog{config: eluo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, eluo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{erasurelog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	eluo.mutation.done = true
+	return _node, nil
+}
@@ -20,6 +20,18 @@ func (f CustomerFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, er
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.CustomerMutation", m)
 }
 
+// The ErasureLogFunc type is an adapter to allow the use of ordinary
+// function as ErasureLog mutator.
+type ErasureLogFunc func(context.Context, *ent.ErasureLogMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f ErasureLogFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.ErasureLogMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ErasureLogMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, ent.Mutation) bool
 
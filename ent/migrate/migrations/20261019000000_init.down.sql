@@ -0,0 +1,12 @@
+-- reverse: create index "erasurelog_customer_id" to table: "erasure_logs"
+DROP INDEX "erasurelog_customer_id";
+-- reverse: create index "erasurelog_prev_hash" to table: "erasure_logs"
+DROP INDEX "erasurelog_prev_hash";
+-- reverse: create "erasure_logs" table
+DROP TABLE "erasure_logs";
+-- reverse: create index "customer_surname_bidx" to table: "customers"
+DROP INDEX "customer_surname_bidx";
+-- reverse: create index "customer_name_bidx" to table: "customers"
+DROP INDEX "customer_name_bidx";
+-- reverse: create "customers" table
+DROP TABLE "customers";
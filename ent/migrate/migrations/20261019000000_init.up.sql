@@ -0,0 +1,12 @@
+-- create "customers" table
+CREATE TABLE "customers" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" text NOT NULL, "name_bidx" character varying NULL, "surname" text NOT NULL, "surname_bidx" character varying NULL, "number" bigint NOT NULL, "gender" character varying NOT NULL, "country" character varying NOT NULL, "dependants" bigint NOT NULL DEFAULT 0, "birth_date" text NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "customers_dependants_check" CHECK (dependants >= 0), CONSTRAINT "customers_gender_check" CHECK (gender IN ('Male', 'Female')));
+-- create index "customer_name_bidx" to table: "customers"
+CREATE INDEX "customer_name_bidx" ON "customers" ("name_bidx");
+-- create index "customer_surname_bidx" to table: "customers"
+CREATE INDEX "customer_surname_bidx" ON "customers" ("surname_bidx");
+-- create "erasure_logs" table
+CREATE TABLE "erasure_logs" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "customer_id" bigint NOT NULL, "mode" character varying NOT NULL, "requested_by" character varying NOT NULL, "erased_at" timestamptz NOT NULL, "prev_hash" character varying NOT NULL, "hash" character varying NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "erasure_logs_customer_id_check" CHECK (customer_id > 0), CONSTRAINT "erasure_logs_mode_check" CHECK (mode IN ('hard_delete', 'anonymise')));
+-- create index "erasurelog_prev_hash" to table: "erasure_logs"
+CREATE UNIQUE INDEX "erasurelog_prev_hash" ON "erasure_logs" ("prev_hash");
+-- create index "erasurelog_customer_id" to table: "erasure_logs"
+CREATE INDEX "erasurelog_customer_id" ON "erasure_logs" ("customer_id");
@@ -0,0 +1,4 @@
+-- reverse: reject updates and deletes on "erasure_logs"
+DROP TRIGGER "erasure_logs_append_only" ON "erasure_logs";
+-- reverse: the erasure log is append-only
+DROP FUNCTION "erasure_logs_append_only"();
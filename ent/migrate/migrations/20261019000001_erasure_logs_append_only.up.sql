@@ -0,0 +1,8 @@
+-- The erasure log is append-only
+CREATE FUNCTION "erasure_logs_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'erasure_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- reject updates and deletes on "erasure_logs"
+CREATE TRIGGER "erasure_logs_append_only" BEFORE UPDATE OR DELETE ON "erasure_logs" FOR EACH STATEMENT EXECUTE FUNCTION "erasure_logs_append_only"();
@@ -0,0 +1,4 @@
+-- reverse: modify "customers" table
+ALTER TABLE "customers" ADD COLUMN "name_bidx" character varying NULL;
+-- reverse: drop index "customer_name_bidx" from table: "customers"
+CREATE INDEX "customer_name_bidx" ON "customers" ("name_bidx");
@@ -0,0 +1,4 @@
+-- drop index "customer_name_bidx" from table: "customers"
+DROP INDEX "customer_name_bidx";
+-- modify "customers" table
+ALTER TABLE "customers" DROP COLUMN "name_bidx";
@@ -0,0 +1,10 @@
+// Package migrations embeds the versioned schema migrations, generated from
+// the ent schema with `migrate new` and applied with `migrate up`.
+package migrations
+
+import "embed"
+
+// FS holds the migration files and their atlas.sum integrity file.
+//
+//go:embed *.sql atlas.sum
+var FS embed.FS
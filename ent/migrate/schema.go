@@ -3,6 +3,7 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
@@ -11,25 +12,72 @@ var (
 	// CustomersColumns holds the columns for the "customers" table.
 	CustomersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
-		{Name: "name", Type: field.TypeString, Size: 100},
-		{Name: "surname", Type: field.TypeString, Size: 100},
+		{Name: "name", Type: field.TypeString, Size: 100, SchemaType: map[string]string{"postgres": "text"}},
+		{Name: "surname", Type: field.TypeString, Size: 100, SchemaType: map[string]string{"postgres": "text"}},
+		{Name: "surname_bidx", Type: field.TypeString, Nullable: true},
 		{Name: "number", Type: field.TypeInt},
 		{Name: "gender", Type: field.TypeEnum, Enums: []string{"Male", "Female"}},
 		{Name: "country", Type: field.TypeString, Size: 50},
 		{Name: "dependants", Type: field.TypeInt, Default: 0},
-		{Name: "birth_date", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "date"}},
+		{Name: "birth_date", Type: field.TypeString, SchemaType: map[string]string{"postgres": "text"}},
 	}
 	// CustomersTable holds the schema information for the "customers" table.
 	CustomersTable = &schema.Table{
 		Name:       "customers",
 		Columns:    CustomersColumns,
 		PrimaryKey: []*schema.Column{CustomersColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "customer_surname_bidx",
+				Unique:  false,
+				Columns: []*schema.Column{CustomersColumns[3]},
+			},
+		},
+	}
+	// ErasureLogsColumns holds the columns for the "erasure_logs" table.
+	ErasureLogsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "customer_id", Type: field.TypeInt},
+		{Name: "mode", Type: field.TypeEnum, Enums: []string{"hard_delete", "anonymise"}},
+		{Name: "requested_by", Type: field.TypeString, Size: 100},
+		{Name: "erased_at", Type: field.TypeTime},
+		{Name: "prev_hash", Type: field.TypeString, Size: 64},
+		{Name: "hash", Type: field.TypeString, Size: 64},
+	}
+	// ErasureLogsTable holds the schema information for the "erasure_logs" table.
+	ErasureLogsTable = &schema.Table{
+		Name:       "erasure_logs",
+		Columns:    ErasureLogsColumns,
+		PrimaryKey: []*schema.Column{ErasureLogsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "erasurelog_prev_hash",
+				Unique:  true,
+				Columns: []*schema.Column{ErasureLogsColumns[5]},
+			},
+			{
+				Name:    "erasurelog_customer_id",
+				Unique:  false,
+				Columns: []*schema.Column{ErasureLogsColumns[1]},
+			},
+		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		CustomersTable,
+		ErasureLogsTable,
 	}
 )
 
 func init() {
+	CustomersTable.Annotation = &entsql.Annotation{}
+	CustomersTable.Annotation.Checks = map[string]string{
+		"customers_dependants_check": "dependants >= 0",
+		"customers_gender_check":     "gender IN ('Male', 'Female')",
+	}
+	ErasureLogsTable.Annotation = &entsql.Annotation{}
+	ErasureLogsTable.Annotation.Checks = map[string]string{
+		"erasure_logs_customer_id_check": "customer_id > 0",
+		"erasure_logs_mode_check":        "mode IN ('hard_delete', 'anonymise')",
+	}
 }
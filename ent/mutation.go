@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/ent/erasurelog"
 	"iohk-golang-backend/ent/predicate"
 	"sync"
 	"time"
@@ -24,7 +25,8 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeCustomer = "Customer"
+	TypeCustomer   = "Customer"
+	TypeErasureLog = "ErasureLog"
 )
 
 // CustomerMutation represents an operation that mutates the Customer nodes in the graph.
@@ -35,6 +37,7 @@ type CustomerMutation struct {
 	id            *int
 	name          *string
 	surname       *string
+	surname_bidx  *string
 	number        *int
 	addnumber     *int
 	gender        *customer.Gender
@@ -224,6 +227,55 @@ func (m *CustomerMutation) ResetSurname() {
 	m.surname = nil
 }
 
+// SetSurnameBidx sets the "surname_bidx" field.
+func (m *CustomerMutation) SetSurnameBidx(s string) {
+	m.surname_bidx = &s
+}
+
+// SurnameBidx returns the value of the "surname_bidx" field in the mutation.
+func (m *CustomerMutation) SurnameBidx() (r string, exists bool) {
+	v := m.surname_bidx
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSurnameBidx returns the old "surname_bidx" field's value of the Customer entity.
+// If the Customer object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CustomerMutation) OldSurnameBidx(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSurnameBidx is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSurnameBidx requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSurnameBidx: %w", err)
+	}
+	return oldValue.SurnameBidx, nil
+}
+
+// ClearSurnameBidx clears the value of the "surname_bidx" field.
+func (m *CustomerMutation) ClearSurnameBidx() {
+	m.surname_bidx = nil
+	m.clearedFields[customer.FieldSurnameBidx] = struct{}{}
+}
+
+// SurnameBidxCleared returns if the "surname_bidx" field was cleared in this mutation.
+func (m *CustomerMutation) SurnameBidxCleared() bool {
+	_, ok := m.clearedFields[customer.FieldSurnameBidx]
+	return ok
+}
+
+// ResetSurnameBidx resets all changes to the "surname_bidx" field.
+func (m *CustomerMutation) ResetSurnameBidx() {
+	m.surname_bidx = nil
+	delete(m.clearedFields, customer.FieldSurnameBidx)
+}
+
 // SetNumber sets the "number" field.
 func (m *CustomerMutation) SetNumber(i int) {
 	m.number = &i
@@ -478,13 +530,16 @@ func (m *CustomerMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *CustomerMutation) Fields() []string {
-	fields := make([]string, 0, 7)
+	fields := make([]string, 0, 8)
 	if m.name != nil {
 		fields = append(fields, customer.FieldName)
 	}
 	if m.surname != nil {
 		fields = append(fields, customer.FieldSurname)
 	}
+	if m.surname_bidx != nil {
+		fields = append(fields, customer.FieldSurnameBidx)
+	}
 	if m.number != nil {
 		fields = append(fields, customer.FieldNumber)
 	}
@@ -512,6 +567,8 @@ func (m *CustomerMutation) Field(name string) (ent.Value, bool) {
 		return m.Name()
 	case customer.FieldSurname:
 		return m.Surname()
+	case customer.FieldSurnameBidx:
+		return m.SurnameBidx()
 	case customer.FieldNumber:
 		return m.Number()
 	case customer.FieldGender:
@@ -535,6 +592,8 @@ func (m *CustomerMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldName(ctx)
 	case customer.FieldSurname:
 		return m.OldSurname(ctx)
+	case customer.FieldSurnameBidx:
+		return m.OldSurnameBidx(ctx)
 	case customer.FieldNumber:
 		return m.OldNumber(ctx)
 	case customer.FieldGender:
@@ -568,6 +627,13 @@ func (m *CustomerMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetSurname(v)
 		return nil
+	case customer.FieldSurnameBidx:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSurnameBidx(v)
+		return nil
 	case customer.FieldNumber:
 		v, ok := value.(int)
 		if !ok {
@@ -659,7 +725,11 @@ func (m *CustomerMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *CustomerMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(customer.FieldSurnameBidx) {
+		fields = append(fields, customer.FieldSurnameBidx)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -672,6 +742,11 @@ func (m *CustomerMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *CustomerMutation) ClearField(name string) error {
+	switch name {
+	case customer.FieldSurnameBidx:
+		m.ClearSurnameBidx()
+		return nil
+	}
 	return fmt.Errorf("unknown Customer nullable field %s", name)
 }
 
@@ -685,6 +760,9 @@ func (m *CustomerMutation) ResetField(name string) error {
 	case customer.FieldSurname:
 		m.ResetSurname()
 		return nil
+	case customer.FieldSurnameBidx:
+		m.ResetSurnameBidx()
+		return nil
 	case customer.FieldNumber:
 		m.ResetNumber()
 		return nil
@@ -751,3 +829,635 @@ func (m *CustomerMutation) ClearEdge(name string) error {
 func (m *CustomerMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Customer edge %s", name)
 }
+
+// ErasureLogMutation represents an operation that mutates the ErasureLog nodes in the graph.
+type ErasureLogMutation struct {
+	config
+	op             Op
+	typ            string
+	id             *int
+	customer_id    *int
+	addcustomer_id *int
+	mode           *erasurelog.Mode
+	requested_by   *string
+	erased_at      *time.Time
+	prev_hash      *string
+	hash           *string
+	clearedFields  map[string]struct{}
+	done           bool
+	oldValue       func(context.Context) (*ErasureLog, error)
+	predicates     []predicate.ErasureLog
+}
+
+var _ ent.Mutation = (*ErasureLogMutation)(nil)
+
+// erasurelogOption allows management of the mutation configuration using functional options.
+type erasurelogOption func(*ErasureLogMutation)
+
+// newErasureLogMutation creates new mutation for the ErasureLog entity.
+func newErasureLogMutation(c config, op Op, opts ...erasurelogOption) *ErasureLogMutation {
+	m := &ErasureLogMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeErasureLog,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withErasureLogID sets the ID field of the mutation.
+func withErasureLogID(id int) erasurelogOption {
+	return func(m *ErasureLogMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *ErasureLog
+		)
+		m.oldValue = func(ctx context.Context) (*ErasureLog, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().ErasureLog.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withErasureLog sets the old ErasureLog of the mutation.
+func withErasureLog(node *ErasureLog) erasurelogOption {
+	return func(m *ErasureLogMutation) {
+		m.oldValue = func(context.Context) (*ErasureLog, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ErasureLogMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ErasureLogMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ErasureLogMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ErasureLogMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().ErasureLog.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCustomerID sets the "customer_id" field.
+func (m *ErasureLogMutation) SetCustomerID(i int) {
+	m.customer_id = &i
+	m.addcustomer_id = nil
+}
+
+// CustomerID returns the value of the "customer_id" field in the mutation.
+func (m *ErasureLogMutation) CustomerID() (r int, exists bool) {
+	v := m.customer_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCustomerID returns the old "customer_id" field's value of the ErasureLog entity.
+// If the ErasureLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ErasureLogMutation) OldCustomerID(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCustomerID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCustomerID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCustomerID: %w", err)
+	}
+	return oldValue.CustomerID, nil
+}
+
+// AddCustomerID adds i to the "customer_id" field.
+func (m *ErasureLogMutation) AddCustomerID(i int) {
+	if m.addcustomer_id != nil {
+		*m.addcustomer_id += i
+	} else {
+		m.addcustomer_id = &i
+	}
+}
+
+// AddedCustomerID returns the value that was added to the "customer_id" field in this mutation.
+func (m *ErasureLogMutation) AddedCustomerID() (r int, exists bool) {
+	v := m.addcustomer_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCustomerID resets all changes to the "customer_id" field.
+func (m *ErasureLogMutation) ResetCustomerID() {
+	m.customer_id = nil
+	m.addcustomer_id = nil
+}
+
+// SetMode sets the "mode" field.
+func (m *ErasureLogMutation) SetMode(e erasurelog.Mode) {
+	m.mode = &e
+}
+
+// Mode returns the value of the "mode" field in the mutation.
+func (m *ErasureLogMutation) Mode() (r erasurelog.Mode, exists bool) {
+	v := m.mode
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMode returns the old "mode" field's value of the ErasureLog entity.
+// If the ErasureLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ErasureLogMutation) OldMode(ctx context.Context) (v erasurelog.Mode, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMode: %w", err)
+	}
+	return oldValue.Mode, nil
+}
+
+// ResetMode resets all changes to the "mode" field.
+func (m *ErasureLogMutation) ResetMode() {
+	m.mode = nil
+}
+
+// SetRequestedBy sets the "requested_by" field.
+func (m *ErasureLogMutation) SetRequestedBy(s string) {
+	m.requested_by = &s
+}
+
+// RequestedBy returns the value of the "requested_by" field in the mutation.
+func (m *ErasureLogMutation) RequestedBy() (r string, exists bool) {
+	v := m.requested_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequestedBy returns the old "requested_by" field's value of the ErasureLog entity.
+// If the ErasureLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ErasureLogMutation) OldRequestedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequestedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequestedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequestedBy: %w", err)
+	}
+	return oldValue.RequestedBy, nil
+}
+
+// ResetRequestedBy resets all changes to the "requested_by" field.
+func (m *ErasureLogMutation) ResetRequestedBy() {
+	m.requested_by = nil
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (m *ErasureLogMutation) SetErasedAt(t time.Time) {
+	m.erased_at = &t
+}
+
+// ErasedAt returns the value of the "erased_at" field in the mutation.
+func (m *ErasureLogMutation) ErasedAt() (r time.Time, exists bool) {
+	v := m.erased_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldErasedAt returns the old "erased_at" field's value of the ErasureLog entity.
+// If the ErasureLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ErasureLogMutation) OldErasedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldErasedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldErasedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldErasedAt: %w", err)
+	}
+	return oldValue.ErasedAt, nil
+}
+
+// ResetErasedAt resets all changes to the "erased_at" field.
+func (m *ErasureLogMutation) ResetErasedAt() {
+	m.erased_at = nil
+}
+
+// SetPrevHash sets the "prev_hash" field.
+func (m *ErasureLogMutation) SetPrevHash(s string) {
+	m.prev_hash = &s
+}
+
+// PrevHash returns the value of the "prev_hash" field in the mutation.
+func (m *ErasureLogMutation) PrevHash() (r string, exists bool) {
+	v := m.prev_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPrevHash returns the old "prev_hash" field's value of the ErasureLog entity.
+// If the ErasureLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ErasureLogMutation) OldPrevHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPrevHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPrevHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPrevHash: %w", err)
+	}
+	return oldValue.PrevHash, nil
+}
+
+// ResetPrevHash resets all changes to the "prev_hash" field.
+func (m *ErasureLogMutation) ResetPrevHash() {
+	m.prev_hash = nil
+}
+
+// SetHash sets the "hash" field.
+func (m *ErasureLogMutation) SetHash(s string) {
+	m.hash = &s
+}
+
+// Hash returns the value of the "hash" field in the mutation.
+func (m *ErasureLogMutation) Hash() (r string, exists bool) {
+	v := m.hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHash returns the old "hash" field's value of the ErasureLog entity.
+// If the ErasureLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ErasureLogMutation) OldHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+	}
+	return oldValue.Hash, nil
+}
+
+// ResetHash resets all changes to the "hash" field.
+func (m *ErasureLogMutation) ResetHash() {
+	m.hash = nil
+}
+
+// Where appends a list predicates to the ErasureLogMutation builder.
+func (m *ErasureLogMutation) Where(ps ...predicate.ErasureLog) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ErasureLogMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ErasureLogMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ErasureLog, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ErasureLogMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ErasureLogMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ErasureLog).
+func (m *ErasureLogMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ErasureLogMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.customer_id != nil {
+		fields = append(fields, erasurelog.FieldCustomerID)
+	}
+	if m.mode != nil {
+		fields = append(fields, erasurelog.FieldMode)
+	}
+	if m.requested_by != nil {
+		fields = append(fields, erasurelog.FieldRequestedBy)
+	}
+	if m.erased_at != nil {
+		fields = append(fields, erasurelog.FieldErasedAt)
+	}
+	if m.prev_hash != nil {
+		fields = append(fields, erasurelog.FieldPrevHash)
+	}
+	if m.hash != nil {
+		fields = append(fields, erasurelog.FieldHash)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ErasureLogMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case erasurelog.FieldCustomerID:
+		return m.CustomerID()
+	case erasurelog.FieldMode:
+		return m.Mode()
+	case erasurelog.FieldRequestedBy:
+		return m.RequestedBy()
+	case erasurelog.FieldErasedAt:
+		return m.ErasedAt()
+	case erasurelog.FieldPrevHash:
+		return m.PrevHash()
+	case erasurelog.FieldHash:
+		return m.Hash()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ErasureLogMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case erasurelog.FieldCustomerID:
+		return m.OldCustomerID(ctx)
+	case erasurelog.FieldMode:
+		return m.OldMode(ctx)
+	case erasurelog.FieldRequestedBy:
+		return m.OldRequestedBy(ctx)
+	case erasurelog.FieldErasedAt:
+		return m.OldErasedAt(ctx)
+	case erasurelog.FieldPrevHash:
+		return m.OldPrevHash(ctx)
+	case erasurelog.FieldHash:
+		return m.OldHash(ctx)
+	}
+	return nil, fmt.Errorf("unknown ErasureLog field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ErasureLogMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case erasurelog.FieldCustomerID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCustomerID(v)
+		return nil
+	case erasurelog.FieldMode:
+		v, ok := value.(erasurelog.Mode)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMode(v)
+		return nil
+	case erasurelog.FieldRequestedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequestedBy(v)
+		return nil
+	case erasurelog.FieldErasedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetErasedAt(v)
+		return nil
+	case erasurelog.FieldPrevHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrevHash(v)
+		return nil
+	case erasurelog.FieldHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHash(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ErasureLog field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ErasureLogMutation) AddedFields() []string {
+	var fields []string
+	if m.addcustomer_id != nil {
+		fields = append(fields, erasurelog.FieldCustomerID)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ErasureLogMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case erasurelog.FieldCustomerID:
+		return m.AddedCustomerID()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ErasureLogMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case erasurelog.FieldCustomerID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCustomerID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ErasureLog numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ErasureLogMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ErasureLogMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ErasureLogMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown ErasureLog nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ErasureLogMutation) ResetField(name string) error {
+	switch name {
+	case erasurelog.FieldCustomerID:
+		m.ResetCustomerID()
+		return nil
+	case erasurelog.FieldMode:
+		m.ResetMode()
+		return nil
+	case erasurelog.FieldRequestedBy:
+		m.ResetRequestedBy()
+		return nil
+	case erasurelog.FieldErasedAt:
+		m.ResetErasedAt()
+		return nil
+	case erasurelog.FieldPrevHash:
+		m.ResetPrevHash()
+		return nil
+	case erasurelog.FieldHash:
+		m.ResetHash()
+		return nil
+	}
+	return fmt.Errorf("unknown ErasureLog field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ErasureLogMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ErasureLogMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ErasureLogMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ErasureLogMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ErasureLogMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ErasureLogMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ErasureLogMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown ErasureLog unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ErasureLogMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown ErasureLog edge %s", name)
+}
@@ -8,3 +8,17 @@ import (
 
 // Customer is the predicate function for customer builders.
 type Customer func(*sql.Selector)
+
+// CustomerOrErr calls the predicate only if the error is not nit.
+func CustomerOrErr(p Customer, err error) Customer {
+	return func(s *sql.Selector) {
+		if err != nil {
+			s.AddError(err)
+			return
+		}
+		p(s)
+	}
+}
+
+// ErasureLog is the predicate function for erasurelog builders.
+type ErasureLog func(*sql.Selector)
@@ -2,7 +2,144 @@
 
 package runtime
 
-// The schema-stitching logic is generated in iohk-golang-backend/ent/runtime.go
+import (
+	"iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/ent/erasurelog"
+	"iohk-golang-backend/ent/schema"
+	"time"
+
+	"entgo.io/ent/schema/field"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	customerHooks := schema.Customer{}.Hooks()
+	customer.Hooks[0] = customerHooks[0]
+	customer.Hooks[1] = customerHooks[1]
+	customerFields := schema.Customer{}.Fields()
+	_ = customerFields
+	// customerDescName is the schema descriptor for name field.
+	customerDescName := customerFields[1].Descriptor()
+	customer.ValueScanner.Name = customerDescName.ValueScanner.(field.TypeValueScanner[string])
+	// customer.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	customer.NameValidator = func() func(string) error {
+		validators := customerDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// customerDescSurname is the schema descriptor for surname field.
+	customerDescSurname := customerFields[2].Descriptor()
+	customer.ValueScanner.Surname = customerDescSurname.ValueScanner.(field.TypeValueScanner[string])
+	// customer.SurnameValidator is a validator for the "surname" field. It is called by the builders before save.
+	customer.SurnameValidator = func() func(string) error {
+		validators := customerDescSurname.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(surname string) error {
+			for _, fn := range fns {
+				if err := fn(surname); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// customerDescNumber is the schema descriptor for number field.
+	customerDescNumber := customerFields[4].Descriptor()
+	// customer.NumberValidator is a validator for the "number" field. It is called by the builders before save.
+	customer.NumberValidator = customerDescNumber.Validators[0].(func(int) error)
+	// customerDescCountry is the schema descriptor for country field.
+	customerDescCountry := customerFields[6].Descriptor()
+	// customer.CountryValidator is a validator for the "country" field. It is called by the builders before save.
+	customer.CountryValidator = func() func(string) error {
+		validators := customerDescCountry.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(country string) error {
+			for _, fn := range fns {
+				if err := fn(country); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// customerDescDependants is the schema descriptor for dependants field.
+	customerDescDependants := customerFields[7].Descriptor()
+	// customer.DefaultDependants holds the default value on creation for the dependants field.
+	customer.DefaultDependants = customerDescDependants.Default.(int)
+	// customer.DependantsValidator is a validator for the "dependants" field. It is called by the builders before save.
+	customer.DependantsValidator = customerDescDependants.Validators[0].(func(int) error)
+	// customerDescBirthDate is the schema descriptor for birth_date field.
+	customerDescBirthDate := customerFields[8].Descriptor()
+	customer.ValueScanner.BirthDate = customerDescBirthDate.ValueScanner.(field.TypeValueScanner[time.Time])
+	// customerDescID is the schema descriptor for id field.
+	customerDescID := customerFields[0].Descriptor()
+	// customer.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	customer.IDValidator = customerDescID.Validators[0].(func(int) error)
+	erasurelogFields := schema.ErasureLog{}.Fields()
+	_ = erasurelogFields
+	// erasurelogDescCustomerID is the schema descriptor for customer_id field.
+	erasurelogDescCustomerID := erasurelogFields[0].Descriptor()
+	// erasurelog.CustomerIDValidator is a validator for the "customer_id" field. It is called by the builders before save.
+	erasurelog.CustomerIDValidator = erasurelogDescCustomerID.Validators[0].(func(int) error)
+	// erasurelogDescRequestedBy is the schema descriptor for requested_by field.
+	erasurelogDescRequestedBy := erasurelogFields[2].Descriptor()
+	// erasurelog.RequestedByValidator is a validator for the "requested_by" field. It is called by the builders before save.
+	erasurelog.RequestedByValidator = func() func(string) error {
+		validators := erasurelogDescRequestedBy.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(requested_by string) error {
+			for _, fn := range fns {
+				if err := fn(requested_by); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// erasurelogDescPrevHash is the schema descriptor for prev_hash field.
+	erasurelogDescPrevHash := erasurelogFields[4].Descriptor()
+	// erasurelog.PrevHashValidator is a validator for the "prev_hash" field. It is called by the builders before save.
+	erasurelog.PrevHashValidator = erasurelogDescPrevHash.Validators[0].(func(string) error)
+	// erasurelogDescHash is the schema descriptor for hash field.
+	erasurelogDescHash := erasurelogFields[5].Descriptor()
+	// erasurelog.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	erasurelog.HashValidator = func() func(string) error {
+		validators := erasurelogDescHash.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(hash string) error {
+			for _, fn := range fns {
+				if err := fn(hash); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+}
 
 const (
 	Version = "v0.14.1"                                         // Version of ent codegen.
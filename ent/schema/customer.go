@@ -1,9 +1,21 @@
 package schema
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	gen "iohk-golang-backend/ent"
+	"iohk-golang-backend/ent/hook"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/piicrypto"
 )
 
 // Customer holds the schema definition for the Customer entity.
@@ -12,18 +24,25 @@ type Customer struct {
 }
 
 // Fields of the Customer.
+// name, surname and birth_date are encrypted at rest, so they are stored as
+// text and any checks on them are enforced in Go rather than in the database.
 func (Customer) Fields() []ent.Field {
 	return []ent.Field{
 		field.Int("id").Unique().Immutable().Positive(),
-		field.String("name").MaxLen(100).NotEmpty(),
-		field.String("surname").MaxLen(100).NotEmpty(),
+		field.String("name").MaxLen(100).NotEmpty().
+			ValueScanner(piicrypto.EncryptedString{Column: "name"}).
+			SchemaType(map[string]string{dialect.Postgres: "text"}),
+		field.String("surname").MaxLen(100).NotEmpty().
+			ValueScanner(piicrypto.EncryptedString{Column: "surname"}).
+			SchemaType(map[string]string{dialect.Postgres: "text"}),
+		field.String("surname_bidx").Optional().Sensitive(),
 		field.Int("number").Positive(),
 		field.Enum("gender").Values("Male", "Female"),
 		field.String("country").MaxLen(50).NotEmpty(),
 		field.Int("dependants").Default(0).NonNegative(),
-		field.Time("birth_date").SchemaType(map[string]string{
-			dialect.Postgres: "date",
-		}),
+		field.String("birth_date").GoType(time.Time{}).
+			ValueScanner(piicrypto.EncryptedDate{Column: "birth_date"}).
+			SchemaType(map[string]string{dialect.Postgres: "text"}),
 	}
 }
 
@@ -31,3 +50,55 @@ func (Customer) Fields() []ent.Field {
 func (Customer) Edges() []ent.Edge {
 	return nil
 }
+
+// Indexes of the Customer.
+func (Customer) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("surname_bidx"),
+	}
+}
+
+// Annotations of the Customer. The checks repeat validators that can be
+// enforced by the database too, for rows written by other clients.
+func (Customer) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Checks(map[string]string{
+			"customers_gender_check":     "gender IN ('Male', 'Female')",
+			"customers_dependants_check": "dependants >= 0",
+		}),
+	}
+}
+
+// Hooks of the Customer.
+func (Customer) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(blindIndexHook, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(birthDateHook, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+	}
+}
+
+// blindIndexHook keeps the surname blind index in step with the encrypted
+// surname whenever it is written.
+func blindIndexHook(next ent.Mutator) ent.Mutator {
+	return hook.CustomerFunc(func(ctx context.Context, m *gen.CustomerMutation) (ent.Value, error) {
+		if surname, ok := m.Surname(); ok {
+			if err := m.SetField("surname_bidx", piicrypto.BlindIndex(surname)); err != nil {
+				return nil, err
+			}
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// birthDateHook replaces the database CHECK (birth_date <= CURRENT_DATE),
+// which cannot be applied to an encrypted column.
+func birthDateHook(next ent.Mutator) ent.Mutator {
+	return hook.CustomerFunc(func(ctx context.Context, m *gen.CustomerMutation) (ent.Value, error) {
+		if birthDate, ok := m.BirthDate(); ok {
+			if err := domainmodel.BirthDateValidator(birthDate); err != nil {
+				return nil, fmt.Errorf(`ent: validator failed for field "Customer.birth_date": %w`, err)
+			}
+		}
+		return next.Mutate(ctx, m)
+	})
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ErasureLog holds the schema definition for the ErasureLog entity.
+// Entries form a hash chain: each hash covers the entry and the previous
+// hash, so editing or removing an entry breaks every hash after it.
+// It deliberately stores no personal data beyond the customer ID.
+type ErasureLog struct {
+	ent.Schema
+}
+
+// Fields of the ErasureLog.
+func (ErasureLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("customer_id").Positive().Immutable(),
+		field.Enum("mode").Values("hard_delete", "anonymise").Immutable(),
+		field.String("requested_by").MaxLen(100).NotEmpty().Immutable(),
+		field.Time("erased_at").Immutable(),
+		// prev_hash is unique so two concurrent erasures cannot both extend the
+		// same entry and fork the chain.
+		field.String("prev_hash").MaxLen(64).Immutable(),
+		field.String("hash").MaxLen(64).NotEmpty().Immutable(),
+	}
+}
+
+// Edges of the ErasureLog.
+func (ErasureLog) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the ErasureLog.
+func (ErasureLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("prev_hash").Unique(),
+		index.Fields("customer_id"),
+	}
+}
+
+// Annotations of the ErasureLog.
+func (ErasureLog) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Checks(map[string]string{
+			"erasure_logs_customer_id_check": "customer_id > 0",
+			"erasure_logs_mode_check":        "mode IN ('hard_delete', 'anonymise')",
+		}),
+	}
+}
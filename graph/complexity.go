@@ -0,0 +1,47 @@
+package graph
+
+import "iohk-golang-backend/graph/model"
+
+// Field costs used by the complexity limit. Scalar fields keep gqlgen's
+// default cost of 1 plus their children.
+const (
+	// listSizeEstimate is the number of elements a list field is assumed to
+	// return, since the real size is unknown until it has been resolved.
+	listSizeEstimate = 20
+	// exportCost reflects that a data export reads every table holding
+	// customer data.
+	exportCost = 50
+	// mutationCost reflects that writes hold a transaction and a primary
+	// connection for longer than reads.
+	mutationCost = 10
+)
+
+// NewComplexityRoot returns the per-field costs for the complexity limit.
+func NewComplexityRoot() ComplexityRoot {
+	var c ComplexityRoot
+
+	c.Query.Customers = func(childComplexity int) int {
+		return 1 + listSizeEstimate*childComplexity
+	}
+	c.Query.ExportCustomerData = func(childComplexity int, id string) int {
+		return exportCost + childComplexity
+	}
+	c.Query.VerifyErasureLog = func(childComplexity int) int {
+		return exportCost + childComplexity
+	}
+
+	c.Mutation.CreateCustomer = func(childComplexity int, input model.CreateCustomerInput) int {
+		return mutationCost + childComplexity
+	}
+	c.Mutation.UpdateCustomer = func(childComplexity int, id string, input model.UpdateCustomerInput) int {
+		return mutationCost + childComplexity
+	}
+	c.Mutation.DeleteCustomer = func(childComplexity int, id string) int {
+		return mutationCost + childComplexity
+	}
+	c.Mutation.EraseCustomer = func(childComplexity int, id string, mode model.ErasureMode) int {
+		return mutationCost + childComplexity
+	}
+
+	return c
+}
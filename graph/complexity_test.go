@@ -0,0 +1,42 @@
+//go:build testcoverage
+// +build testcoverage
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+)
+
+func TestComplexityRoot(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		expectedComplexity int
+	}{
+		{name: "Single customer", query: `{ customer(id: "1") { id name } }`, expectedComplexity: 3},
+		{name: "Customer list", query: `{ customers { id name } }`, expectedComplexity: 1 + listSizeEstimate*2},
+		{name: "Data export", query: `{ exportCustomerData(id: "1") { data } }`, expectedComplexity: exportCost + 1},
+		{name: "Mutation", query: `mutation { deleteCustomer(id: "1") }`, expectedComplexity: mutationCost},
+	}
+
+	es := NewExecutableSchema(NewConfig(NewResolver(nil)))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			doc, errs := gqlparser.LoadQuery(es.Schema(), tc.query)
+			require.Empty(t, errs)
+
+			// Act
+			result := complexity.Calculate(es, doc.Operations[0], nil)
+
+			// Assert
+			assert.Equal(t, tc.expectedComplexity, result)
+		})
+	}
+}
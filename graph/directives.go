@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/auth"
+)
+
+// NewConfig wires the resolver, directive and complexity implementations into a schema config.
+func NewConfig(resolver *Resolver) Config {
+	return Config{
+		Resolvers:  resolver,
+		Complexity: NewComplexityRoot(),
+		Directives: DirectiveRoot{
+			Pii:      PiiDirective,
+			HasScope: HasScopeDirective,
+		},
+	}
+}
+
+// PiiDirective masks the resolved value of a @pii field unless the caller holds
+// the pii:read scope. It runs on the output of mapper.DomainToGraphQL, so the
+// service and mapper layers always work with unmasked data.
+func PiiDirective(ctx context.Context, obj interface{}, next graphql.Resolver, mask model.PiiMask) (interface{}, error) {
+	value, err := next(ctx)
+	if err != nil || auth.HasScope(ctx, auth.ScopePIIRead) {
+		return value, err
+	}
+	return maskValue(value, mask), nil
+}
+
+// HasScopeDirective rejects the field unless the caller holds the given scope.
+func HasScopeDirective(ctx context.Context, obj interface{}, next graphql.Resolver, scope string) (interface{}, error) {
+	if !auth.HasScope(ctx, scope) {
+		return nil, fmt.Errorf("forbidden: requires the %s scope", scope)
+	}
+	return next(ctx)
+}
+
+func maskValue(value interface{}, mask model.PiiMask) interface{} {
+	switch v := value.(type) {
+	case string:
+		return maskString(v, mask)
+	case int:
+		return 0
+	default:
+		return value
+	}
+}
+
+func maskString(s string, mask model.PiiMask) string {
+	switch mask {
+	case model.PiiMaskInitial:
+		if s == "" {
+			return s
+		}
+		first, _ := utf8.DecodeRuneInString(s)
+		return string(first) + "****"
+	case model.PiiMaskYear:
+		// Dates are formatted as YYYY-MM-DD, so the year is everything before the first dash.
+		year, _, _ := strings.Cut(s, "-")
+		return year
+	default:
+		return "****"
+	}
+}
@@ -0,0 +1,152 @@
+//go:build testcoverage
+// +build testcoverage
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/auth"
+)
+
+func TestPiiDirective(t *testing.T) {
+	testCases := []struct {
+		name      string
+		principal *auth.Principal
+		value     interface{}
+		mask      model.PiiMask
+		expected  interface{}
+	}{
+		{
+			name:      "Surname masked without scope",
+			principal: &auth.Principal{Name: "callcentre"},
+			value:     "Smith",
+			mask:      model.PiiMaskInitial,
+			expected:  "S****",
+		},
+		{
+			name:      "Multi-byte initial is kept intact",
+			principal: &auth.Principal{Name: "callcentre"},
+			value:     "Ångström",
+			mask:      model.PiiMaskInitial,
+			expected:  "Å****",
+		},
+		{
+			name:      "Birth date reduced to year without scope",
+			principal: &auth.Principal{Name: "callcentre"},
+			value:     "1990-05-17",
+			mask:      model.PiiMaskYear,
+			expected:  "1990",
+		},
+		{
+			name:      "Number redacted without scope",
+			principal: &auth.Principal{Name: "callcentre"},
+			value:     123,
+			mask:      model.PiiMaskRedact,
+			expected:  0,
+		},
+		{
+			name:      "No principal is masked",
+			principal: nil,
+			value:     "Smith",
+			mask:      model.PiiMaskInitial,
+			expected:  "S****",
+		},
+		{
+			name:      "Unmasked with pii:read scope",
+			principal: &auth.Principal{Name: "admin", Scopes: []string{auth.ScopePIIRead}},
+			value:     "Smith",
+			mask:      model.PiiMaskInitial,
+			expected:  "Smith",
+		},
+		{
+			name:      "Unmasked for anonymous when auth is disabled",
+			principal: auth.Anonymous,
+			value:     "1990-05-17",
+			mask:      model.PiiMaskYear,
+			expected:  "1990-05-17",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tc.principal)
+			}
+			next := func(ctx context.Context) (interface{}, error) { return tc.value, nil }
+
+			// Act
+			result, err := PiiDirective(ctx, nil, next, tc.mask)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPiiDirectiveResolverError(t *testing.T) {
+	// Arrange
+	next := func(ctx context.Context) (interface{}, error) { return nil, errors.New("resolver failed") }
+
+	// Act
+	result, err := PiiDirective(context.Background(), nil, next, model.PiiMaskInitial)
+
+	// Assert
+	assert.EqualError(t, err, "resolver failed")
+	assert.Nil(t, result)
+}
+
+func TestHasScopeDirective(t *testing.T) {
+	testCases := []struct {
+		name          string
+		principal     *auth.Principal
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:      "Principal holds the scope",
+			principal: &auth.Principal{Name: "dpo", Scopes: []string{auth.ScopeErase}},
+			expected:  "ok",
+		},
+		{
+			name:          "Principal lacks the scope",
+			principal:     &auth.Principal{Name: "callcentre", Scopes: []string{auth.ScopePIIRead}},
+			expectedError: "forbidden: requires the gdpr:erase scope",
+		},
+		{
+			name:          "No principal",
+			expectedError: "forbidden: requires the gdpr:erase scope",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tc.principal)
+			}
+			next := func(ctx context.Context) (interface{}, error) { return "ok", nil }
+
+			// Act
+			result, err := HasScopeDirective(ctx, nil, next, auth.ScopeErase)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
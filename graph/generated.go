@@ -44,6 +44,8 @@ type ResolverRoot interface {
 }
 
 type DirectiveRoot struct {
+	HasScope func(ctx context.Context, obj interface{}, next graphql.Resolver, scope string) (res interface{}, err error)
+	Pii      func(ctx context.Context, obj interface{}, next graphql.Resolver, mask model.PiiMask) (res interface{}, err error)
 }
 
 type ComplexityRoot struct {
@@ -58,15 +60,39 @@ type ComplexityRoot struct {
 		Surname    func(childComplexity int) int
 	}
 
+	CustomerDataExport struct {
+		CustomerID func(childComplexity int) int
+		Data       func(childComplexity int) int
+		ExportedAt func(childComplexity int) int
+	}
+
+	ErasureLogVerification struct {
+		Entries        func(childComplexity int) int
+		FirstInvalidID func(childComplexity int) int
+		Valid          func(childComplexity int) int
+	}
+
+	ErasureRecord struct {
+		CustomerID  func(childComplexity int) int
+		ErasedAt    func(childComplexity int) int
+		Hash        func(childComplexity int) int
+		ID          func(childComplexity int) int
+		Mode        func(childComplexity int) int
+		RequestedBy func(childComplexity int) int
+	}
+
 	Mutation struct {
 		CreateCustomer func(childComplexity int, input model.CreateCustomerInput) int
 		DeleteCustomer func(childComplexity int, id string) int
+		EraseCustomer  func(childComplexity int, id string, mode model.ErasureMode) int
 		UpdateCustomer func(childComplexity int, id string, input model.UpdateCustomerInput) int
 	}
 
 	Query struct {
-		Customer  func(childComplexity int, id string) int
-		Customers func(childComplexity int) int
+		Customer           func(childComplexity int, id string) int
+		Customers          func(childComplexity int) int
+		ExportCustomerData func(childComplexity int, id string) int
+		VerifyErasureLog   func(childComplexity int) int
 	}
 }
 
@@ -74,10 +100,13 @@ type MutationResolver interface {
 	CreateCustomer(ctx context.Context, input model.CreateCustomerInput) (*model.Customer, error)
 	UpdateCustomer(ctx context.Context, id string, input model.UpdateCustomerInput) (*model.Customer, error)
 	DeleteCustomer(ctx context.Context, id string) (bool, error)
+	EraseCustomer(ctx context.Context, id string, mode model.ErasureMode) (*model.ErasureRecord, error)
 }
 type QueryResolver interface {
 	Customer(ctx context.Context, id string) (*model.Customer, error)
 	Customers(ctx context.Context) ([]*model.Customer, error)
+	ExportCustomerData(ctx context.Context, id string) (*model.CustomerDataExport, error)
+	VerifyErasureLog(ctx context.Context) (*model.ErasureLogVerification, error)
 }
 
 type executableSchema struct {
@@ -155,6 +184,90 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Customer.Surname(childComplexity), true
 
+	case "CustomerDataExport.customerId":
+		if e.complexity.CustomerDataExport.CustomerID == nil {
+			break
+		}
+
+		return e.complexity.CustomerDataExport.CustomerID(childComplexity), true
+
+	case "CustomerDataExport.data":
+		if e.complexity.CustomerDataExport.Data == nil {
+			break
+		}
+
+		return e.complexity.CustomerDataExport.Data(childComplexity), true
+
+	case "CustomerDataExport.exportedAt":
+		if e.complexity.CustomerDataExport.ExportedAt == nil {
+			break
+		}
+
+		return e.complexity.CustomerDataExport.ExportedAt(childComplexity), true
+
+	case "ErasureLogVerification.entries":
+		if e.complexity.ErasureLogVerification.Entries == nil {
+			break
+		}
+
+		return e.complexity.ErasureLogVerification.Entries(childComplexity), true
+
+	case "ErasureLogVerification.firstInvalidId":
+		if e.complexity.ErasureLogVerification.FirstInvalidID == nil {
+			break
+		}
+
+		return e.complexity.ErasureLogVerification.FirstInvalidID(childComplexity), true
+
+	case "ErasureLogVerification.valid":
+		if e.complexity.ErasureLogVerification.Valid == nil {
+			break
+		}
+
+		return e.complexity.ErasureLogVerification.Valid(childComplexity), true
+
+	case "ErasureRecord.customerId":
+		if e.complexity.ErasureRecord.CustomerID == nil {
+			break
+		}
+
+		return e.complexity.ErasureRecord.CustomerID(childComplexity), true
+
+	case "ErasureRecord.erasedAt":
+		if e.complexity.ErasureRecord.ErasedAt == nil {
+			break
+		}
+
+		return e.complexity.ErasureRecord.ErasedAt(childComplexity), true
+
+	case "ErasureRecord.hash":
+		if e.complexity.ErasureRecord.Hash == nil {
+			break
+		}
+
+		return e.complexity.ErasureRecord.Hash(childComplexity), true
+
+	case "ErasureRecord.id":
+		if e.complexity.ErasureRecord.ID == nil {
+			break
+		}
+
+		return e.complexity.ErasureRecord.ID(childComplexity), true
+
+	case "ErasureRecord.mode":
+		if e.complexity.ErasureRecord.Mode == nil {
+			break
+		}
+
+		return e.complexity.ErasureRecord.Mode(childComplexity), true
+
+	case "ErasureRecord.requestedBy":
+		if e.complexity.ErasureRecord.RequestedBy == nil {
+			break
+		}
+
+		return e.complexity.ErasureRecord.RequestedBy(childComplexity), true
+
 	case "Mutation.createCustomer":
 		if e.complexity.Mutation.CreateCustomer == nil {
 			break
@@ -179,6 +292,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.DeleteCustomer(childComplexity, args["id"].(string)), true
 
+	case "Mutation.eraseCustomer":
+		if e.complexity.Mutation.EraseCustomer == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_eraseCustomer_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.EraseCustomer(childComplexity, args["id"].(string), args["mode"].(model.ErasureMode)), true
+
 	case "Mutation.updateCustomer":
 		if e.complexity.Mutation.UpdateCustomer == nil {
 			break
@@ -210,6 +335,25 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Customers(childComplexity), true
 
+	case "Query.exportCustomerData":
+		if e.complexity.Query.ExportCustomerData == nil {
+			break
+		}
+
+		args, err := ec.field_Query_exportCustomerData_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ExportCustomerData(childComplexity, args["id"].(string)), true
+
+	case "Query.verifyErasureLog":
+		if e.complexity.Query.VerifyErasureLog == nil {
+			break
+		}
+
+		return e.complexity.Query.VerifyErasureLog(childComplexity), true
+
 	}
 	return 0, false
 }
@@ -336,6 +480,70 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
 // region    ***************************** args.gotpl *****************************
 
+func (ec *executionContext) dir_hasScope_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	arg0, err := ec.dir_hasScope_argsScope(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["scope"] = arg0
+	return args, nil
+}
+func (ec *executionContext) dir_hasScope_argsScope(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["scope"]
+	if !ok {
+		var zeroVal string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("scope"))
+	if tmp, ok := rawArgs["scope"]; ok {
+		return ec.unmarshalNString2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) dir_pii_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	arg0, err := ec.dir_pii_argsMask(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["mask"] = arg0
+	return args, nil
+}
+func (ec *executionContext) dir_pii_argsMask(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (model.PiiMask, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["mask"]
+	if !ok {
+		var zeroVal model.PiiMask
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("mask"))
+	if tmp, ok := rawArgs["mask"]; ok {
+		return ec.unmarshalNPiiMask2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐPiiMask(ctx, tmp)
+	}
+
+	var zeroVal model.PiiMask
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field_Mutation_createCustomer_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -352,7 +560,7 @@ func (ec *executionContext) field_Mutation_createCustomer_argsInput(
 ) (model.CreateCustomerInput, error) {
 	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
 	if tmp, ok := rawArgs["input"]; ok {
-		return ec.unmarshalNCreateCustomerInput2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCreateCustomerInput(ctx, tmp)
+		return ec.unmarshalNCreateCustomerInput2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCreateCustomerInput(ctx, tmp)
 	}
 
 	var zeroVal model.CreateCustomerInput
@@ -382,6 +590,47 @@ func (ec *executionContext) field_Mutation_deleteCustomer_argsID(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Mutation_eraseCustomer_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	arg0, err := ec.field_Mutation_eraseCustomer_argsID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	arg1, err := ec.field_Mutation_eraseCustomer_argsMode(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["mode"] = arg1
+	return args, nil
+}
+func (ec *executionContext) field_Mutation_eraseCustomer_argsID(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (string, error) {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+	if tmp, ok := rawArgs["id"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Mutation_eraseCustomer_argsMode(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (model.ErasureMode, error) {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("mode"))
+	if tmp, ok := rawArgs["mode"]; ok {
+		return ec.unmarshalNErasureMode2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureMode(ctx, tmp)
+	}
+
+	var zeroVal model.ErasureMode
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field_Mutation_updateCustomer_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -416,7 +665,7 @@ func (ec *executionContext) field_Mutation_updateCustomer_argsInput(
 ) (model.UpdateCustomerInput, error) {
 	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
 	if tmp, ok := rawArgs["input"]; ok {
-		return ec.unmarshalNUpdateCustomerInput2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐUpdateCustomerInput(ctx, tmp)
+		return ec.unmarshalNUpdateCustomerInput2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐUpdateCustomerInput(ctx, tmp)
 	}
 
 	var zeroVal model.UpdateCustomerInput
@@ -469,6 +718,29 @@ func (ec *executionContext) field_Query_customer_argsID(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Query_exportCustomerData_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	arg0, err := ec.field_Query_exportCustomerData_argsID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field_Query_exportCustomerData_argsID(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (string, error) {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+	if tmp, ok := rawArgs["id"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -624,8 +896,35 @@ func (ec *executionContext) _Customer_surname(ctx context.Context, field graphql
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.Surname, nil
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return obj.Surname, nil
+		}
+
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			mask, err := ec.unmarshalNPiiMask2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐPiiMask(ctx, "INITIAL")
+			if err != nil {
+				var zeroVal string
+				return zeroVal, err
+			}
+			if ec.directives.Pii == nil {
+				var zeroVal string
+				return zeroVal, errors.New("directive pii is not implemented")
+			}
+			return ec.directives.Pii(ctx, obj, directive0, mask)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(string); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be string`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -668,8 +967,35 @@ func (ec *executionContext) _Customer_number(ctx context.Context, field graphql.
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.Number, nil
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return obj.Number, nil
+		}
+
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			mask, err := ec.unmarshalNPiiMask2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐPiiMask(ctx, "REDACT")
+			if err != nil {
+				var zeroVal int
+				return zeroVal, err
+			}
+			if ec.directives.Pii == nil {
+				var zeroVal int
+				return zeroVal, errors.New("directive pii is not implemented")
+			}
+			return ec.directives.Pii(ctx, obj, directive0, mask)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(int); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be int`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -727,7 +1053,7 @@ func (ec *executionContext) _Customer_gender(ctx context.Context, field graphql.
 	}
 	res := resTmp.(model.Gender)
 	fc.Result = res
-	return ec.marshalNGender2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx, field.Selections, res)
+	return ec.marshalNGender2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) fieldContext_Customer_gender(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
@@ -844,8 +1170,35 @@ func (ec *executionContext) _Customer_birthDate(ctx context.Context, field graph
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.BirthDate, nil
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return obj.BirthDate, nil
+		}
+
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			mask, err := ec.unmarshalNPiiMask2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐPiiMask(ctx, "YEAR")
+			if err != nil {
+				var zeroVal string
+				return zeroVal, err
+			}
+			if ec.directives.Pii == nil {
+				var zeroVal string
+				return zeroVal, errors.New("directive pii is not implemented")
+			}
+			return ec.directives.Pii(ctx, obj, directive0, mask)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(string); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be string`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -875,8 +1228,8 @@ func (ec *executionContext) fieldContext_Customer_birthDate(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createCustomer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_createCustomer(ctx, field)
+func (ec *executionContext) _CustomerDataExport_customerId(ctx context.Context, field graphql.CollectedField, obj *model.CustomerDataExport) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CustomerDataExport_customerId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -889,7 +1242,7 @@ func (ec *executionContext) _Mutation_createCustomer(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CreateCustomer(rctx, fc.Args["input"].(model.CreateCustomerInput))
+		return obj.CustomerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -901,55 +1254,26 @@ func (ec *executionContext) _Mutation_createCustomer(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Customer)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createCustomer(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CustomerDataExport_customerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "CustomerDataExport",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Customer_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Customer_name(ctx, field)
-			case "surname":
-				return ec.fieldContext_Customer_surname(ctx, field)
-			case "number":
-				return ec.fieldContext_Customer_number(ctx, field)
-			case "gender":
-				return ec.fieldContext_Customer_gender(ctx, field)
-			case "country":
-				return ec.fieldContext_Customer_country(ctx, field)
-			case "dependants":
-				return ec.fieldContext_Customer_dependants(ctx, field)
-			case "birthDate":
-				return ec.fieldContext_Customer_birthDate(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Customer", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createCustomer_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateCustomer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_updateCustomer(ctx, field)
+func (ec *executionContext) _CustomerDataExport_exportedAt(ctx context.Context, field graphql.CollectedField, obj *model.CustomerDataExport) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CustomerDataExport_exportedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -962,7 +1286,7 @@ func (ec *executionContext) _Mutation_updateCustomer(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().UpdateCustomer(rctx, fc.Args["id"].(string), fc.Args["input"].(model.UpdateCustomerInput))
+		return obj.ExportedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -974,24 +1298,578 @@ func (ec *executionContext) _Mutation_updateCustomer(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Customer)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateCustomer(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CustomerDataExport_exportedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "CustomerDataExport",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Customer_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Customer_name(ctx, field)
-			case "surname":
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CustomerDataExport_data(ctx context.Context, field graphql.CollectedField, obj *model.CustomerDataExport) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CustomerDataExport_data(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Data, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CustomerDataExport_data(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CustomerDataExport",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureLogVerification_valid(ctx context.Context, field graphql.CollectedField, obj *model.ErasureLogVerification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureLogVerification_valid(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Valid, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureLogVerification_valid(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureLogVerification",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureLogVerification_entries(ctx context.Context, field graphql.CollectedField, obj *model.ErasureLogVerification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureLogVerification_entries(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Entries, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureLogVerification_entries(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureLogVerification",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureLogVerification_firstInvalidId(ctx context.Context, field graphql.CollectedField, obj *model.ErasureLogVerification) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureLogVerification_firstInvalidId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.FirstInvalidID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureLogVerification_firstInvalidId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureLogVerification",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureRecord_id(ctx context.Context, field graphql.CollectedField, obj *model.ErasureRecord) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureRecord_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureRecord_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureRecord",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureRecord_customerId(ctx context.Context, field graphql.CollectedField, obj *model.ErasureRecord) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureRecord_customerId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CustomerID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureRecord_customerId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureRecord",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureRecord_mode(ctx context.Context, field graphql.CollectedField, obj *model.ErasureRecord) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureRecord_mode(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Mode, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model.ErasureMode)
+	fc.Result = res
+	return ec.marshalNErasureMode2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureMode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureRecord_mode(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureRecord",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ErasureMode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureRecord_requestedBy(ctx context.Context, field graphql.CollectedField, obj *model.ErasureRecord) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureRecord_requestedBy(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RequestedBy, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureRecord_requestedBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureRecord",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureRecord_erasedAt(ctx context.Context, field graphql.CollectedField, obj *model.ErasureRecord) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureRecord_erasedAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ErasedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureRecord_erasedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureRecord",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ErasureRecord_hash(ctx context.Context, field graphql.CollectedField, obj *model.ErasureRecord) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ErasureRecord_hash(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Hash, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ErasureRecord_hash(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ErasureRecord",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_createCustomer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_createCustomer(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().CreateCustomer(rctx, fc.Args["input"].(model.CreateCustomerInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Customer)
+	fc.Result = res
+	return ec.marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_createCustomer(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Customer_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Customer_name(ctx, field)
+			case "surname":
+				return ec.fieldContext_Customer_surname(ctx, field)
+			case "number":
+				return ec.fieldContext_Customer_number(ctx, field)
+			case "gender":
+				return ec.fieldContext_Customer_gender(ctx, field)
+			case "country":
+				return ec.fieldContext_Customer_country(ctx, field)
+			case "dependants":
+				return ec.fieldContext_Customer_dependants(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Customer_birthDate(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Customer", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createCustomer_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_updateCustomer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_updateCustomer(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().UpdateCustomer(rctx, fc.Args["id"].(string), fc.Args["input"].(model.UpdateCustomerInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Customer)
+	fc.Result = res
+	return ec.marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateCustomer(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Customer_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Customer_name(ctx, field)
+			case "surname":
 				return ec.fieldContext_Customer_surname(ctx, field)
 			case "number":
 				return ec.fieldContext_Customer_number(ctx, field)
@@ -1076,6 +1954,102 @@ func (ec *executionContext) fieldContext_Mutation_deleteCustomer(ctx context.Con
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_eraseCustomer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_eraseCustomer(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().EraseCustomer(rctx, fc.Args["id"].(string), fc.Args["mode"].(model.ErasureMode))
+		}
+
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			scope, err := ec.unmarshalNString2string(ctx, "gdpr:erase")
+			if err != nil {
+				var zeroVal *model.ErasureRecord
+				return zeroVal, err
+			}
+			if ec.directives.HasScope == nil {
+				var zeroVal *model.ErasureRecord
+				return zeroVal, errors.New("directive hasScope is not implemented")
+			}
+			return ec.directives.HasScope(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.ErasureRecord); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *iohk-golang-backend/graph/model.ErasureRecord`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.ErasureRecord)
+	fc.Result = res
+	return ec.marshalNErasureRecord2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureRecord(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Mutation_eraseCustomer(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_ErasureRecord_id(ctx, field)
+			case "customerId":
+				return ec.fieldContext_ErasureRecord_customerId(ctx, field)
+			case "mode":
+				return ec.fieldContext_ErasureRecord_mode(ctx, field)
+			case "requestedBy":
+				return ec.fieldContext_ErasureRecord_requestedBy(ctx, field)
+			case "erasedAt":
+				return ec.fieldContext_ErasureRecord_erasedAt(ctx, field)
+			case "hash":
+				return ec.fieldContext_ErasureRecord_hash(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ErasureRecord", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_eraseCustomer_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_customer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query_customer(ctx, field)
 	if err != nil {
@@ -1101,7 +2075,7 @@ func (ec *executionContext) _Query_customer(ctx context.Context, field graphql.C
 	}
 	res := resTmp.(*model.Customer)
 	fc.Result = res
-	return ec.marshalOCustomer2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx, field.Selections, res)
+	return ec.marshalOCustomer2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) fieldContext_Query_customer(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
@@ -1157,10 +2131,189 @@ func (ec *executionContext) _Query_customers(ctx context.Context, field graphql.
 			ec.Error(ctx, ec.Recover(ctx, r))
 			ret = graphql.Null
 		}
-	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Customers(rctx)
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Customers(rctx)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Customer)
+	fc.Result = res
+	return ec.marshalNCustomer2ᚕᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomerᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_customers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Customer_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Customer_name(ctx, field)
+			case "surname":
+				return ec.fieldContext_Customer_surname(ctx, field)
+			case "number":
+				return ec.fieldContext_Customer_number(ctx, field)
+			case "gender":
+				return ec.fieldContext_Customer_gender(ctx, field)
+			case "country":
+				return ec.fieldContext_Customer_country(ctx, field)
+			case "dependants":
+				return ec.fieldContext_Customer_dependants(ctx, field)
+			case "birthDate":
+				return ec.fieldContext_Customer_birthDate(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Customer", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_exportCustomerData(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_exportCustomerData(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ExportCustomerData(rctx, fc.Args["id"].(string))
+		}
+
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			scope, err := ec.unmarshalNString2string(ctx, "gdpr:export")
+			if err != nil {
+				var zeroVal *model.CustomerDataExport
+				return zeroVal, err
+			}
+			if ec.directives.HasScope == nil {
+				var zeroVal *model.CustomerDataExport
+				return zeroVal, errors.New("directive hasScope is not implemented")
+			}
+			return ec.directives.HasScope(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CustomerDataExport); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *iohk-golang-backend/graph/model.CustomerDataExport`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.CustomerDataExport)
+	fc.Result = res
+	return ec.marshalNCustomerDataExport2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomerDataExport(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_exportCustomerData(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "customerId":
+				return ec.fieldContext_CustomerDataExport_customerId(ctx, field)
+			case "exportedAt":
+				return ec.fieldContext_CustomerDataExport_exportedAt(ctx, field)
+			case "data":
+				return ec.fieldContext_CustomerDataExport_data(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CustomerDataExport", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_exportCustomerData_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_verifyErasureLog(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_verifyErasureLog(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().VerifyErasureLog(rctx)
+		}
+
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			scope, err := ec.unmarshalNString2string(ctx, "gdpr:erase")
+			if err != nil {
+				var zeroVal *model.ErasureLogVerification
+				return zeroVal, err
+			}
+			if ec.directives.HasScope == nil {
+				var zeroVal *model.ErasureLogVerification
+				return zeroVal, errors.New("directive hasScope is not implemented")
+			}
+			return ec.directives.HasScope(ctx, nil, directive0, scope)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.ErasureLogVerification); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *iohk-golang-backend/graph/model.ErasureLogVerification`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1172,12 +2325,12 @@ func (ec *executionContext) _Query_customers(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Customer)
+	res := resTmp.(*model.ErasureLogVerification)
 	fc.Result = res
-	return ec.marshalNCustomer2ᚕᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomerᚄ(ctx, field.Selections, res)
+	return ec.marshalNErasureLogVerification2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureLogVerification(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_customers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_verifyErasureLog(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -1185,24 +2338,14 @@ func (ec *executionContext) fieldContext_Query_customers(_ context.Context, fiel
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Customer_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Customer_name(ctx, field)
-			case "surname":
-				return ec.fieldContext_Customer_surname(ctx, field)
-			case "number":
-				return ec.fieldContext_Customer_number(ctx, field)
-			case "gender":
-				return ec.fieldContext_Customer_gender(ctx, field)
-			case "country":
-				return ec.fieldContext_Customer_country(ctx, field)
-			case "dependants":
-				return ec.fieldContext_Customer_dependants(ctx, field)
-			case "birthDate":
-				return ec.fieldContext_Customer_birthDate(ctx, field)
+			case "valid":
+				return ec.fieldContext_ErasureLogVerification_valid(ctx, field)
+			case "entries":
+				return ec.fieldContext_ErasureLogVerification_entries(ctx, field)
+			case "firstInvalidId":
+				return ec.fieldContext_ErasureLogVerification_firstInvalidId(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Customer", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ErasureLogVerification", field.Name)
 		},
 	}
 	return fc, nil
@@ -3147,7 +4290,7 @@ func (ec *executionContext) unmarshalInputCreateCustomerInput(ctx context.Contex
 			it.Number = data
 		case "gender":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("gender"))
-			data, err := ec.unmarshalNGender2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx, v)
+			data, err := ec.unmarshalNGender2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -3216,7 +4359,7 @@ func (ec *executionContext) unmarshalInputUpdateCustomerInput(ctx context.Contex
 			it.Number = data
 		case "gender":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("gender"))
-			data, err := ec.unmarshalOGender2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx, v)
+			data, err := ec.unmarshalOGender2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -3330,6 +4473,165 @@ func (ec *executionContext) _Customer(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
+var customerDataExportImplementors = []string{"CustomerDataExport"}
+
+func (ec *executionContext) _CustomerDataExport(ctx context.Context, sel ast.SelectionSet, obj *model.CustomerDataExport) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, customerDataExportImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CustomerDataExport")
+		case "customerId":
+			out.Values[i] = ec._CustomerDataExport_customerId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "exportedAt":
+			out.Values[i] = ec._CustomerDataExport_exportedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "data":
+			out.Values[i] = ec._CustomerDataExport_data(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var erasureLogVerificationImplementors = []string{"ErasureLogVerification"}
+
+func (ec *executionContext) _ErasureLogVerification(ctx context.Context, sel ast.SelectionSet, obj *model.ErasureLogVerification) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, erasureLogVerificationImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ErasureLogVerification")
+		case "valid":
+			out.Values[i] = ec._ErasureLogVerification_valid(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "entries":
+			out.Values[i] = ec._ErasureLogVerification_entries(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "firstInvalidId":
+			out.Values[i] = ec._ErasureLogVerification_firstInvalidId(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var erasureRecordImplementors = []string{"ErasureRecord"}
+
+func (ec *executionContext) _ErasureRecord(ctx context.Context, sel ast.SelectionSet, obj *model.ErasureRecord) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, erasureRecordImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ErasureRecord")
+		case "id":
+			out.Values[i] = ec._ErasureRecord_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "customerId":
+			out.Values[i] = ec._ErasureRecord_customerId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "mode":
+			out.Values[i] = ec._ErasureRecord_mode(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "requestedBy":
+			out.Values[i] = ec._ErasureRecord_requestedBy(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "erasedAt":
+			out.Values[i] = ec._ErasureRecord_erasedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "hash":
+			out.Values[i] = ec._ErasureRecord_hash(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var mutationImplementors = []string{"Mutation"}
 
 func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
@@ -3370,6 +4672,13 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "eraseCustomer":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_eraseCustomer(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -3452,6 +4761,50 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "exportCustomerData":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_exportCustomerData(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "verifyErasureLog":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_verifyErasureLog(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "__type":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
@@ -3825,16 +5178,16 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 	return res
 }
 
-func (ec *executionContext) unmarshalNCreateCustomerInput2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCreateCustomerInput(ctx context.Context, v interface{}) (model.CreateCustomerInput, error) {
+func (ec *executionContext) unmarshalNCreateCustomerInput2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCreateCustomerInput(ctx context.Context, v interface{}) (model.CreateCustomerInput, error) {
 	res, err := ec.unmarshalInputCreateCustomerInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNCustomer2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx context.Context, sel ast.SelectionSet, v model.Customer) graphql.Marshaler {
+func (ec *executionContext) marshalNCustomer2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx context.Context, sel ast.SelectionSet, v model.Customer) graphql.Marshaler {
 	return ec._Customer(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNCustomer2ᚕᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomerᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Customer) graphql.Marshaler {
+func (ec *executionContext) marshalNCustomer2ᚕᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomerᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Customer) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -3858,7 +5211,7 @@ func (ec *executionContext) marshalNCustomer2ᚕᚖiohkᚑgolangᚑbackendᚑpre
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx, sel, v[i])
+			ret[i] = ec.marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -3878,7 +5231,7 @@ func (ec *executionContext) marshalNCustomer2ᚕᚖiohkᚑgolangᚑbackendᚑpre
 	return ret
 }
 
-func (ec *executionContext) marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx context.Context, sel ast.SelectionSet, v *model.Customer) graphql.Marshaler {
+func (ec *executionContext) marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx context.Context, sel ast.SelectionSet, v *model.Customer) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -3888,6 +5241,20 @@ func (ec *executionContext) marshalNCustomer2ᚖiohkᚑgolangᚑbackendᚑprepro
 	return ec._Customer(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNCustomerDataExport2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomerDataExport(ctx context.Context, sel ast.SelectionSet, v model.CustomerDataExport) graphql.Marshaler {
+	return ec._CustomerDataExport(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCustomerDataExport2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomerDataExport(ctx context.Context, sel ast.SelectionSet, v *model.CustomerDataExport) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CustomerDataExport(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNDate2string(ctx context.Context, v interface{}) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -3903,13 +5270,51 @@ func (ec *executionContext) marshalNDate2string(ctx context.Context, sel ast.Sel
 	return res
 }
 
-func (ec *executionContext) unmarshalNGender2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx context.Context, v interface{}) (model.Gender, error) {
+func (ec *executionContext) marshalNErasureLogVerification2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureLogVerification(ctx context.Context, sel ast.SelectionSet, v model.ErasureLogVerification) graphql.Marshaler {
+	return ec._ErasureLogVerification(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNErasureLogVerification2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureLogVerification(ctx context.Context, sel ast.SelectionSet, v *model.ErasureLogVerification) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ErasureLogVerification(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNErasureMode2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureMode(ctx context.Context, v interface{}) (model.ErasureMode, error) {
+	var res model.ErasureMode
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNErasureMode2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureMode(ctx context.Context, sel ast.SelectionSet, v model.ErasureMode) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) marshalNErasureRecord2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureRecord(ctx context.Context, sel ast.SelectionSet, v model.ErasureRecord) graphql.Marshaler {
+	return ec._ErasureRecord(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNErasureRecord2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐErasureRecord(ctx context.Context, sel ast.SelectionSet, v *model.ErasureRecord) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ErasureRecord(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNGender2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx context.Context, v interface{}) (model.Gender, error) {
 	var res model.Gender
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNGender2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx context.Context, sel ast.SelectionSet, v model.Gender) graphql.Marshaler {
+func (ec *executionContext) marshalNGender2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx context.Context, sel ast.SelectionSet, v model.Gender) graphql.Marshaler {
 	return v
 }
 
@@ -3943,6 +5348,16 @@ func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.Selecti
 	return res
 }
 
+func (ec *executionContext) unmarshalNPiiMask2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐPiiMask(ctx context.Context, v interface{}) (model.PiiMask, error) {
+	var res model.PiiMask
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNPiiMask2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐPiiMask(ctx context.Context, sel ast.SelectionSet, v model.PiiMask) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -3958,7 +5373,7 @@ func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.S
 	return res
 }
 
-func (ec *executionContext) unmarshalNUpdateCustomerInput2iohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐUpdateCustomerInput(ctx context.Context, v interface{}) (model.UpdateCustomerInput, error) {
+func (ec *executionContext) unmarshalNUpdateCustomerInput2iohkᚑgolangᚑbackendᚋgraphᚋmodelᚐUpdateCustomerInput(ctx context.Context, v interface{}) (model.UpdateCustomerInput, error) {
 	res, err := ec.unmarshalInputUpdateCustomerInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
@@ -4242,7 +5657,7 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
-func (ec *executionContext) marshalOCustomer2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐCustomer(ctx context.Context, sel ast.SelectionSet, v *model.Customer) graphql.Marshaler {
+func (ec *executionContext) marshalOCustomer2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐCustomer(ctx context.Context, sel ast.SelectionSet, v *model.Customer) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -4265,7 +5680,7 @@ func (ec *executionContext) marshalODate2ᚖstring(ctx context.Context, sel ast.
 	return res
 }
 
-func (ec *executionContext) unmarshalOGender2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx context.Context, v interface{}) (*model.Gender, error) {
+func (ec *executionContext) unmarshalOGender2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx context.Context, v interface{}) (*model.Gender, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -4274,13 +5689,29 @@ func (ec *executionContext) unmarshalOGender2ᚖiohkᚑgolangᚑbackendᚑprepro
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalOGender2ᚖiohkᚑgolangᚑbackendᚑpreprodᚋgraphᚋmodelᚐGender(ctx context.Context, sel ast.SelectionSet, v *model.Gender) graphql.Marshaler {
+func (ec *executionContext) marshalOGender2ᚖiohkᚑgolangᚑbackendᚋgraphᚋmodelᚐGender(ctx context.Context, sel ast.SelectionSet, v *model.Gender) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return v
 }
 
+func (ec *executionContext) unmarshalOID2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalID(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOID2ᚖstring(ctx context.Context, sel ast.SelectionSet, v *string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalID(*v)
+	return res
+}
+
 func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
 	if v == nil {
 		return nil, nil
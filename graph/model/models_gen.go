@@ -29,6 +29,27 @@ type Customer struct {
 	BirthDate  string `json:"birthDate"`
 }
 
+type CustomerDataExport struct {
+	CustomerID string `json:"customerId"`
+	ExportedAt string `json:"exportedAt"`
+	Data       string `json:"data"`
+}
+
+type ErasureLogVerification struct {
+	Valid          bool    `json:"valid"`
+	Entries        int     `json:"entries"`
+	FirstInvalidID *string `json:"firstInvalidId,omitempty"`
+}
+
+type ErasureRecord struct {
+	ID          string      `json:"id"`
+	CustomerID  string      `json:"customerId"`
+	Mode        ErasureMode `json:"mode"`
+	RequestedBy string      `json:"requestedBy"`
+	ErasedAt    string      `json:"erasedAt"`
+	Hash        string      `json:"hash"`
+}
+
 type Mutation struct {
 }
 
@@ -45,6 +66,47 @@ type UpdateCustomerInput struct {
 	BirthDate  *string `json:"birthDate,omitempty"`
 }
 
+type ErasureMode string
+
+const (
+	ErasureModeHardDelete ErasureMode = "HARD_DELETE"
+	ErasureModeAnonymise  ErasureMode = "ANONYMISE"
+)
+
+var AllErasureMode = []ErasureMode{
+	ErasureModeHardDelete,
+	ErasureModeAnonymise,
+}
+
+func (e ErasureMode) IsValid() bool {
+	switch e {
+	case ErasureModeHardDelete, ErasureModeAnonymise:
+		return true
+	}
+	return false
+}
+
+func (e ErasureMode) String() string {
+	return string(e)
+}
+
+func (e *ErasureMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ErasureMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ErasureMode", str)
+	}
+	return nil
+}
+
+func (e ErasureMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Gender string
 
 const (
@@ -85,3 +147,46 @@ func (e *Gender) UnmarshalGQL(v interface{}) error {
 func (e Gender) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type PiiMask string
+
+const (
+	PiiMaskInitial PiiMask = "INITIAL"
+	PiiMaskYear    PiiMask = "YEAR"
+	PiiMaskRedact  PiiMask = "REDACT"
+)
+
+var AllPiiMask = []PiiMask{
+	PiiMaskInitial,
+	PiiMaskYear,
+	PiiMaskRedact,
+}
+
+func (e PiiMask) IsValid() bool {
+	switch e {
+	case PiiMaskInitial, PiiMaskYear, PiiMaskRedact:
+		return true
+	}
+	return false
+}
+
+func (e PiiMask) String() string {
+	return string(e)
+}
+
+func (e *PiiMask) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PiiMask(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PiiMask", str)
+	}
+	return nil
+}
+
+func (e PiiMask) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -3,6 +3,8 @@ package graph
 import (
 	"context"
 	"iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/auth"
+	domainmodel "iohk-golang-backend/internal/domain/model"
 	"iohk-golang-backend/internal/domain/service"
 	"iohk-golang-backend/internal/infra/mapper"
 )
@@ -34,6 +36,22 @@ func (r *queryResolver) Customers(ctx context.Context) ([]*model.Customer, error
 	return mapper.DomainToGraphQLSlice(domainCustomers), nil
 }
 
+func (r *queryResolver) ExportCustomerData(ctx context.Context, id string) (*model.CustomerDataExport, error) {
+	export, err := r.customerService.ExportCustomerData(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.DataExportToGraphQL(export)
+}
+
+func (r *queryResolver) VerifyErasureLog(ctx context.Context) (*model.ErasureLogVerification, error) {
+	verification, err := r.customerService.VerifyErasureLog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ErasureLogVerificationToGraphQL(verification), nil
+}
+
 // Mutation Resolvers
 func (r *mutationResolver) CreateCustomer(ctx context.Context, input model.CreateCustomerInput) (*model.Customer, error) {
 	domainCustomer := mapper.CreateInputToDomain(&input)
@@ -57,6 +75,18 @@ func (r *mutationResolver) DeleteCustomer(ctx context.Context, id string) (bool,
 	return r.customerService.DeleteCustomer(ctx, id)
 }
 
+func (r *mutationResolver) EraseCustomer(ctx context.Context, id string, mode model.ErasureMode) (*model.ErasureRecord, error) {
+	requestedBy := "unknown"
+	if principal := auth.FromContext(ctx); principal != nil {
+		requestedBy = principal.Name
+	}
+	record, err := r.customerService.EraseCustomer(ctx, id, domainmodel.ErasureMode(mode), requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ErasureRecordToGraphQL(record), nil
+}
+
 // Resolver type assertions
 func (r *Resolver) Query() QueryResolver       { return &queryResolver{r} }
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
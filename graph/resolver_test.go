@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/auth"
 	internalModel "iohk-golang-backend/internal/domain/model"
 )
 
@@ -51,6 +52,30 @@ func (m *MockCustomerService) DeleteCustomer(ctx context.Context, id string) (bo
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCustomerService) ExportCustomerData(ctx context.Context, id string) (*internalModel.CustomerDataExport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*internalModel.CustomerDataExport), args.Error(1)
+}
+
+func (m *MockCustomerService) EraseCustomer(ctx context.Context, id string, mode internalModel.ErasureMode, requestedBy string) (*internalModel.ErasureRecord, error) {
+	args := m.Called(ctx, id, mode, requestedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*internalModel.ErasureRecord), args.Error(1)
+}
+
+func (m *MockCustomerService) VerifyErasureLog(ctx context.Context) (*internalModel.ErasureLogVerification, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*internalModel.ErasureLogVerification), args.Error(1)
+}
+
 func TestCustomer(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -369,3 +394,108 @@ func TestDeleteCustomer(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestExportCustomerData(t *testing.T) {
+	// Arrange
+	mockService := new(MockCustomerService)
+	resolver := &Resolver{customerService: mockService}
+	mockService.On("ExportCustomerData", mock.Anything, "1").Return(&internalModel.CustomerDataExport{
+		FormatVersion: 1,
+		ExportedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Customer:      internalModel.CustomerExport{ID: 1, Name: "Alice", BirthDate: "1990-01-01"},
+		ErasureLog:    []internalModel.ErasureRecordExport{},
+	}, nil)
+
+	// Act
+	result, err := resolver.Query().ExportCustomerData(context.Background(), "1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result.CustomerID)
+	assert.Equal(t, "2024-01-02T03:04:05Z", result.ExportedAt)
+	assert.JSONEq(t, `{
+		"formatVersion": 1,
+		"exportedAt": "2024-01-02T03:04:05Z",
+		"customer": {"id": 1, "name": "Alice", "surname": "", "number": 0, "gender": "", "country": "", "dependants": 0, "birthDate": "1990-01-01"},
+		"erasureLog": []
+	}`, result.Data)
+	mockService.AssertExpectations(t)
+}
+
+func TestEraseCustomer(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		requestedBy   string
+		expectedError error
+	}{
+		{
+			name:        "Erasure recorded against the principal",
+			ctx:         auth.WithPrincipal(context.Background(), &auth.Principal{Name: "dpo"}),
+			requestedBy: "dpo",
+		},
+		{
+			name:        "Erasure without a principal",
+			ctx:         context.Background(),
+			requestedBy: "unknown",
+		},
+		{
+			name:          "Service error",
+			ctx:           context.Background(),
+			requestedBy:   "unknown",
+			expectedError: errors.New("customer not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockCustomerService)
+			resolver := &Resolver{customerService: mockService}
+			if tc.expectedError != nil {
+				mockService.On("EraseCustomer", mock.Anything, "1", internalModel.ErasureModeAnonymise, tc.requestedBy).Return(nil, tc.expectedError)
+			} else {
+				mockService.On("EraseCustomer", mock.Anything, "1", internalModel.ErasureModeAnonymise, tc.requestedBy).Return(&internalModel.ErasureRecord{
+					ID:          7,
+					CustomerID:  1,
+					Mode:        internalModel.ErasureModeAnonymise,
+					RequestedBy: tc.requestedBy,
+					ErasedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					Hash:        "abc",
+				}, nil)
+			}
+
+			// Act
+			result, err := resolver.Mutation().EraseCustomer(tc.ctx, "1", model.ErasureModeAnonymise)
+
+			// Assert
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "7", result.ID)
+				assert.Equal(t, model.ErasureModeAnonymise, result.Mode)
+				assert.Equal(t, tc.requestedBy, result.RequestedBy)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVerifyErasureLog(t *testing.T) {
+	// Arrange
+	mockService := new(MockCustomerService)
+	resolver := &Resolver{customerService: mockService}
+	mockService.On("VerifyErasureLog", mock.Anything).Return(&internalModel.ErasureLogVerification{Valid: false, Entries: 3, FirstInvalidID: 2}, nil)
+
+	// Act
+	result, err := resolver.Query().VerifyErasureLog(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, 3, result.Entries)
+	assert.Equal(t, "2", *result.FirstInvalidID)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,137 @@
+// Package allowlist restricts the GraphQL endpoint to operations registered
+// ahead of time in a persisted query manifest.
+package allowlist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// CodeOperationNotAllowed is the error code of operations missing from the allowlist.
+const CodeOperationNotAllowed = "OPERATION_NOT_ALLOWED"
+
+// Allowlist is a gqlgen extension that only lets through operations present
+// in its manifest. Clients either send the full query text, which must match
+// a registered query exactly, or only its hash using the automatic persisted
+// query protocol, in which case the query is taken from the manifest.
+type Allowlist struct {
+	queries map[string]string
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = &Allowlist{}
+
+// Load reads a manifest file, see Parse for its format.
+func Load(path string) (*Allowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowlist manifest: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse reads a manifest mapping the hex encoded SHA-256 hash of each query
+// to its text, the format frontend persisted document tooling generates.
+func Parse(data []byte) (*Allowlist, error) {
+	var queries map[string]string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("failed to parse allowlist manifest: %w", err)
+	}
+	for hash, query := range queries {
+		if queryHash(query) != hash {
+			return nil, fmt.Errorf("allowlist manifest entry %s does not match the SHA-256 hash of its query", hash)
+		}
+	}
+	return &Allowlist{queries: queries}, nil
+}
+
+// Len returns the number of allowed operations.
+func (a *Allowlist) Len() int {
+	return len(a.queries)
+}
+
+// OperationNames returns the names of the operations in the manifest. Unlike
+// the names clients send, they are a fixed set, so they are safe to use as
+// metric labels.
+func (a *Allowlist) OperationNames() map[string]bool {
+	names := make(map[string]bool)
+	for _, query := range a.queries {
+		doc, err := parser.ParseQuery(&ast.Source{Input: query})
+		if err != nil {
+			continue // Never runs, so its name is never recorded either
+		}
+		for _, op := range doc.Operations {
+			if op.Name != "" {
+				names[op.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+func (*Allowlist) ExtensionName() string {
+	return "Allowlist"
+}
+
+func (*Allowlist) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a *Allowlist) MutateOperationParameters(ctx context.Context, params *graphql.RawParams) *gqlerror.Error {
+	hash, hasHash := persistedQueryHash(params)
+
+	if params.Query == "" {
+		if !hasHash {
+			return nil // Nothing to run, gqlgen reports the missing query
+		}
+		query, ok := a.queries[hash]
+		if !ok {
+			return notAllowed("persisted query is not in the allowlist")
+		}
+		params.Query = query
+		return nil
+	}
+
+	queryHash := queryHash(params.Query)
+	if hasHash && hash != queryHash {
+		return gqlerror.Errorf("provided persisted query hash does not match query")
+	}
+	if _, ok := a.queries[queryHash]; !ok {
+		return notAllowed("operation is not in the allowlist")
+	}
+	return nil
+}
+
+// persistedQueryHash returns the hash of the automatic persisted query
+// extension, if the request has one.
+func persistedQueryHash(params *graphql.RawParams) (string, bool) {
+	ext, ok := params.Extensions["persistedQuery"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	hash, ok := ext["sha256Hash"].(string)
+	return hash, ok && hash != ""
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+func notAllowed(message string) *gqlerror.Error {
+	err := gqlerror.Errorf("%s", message)
+	errcode.Set(err, CodeOperationNotAllowed)
+	return err
+}
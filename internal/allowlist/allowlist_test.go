@@ -0,0 +1,132 @@
+//go:build testcoverage
+// +build testcoverage
+
+package allowlist
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allowedQuery = `query Customers { customers { id name } }`
+
+func newTestAllowlist(t *testing.T) *Allowlist {
+	a, err := Parse([]byte(`{"` + queryHash(allowedQuery) + `": "` + allowedQuery + `"}`))
+	require.NoError(t, err)
+	return a
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		manifest      string
+		expectedLen   int
+		expectedError string
+	}{
+		{name: "Valid manifest", manifest: `{"` + queryHash(allowedQuery) + `": "` + allowedQuery + `"}`, expectedLen: 1},
+		{name: "Empty manifest", manifest: `{}`, expectedLen: 0},
+		{name: "Hash mismatch", manifest: `{"abc": "` + allowedQuery + `"}`, expectedError: "allowlist manifest entry abc does not match the SHA-256 hash of its query"},
+		{name: "Invalid JSON", manifest: `[`, expectedError: "failed to parse allowlist manifest: unexpected end of JSON input"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			a, err := Parse([]byte(tc.manifest))
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLen, a.Len())
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"`+queryHash(allowedQuery)+`": "`+allowedQuery+`"}`), 0o600))
+
+	// Act
+	a, err := Load(path)
+	_, missingErr := Load(filepath.Join(t.TempDir(), "missing.json"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, a.Len())
+	assert.ErrorContains(t, missingErr, "failed to read allowlist manifest")
+}
+
+func TestOperationNames(t *testing.T) {
+	// Arrange
+	queries := []string{allowedQuery, `query { customers { id } }`, `mutation Erase { eraseCustomer(id: "1", mode: ANONYMISE) { id } }`, `query Broken {`}
+	manifest := map[string]string{}
+	for _, q := range queries {
+		manifest[queryHash(q)] = q
+	}
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	a, err := Parse(data)
+	require.NoError(t, err)
+
+	// Act
+	names := a.OperationNames()
+
+	// Assert
+	assert.Equal(t, map[string]bool{"Customers": true, "Erase": true}, names)
+}
+
+func TestMutateOperationParameters(t *testing.T) {
+	persisted := func(hash string) map[string]interface{} {
+		return map[string]interface{}{"persistedQuery": map[string]interface{}{"version": float64(1), "sha256Hash": hash}}
+	}
+
+	testCases := []struct {
+		name          string
+		params        graphql.RawParams
+		expectedQuery string
+		expectedCode  string
+		expectedError string
+	}{
+		{name: "Allowed query", params: graphql.RawParams{Query: allowedQuery}, expectedQuery: allowedQuery},
+		{name: "Unknown query", params: graphql.RawParams{Query: `{ customers { id } }`}, expectedCode: CodeOperationNotAllowed, expectedError: "operation is not in the allowlist"},
+		{name: "Persisted hash", params: graphql.RawParams{Extensions: persisted(queryHash(allowedQuery))}, expectedQuery: allowedQuery},
+		{name: "Unknown persisted hash", params: graphql.RawParams{Extensions: persisted("abc")}, expectedCode: CodeOperationNotAllowed, expectedError: "persisted query is not in the allowlist"},
+		{name: "Hash and query", params: graphql.RawParams{Query: allowedQuery, Extensions: persisted(queryHash(allowedQuery))}, expectedQuery: allowedQuery},
+		{name: "Hash not matching query", params: graphql.RawParams{Query: allowedQuery, Extensions: persisted("abc")}, expectedError: "provided persisted query hash does not match query"},
+		{name: "No query", params: graphql.RawParams{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			a := newTestAllowlist(t)
+			params := tc.params
+
+			// Act
+			err := a.MutateOperationParameters(context.Background(), &params)
+
+			// Assert
+			if tc.expectedError == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedQuery, params.Query)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, tc.expectedError, err.Message)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, err.Extensions["code"])
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Scopes understood by the API.
+const (
+	ScopeAll     = "*"
+	ScopePIIRead = "pii:read"
+	ScopeExport  = "gdpr:export"
+	ScopeErase   = "gdpr:erase"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Name   string
+	Scopes []string
+}
+
+// Anonymous is attached to every request when authentication is disabled.
+var Anonymous = &Principal{Name: "anonymous", Scopes: []string{ScopeAll}}
+
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAll {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of the request, or nil if there is none.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// HasScope reports whether the principal in ctx holds the given scope.
+func HasScope(ctx context.Context, scope string) bool {
+	return FromContext(ctx).HasScope(scope)
+}
+
+// ParseAPIKeys parses a comma-separated list of "name:key:scope scope" entries,
+// e.g. "callcentre:s3cret,admin:t0psecret:pii:read", into principals keyed by API key.
+func ParseAPIKeys(raw string) (map[string]*Principal, error) {
+	keys := make(map[string]*Principal)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid API key entry %q, expected name:key[:scopes]", entry)
+		}
+		if _, exists := keys[parts[1]]; exists {
+			return nil, fmt.Errorf("duplicate API key for %q", parts[0])
+		}
+
+		principal := &Principal{Name: parts[0]}
+		if len(parts) == 3 {
+			principal.Scopes = strings.Fields(parts[2])
+		}
+		keys[parts[1]] = principal
+	}
+	return keys, nil
+}
+
+// ParseClientCerts parses a comma-separated list of "name[:scope scope]"
+// entries, e.g. "billing:pii:read,reporting", into principals keyed by the
+// common name of the client certificates they authenticate.
+func ParseClientCerts(raw string) (map[string]*Principal, error) {
+	certs := make(map[string]*Principal)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, scopes, _ := strings.Cut(entry, ":")
+		if name == "" {
+			return nil, fmt.Errorf("invalid client certificate entry %q, expected name[:scopes]", entry)
+		}
+		if _, exists := certs[name]; exists {
+			return nil, fmt.Errorf("duplicate client certificate %q", name)
+		}
+		principal := &Principal{Name: name}
+		if scopes != "" {
+			principal.Scopes = strings.Fields(scopes)
+		}
+		certs[name] = principal
+	}
+	return certs, nil
+}
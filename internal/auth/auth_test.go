@@ -0,0 +1,121 @@
+//go:build testcoverage
+// +build testcoverage
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	testCases := []struct {
+		name          string
+		raw           string
+		expected      map[string]*Principal
+		expectedError string
+	}{
+		{
+			name:     "Empty configuration",
+			raw:      "",
+			expected: map[string]*Principal{},
+		},
+		{
+			name: "Keys with and without scopes",
+			raw:  "callcentre:abc, admin:xyz:pii:read gdpr:erase",
+			expected: map[string]*Principal{
+				"abc": {Name: "callcentre"},
+				"xyz": {Name: "admin", Scopes: []string{"pii:read", "gdpr:erase"}},
+			},
+		},
+		{
+			name:          "Missing key",
+			raw:           "callcentre",
+			expectedError: `invalid API key entry "callcentre", expected name:key[:scopes]`,
+		},
+		{
+			name:          "Duplicate key",
+			raw:           "a:abc,b:abc",
+			expectedError: `duplicate API key for "b"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			keys, err := ParseAPIKeys(tc.raw)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, keys)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, keys)
+			}
+		})
+	}
+}
+
+func TestParseClientCerts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		raw           string
+		expected      map[string]*Principal
+		expectedError string
+	}{
+		{
+			name:     "Empty configuration",
+			raw:      "",
+			expected: map[string]*Principal{},
+		},
+		{
+			name: "Certificates with and without scopes",
+			raw:  "reporting, billing:pii:read gdpr:export",
+			expected: map[string]*Principal{
+				"reporting": {Name: "reporting"},
+				"billing":   {Name: "billing", Scopes: []string{"pii:read", "gdpr:export"}},
+			},
+		},
+		{
+			name:          "Missing name",
+			raw:           ":pii:read",
+			expectedError: `invalid client certificate entry ":pii:read", expected name[:scopes]`,
+		},
+		{
+			name:          "Duplicate name",
+			raw:           "billing,billing:pii:read",
+			expectedError: `duplicate client certificate "billing"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			certs, err := ParseClientCerts(tc.raw)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, certs)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, certs)
+			}
+		})
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	// Arrange
+	reader := &Principal{Name: "reader", Scopes: []string{ScopePIIRead}}
+	ctx := WithPrincipal(context.Background(), reader)
+
+	// Act & Assert
+	assert.True(t, HasScope(ctx, ScopePIIRead))
+	assert.False(t, HasScope(ctx, "gdpr:erase"))
+	assert.True(t, Anonymous.HasScope("gdpr:erase"))
+	assert.False(t, HasScope(context.Background(), ScopePIIRead))
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"net/http"
+	"strings"
+)
+
+// Middleware authenticates requests by verified TLS client certificate, looked
+// up in certs by its common name, or by API key, read from the X-API-Key
+// header or an "Authorization: Bearer" header. When neither keys nor
+// certificates are configured, authentication is disabled and every request
+// runs as Anonymous.
+func Middleware(keys, certs map[string]*Principal) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(keys) == 0 && len(certs) == 0 {
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), Anonymous)))
+				return
+			}
+
+			principal := clientCertPrincipal(certs, r.TLS)
+			if principal == nil {
+				principal = lookup(keys, apiKeyFromRequest(r))
+			}
+			if principal == nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// clientCertPrincipal only trusts certificates the TLS handshake verified
+// against the client CA bundle.
+func clientCertPrincipal(certs map[string]*Principal, state *tls.ConnectionState) *Principal {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return certs[state.VerifiedChains[0][0].Subject.CommonName]
+}
+
+func apiKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+// lookup compares against every key in constant time so response timing
+// does not reveal how much of a key was correct.
+func lookup(keys map[string]*Principal, candidate string) *Principal {
+	if candidate == "" {
+		return nil
+	}
+	var found *Principal
+	for key, principal := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(candidate)) == 1 {
+			found = principal
+		}
+	}
+	return found
+}
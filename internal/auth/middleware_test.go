@@ -0,0 +1,159 @@
+//go:build testcoverage
+// +build testcoverage
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	keys := map[string]*Principal{
+		"abc": {Name: "callcentre"},
+	}
+
+	testCases := []struct {
+		name           string
+		keys           map[string]*Principal
+		headers        map[string]string
+		expectedStatus int
+		expectedName   string
+	}{
+		{
+			name:           "Authentication disabled",
+			keys:           nil,
+			expectedStatus: http.StatusOK,
+			expectedName:   "anonymous",
+		},
+		{
+			name:           "Valid X-API-Key header",
+			keys:           keys,
+			headers:        map[string]string{"X-API-Key": "abc"},
+			expectedStatus: http.StatusOK,
+			expectedName:   "callcentre",
+		},
+		{
+			name:           "Valid bearer token",
+			keys:           keys,
+			headers:        map[string]string{"Authorization": "Bearer abc"},
+			expectedStatus: http.StatusOK,
+			expectedName:   "callcentre",
+		},
+		{
+			name:           "Invalid key",
+			keys:           keys,
+			headers:        map[string]string{"X-API-Key": "wrong"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Missing key",
+			keys:           keys,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var principal *Principal
+			handler := Middleware(tc.keys, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal = FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedName != "" {
+				assert.Equal(t, tc.expectedName, principal.Name)
+			} else {
+				assert.Nil(t, principal)
+			}
+		})
+	}
+}
+
+func TestMiddlewareClientCertificate(t *testing.T) {
+	keys := map[string]*Principal{
+		"abc": {Name: "callcentre"},
+	}
+	certs := map[string]*Principal{
+		"billing": {Name: "billing", Scopes: []string{ScopePIIRead}},
+	}
+	verified := func(commonName string) *tls.ConnectionState {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+
+	testCases := []struct {
+		name           string
+		tls            *tls.ConnectionState
+		apiKey         string
+		expectedStatus int
+		expectedName   string
+	}{
+		{
+			name:           "Known client certificate",
+			tls:            verified("billing"),
+			expectedStatus: http.StatusOK,
+			expectedName:   "billing",
+		},
+		{
+			name:           "Unknown client certificate",
+			tls:            verified("reporting"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Unknown client certificate with API key",
+			tls:            verified("reporting"),
+			apiKey:         "abc",
+			expectedStatus: http.StatusOK,
+			expectedName:   "callcentre",
+		},
+		{
+			name:           "Unverified client certificate",
+			tls:            &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "billing"}}}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var principal *Principal
+			handler := Middleware(keys, certs)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal = FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			req.TLS = tc.tls
+			if tc.apiKey != "" {
+				req.Header.Set("X-API-Key", tc.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedName != "" {
+				assert.Equal(t, tc.expectedName, principal.Name)
+			} else {
+				assert.Nil(t, principal)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Package buildinfo holds the version and commit the binary was built from.
+// Both are injected at link time:
+//
+//	go build -ldflags "-X iohk-golang-backend/internal/buildinfo.Version=v1.2.3 -X iohk-golang-backend/internal/buildinfo.Commit=$(git rev-parse HEAD)"
+package buildinfo
+
+import "runtime/debug"
+
+var (
+	// Version is the release the binary was built from.
+	Version = "dev"
+	// Commit is the git commit the binary was built from. When it is not
+	// injected it falls back to the revision the Go toolchain stamped, if any.
+	Commit = ""
+)
+
+func init() {
+	if Commit != "" {
+		return
+	}
+	Commit = "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				Commit = s.Value
+			}
+		}
+	}
+}
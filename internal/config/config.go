@@ -1,87 +1,336 @@
 package config
 
 import (
-	"fmt"
-	"log"
+	"errors"
+	"net"
+	"strings"
 	"time"
 
-	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
+
+	"iohk-golang-backend/internal/logging"
+)
+
+// EnvProduction is the APP_ENV value of production deployments.
+const EnvProduction = "production"
+
+// STORAGE values: customers in PostgreSQL, in a SQLite file, or in memory
+// until the process exits.
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// DB_PENDING_MIGRATIONS values: refuse to start, or log a warning and serve.
+const (
+	PendingMigrationsFail = "fail"
+	PendingMigrationsWarn = "warn"
 )
 
 type Config struct {
-	PostgresUser        string
-	PostgresPassword    string
-	PostgresDB          string
-	PostgresHost        string
-	PostgresPort        string
-	PostgresSSLMode     string
-	DBMaxConns          int
-	DBMinConns          int
-	DBMaxConnLifetime   time.Duration
-	DBMaxConnIdleTime   time.Duration
-	DBHealthCheckPeriod time.Duration
-	AppHost             string `envconfig:"APP_HOST" required:"true"`
-	AppPort             string `envconfig:"APP_PORT" required:"true"`
+	// ConfigFile is the path of the config file that was read, if any.
+	ConfigFile string
+	AppEnv     string
+	// Storage is where customers are kept, StoragePostgres, StorageSQLite or
+	// StorageMemory
+	Storage string
+	// SQLitePath is the database file of StorageSQLite
+	SQLitePath       string
+	DatabaseURL      string
+	PostgresUser     string
+	PostgresPassword string
+	PostgresDB       string
+	PostgresHost     string
+	PostgresPort     string
+	PostgresSSLMode  string
+	// Optional connection parameters, added to the DSN built from the
+	// POSTGRES_* fields or DATABASE_URL
+	PostgresApplicationName    string
+	PostgresSearchPath         string
+	PostgresStatementTimeout   time.Duration
+	PostgresConnectTimeout     time.Duration
+	PostgresTargetSessionAttrs string
+	DBMaxConns                 int
+	DBMinConns                 int
+	DBMaxConnLifetime          time.Duration
+	DBMaxConnIdleTime          time.Duration
+	DBHealthCheckPeriod        time.Duration
+	// Optional read replica for customer reads, checked every
+	// DBReplicaCheckInterval. Clients read from the primary for
+	// DBReplicaPinWindow after they wrote, tracked with tokens signed with
+	// DBReplicaPinKey.
+	ReplicaDatabaseURL     string
+	DBReplicaCheckInterval time.Duration
+	DBReplicaPinWindow     time.Duration
+	DBReplicaPinKey        string
+	// DBPendingMigrations is what the server does when the database lacks
+	// migrations it was built with, PendingMigrationsFail or
+	// PendingMigrationsWarn
+	DBPendingMigrations string
+	// DevDatabaseURL is an empty scratch database `migrate new` replays the
+	// migrations on
+	DevDatabaseURL   string
+	AppHost          string `envconfig:"APP_HOST" required:"true"`
+	AppPort          string `envconfig:"APP_PORT" required:"true"`
+	ShutdownTimeout  time.Duration
+	ReadinessTimeout time.Duration
+	// AdminAddr is the host:port of the listener serving /metrics and
+	// /debug/info, apart from the API
+	AdminAddr                string
+	TracingExporter          string
+	TracingSampleRatio       float64
+	LogLevel                 string
+	LogFormat                string
+	CORSAllowedOrigins       string
+	CORSAllowedMethods       string
+	CORSAllowedHeaders       string
+	CORSAllowCredentials     bool
+	CORSMaxAge               time.Duration
+	TLSCertFile              string
+	TLSKeyFile               string
+	TLSClientCAFile          string
+	TLSReloadInterval        time.Duration
+	AuthAPIKeys              string
+	AuthClientCerts          string
+	PIIEncryptionKeys        string
+	PIIActiveKeyVersion      int
+	PIIBlindIndexKey         string
+	ErasureLogKey            string
+	RateLimitEnabled         bool
+	RateLimitRPS             float64
+	RateLimitBurst           int
+	RateLimitTokenComplexity int
+	RateLimitIPRPS           float64
+	RateLimitIPBurst         int
+	RateLimitTrustProxy      bool
+	GraphQLMaxComplexity     int
+	GraphQLMaxDepth          int
+	GraphQLAPQCacheSize      int
+	GraphQLAllowlist         string
 }
 
-func LoadConfig() (*Config, error) {
-	envFile := ".env.local"
-
-	if err := godotenv.Load(envFile); err != nil {
-		log.Printf("Error loading %s file: %v", envFile, err)
+// LoadConfig reads the configuration from, in increasing precedence, the
+// defaults, a dotenv file passed with --env-file, a YAML or TOML config file,
+// environment variables and the command-line flags in args. The config file
+// is the one passed with --config, or config/<APP_ENV>.yaml, .yml or .toml if
+// that exists. Every setting can also be read from a file named by a
+// <NAME>_FILE variable.
+func LoadConfig(args []string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+	flags, err := parseFlags(v, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := readEnvFile(v, flags); err != nil {
+		return nil, err
+	}
+	v.AutomaticEnv()
+	if err := readSecretFiles(v, flags); err != nil {
+		return nil, err
+	}
+	if err := readConfigFile(v, flags); err != nil {
+		return nil, err
 	}
-
-	viper.AutomaticEnv()
 
 	config := &Config{
-		PostgresUser:        viper.GetString("POSTGRES_USER"),
-		PostgresPassword:    viper.GetString("POSTGRES_PASSWORD"),
-		PostgresDB:          viper.GetString("POSTGRES_DB"),
-		PostgresHost:        viper.GetString("POSTGRES_HOST"),
-		PostgresPort:        viper.GetString("POSTGRES_PORT"),
-		PostgresSSLMode:     viper.GetString("POSTGRES_SSLMODE"),
-		DBMaxConns:          viper.GetInt("DB_MAX_CONNS"),
-		DBMinConns:          viper.GetInt("DB_MIN_CONNS"),
-		DBMaxConnLifetime:   viper.GetDuration("DB_MAX_CONN_LIFETIME"),
-		DBMaxConnIdleTime:   viper.GetDuration("DB_MAX_CONN_IDLE_TIME"),
-		DBHealthCheckPeriod: viper.GetDuration("DB_HEALTH_CHECK_PERIOD"),
-		AppHost:             viper.GetString("APP_HOST"),
-		AppPort:             viper.GetString("APP_PORT"),
+		AppEnv:                     v.GetString("APP_ENV"),
+		Storage:                    v.GetString("STORAGE"),
+		SQLitePath:                 v.GetString("SQLITE_PATH"),
+		DatabaseURL:                v.GetString("DATABASE_URL"),
+		PostgresUser:               v.GetString("POSTGRES_USER"),
+		PostgresPassword:           v.GetString("POSTGRES_PASSWORD"),
+		PostgresDB:                 v.GetString("POSTGRES_DB"),
+		PostgresHost:               v.GetString("POSTGRES_HOST"),
+		PostgresPort:               v.GetString("POSTGRES_PORT"),
+		PostgresSSLMode:            v.GetString("POSTGRES_SSLMODE"),
+		PostgresApplicationName:    v.GetString("POSTGRES_APPLICATION_NAME"),
+		PostgresSearchPath:         v.GetString("POSTGRES_SEARCH_PATH"),
+		PostgresStatementTimeout:   v.GetDuration("POSTGRES_STATEMENT_TIMEOUT"),
+		PostgresConnectTimeout:     v.GetDuration("POSTGRES_CONNECT_TIMEOUT"),
+		PostgresTargetSessionAttrs: v.GetString("POSTGRES_TARGET_SESSION_ATTRS"),
+		DBMaxConns:                 v.GetInt("DB_MAX_CONNS"),
+		DBMinConns:                 v.GetInt("DB_MIN_CONNS"),
+		DBMaxConnLifetime:          v.GetDuration("DB_MAX_CONN_LIFETIME"),
+		DBMaxConnIdleTime:          v.GetDuration("DB_MAX_CONN_IDLE_TIME"),
+		DBHealthCheckPeriod:        v.GetDuration("DB_HEALTH_CHECK_PERIOD"),
+		ReplicaDatabaseURL:         v.GetString("REPLICA_DATABASE_URL"),
+		DBReplicaCheckInterval:     v.GetDuration("DB_REPLICA_CHECK_INTERVAL"),
+		DBReplicaPinWindow:         v.GetDuration("DB_REPLICA_PIN_WINDOW"),
+		DBReplicaPinKey:            v.GetString("DB_REPLICA_PIN_KEY"),
+		DBPendingMigrations:        v.GetString("DB_PENDING_MIGRATIONS"),
+		DevDatabaseURL:             v.GetString("DEV_DATABASE_URL"),
+		AppHost:                    v.GetString("APP_HOST"),
+		AppPort:                    v.GetString("APP_PORT"),
+		ShutdownTimeout:            v.GetDuration("SHUTDOWN_TIMEOUT"),
+		ReadinessTimeout:           v.GetDuration("READINESS_TIMEOUT"),
+		AdminAddr:                  v.GetString("ADMIN_ADDR"),
+		TracingExporter:            v.GetString("TRACING_EXPORTER"),
+		TracingSampleRatio:         v.GetFloat64("TRACING_SAMPLE_RATIO"),
+		LogLevel:                   v.GetString("LOG_LEVEL"),
+		LogFormat:                  v.GetString("LOG_FORMAT"),
+		CORSAllowedOrigins:         v.GetString("CORS_ALLOWED_ORIGINS"),
+		CORSAllowedMethods:         v.GetString("CORS_ALLOWED_METHODS"),
+		CORSAllowedHeaders:         v.GetString("CORS_ALLOWED_HEADERS"),
+		CORSAllowCredentials:       v.GetBool("CORS_ALLOW_CREDENTIALS"),
+		CORSMaxAge:                 v.GetDuration("CORS_MAX_AGE"),
+		TLSCertFile:                v.GetString("TLS_CERT_FILE"),
+		TLSKeyFile:                 v.GetString("TLS_KEY_FILE"),
+		TLSClientCAFile:            v.GetString("TLS_CLIENT_CA_FILE"),
+		TLSReloadInterval:          v.GetDuration("TLS_RELOAD_INTERVAL"),
+		AuthAPIKeys:                v.GetString("AUTH_API_KEYS"),
+		AuthClientCerts:            v.GetString("AUTH_CLIENT_CERTS"),
+		PIIEncryptionKeys:          v.GetString("PII_ENCRYPTION_KEYS"),
+		PIIActiveKeyVersion:        v.GetInt("PII_ACTIVE_KEY_VERSION"),
+		PIIBlindIndexKey:           v.GetString("PII_BLIND_INDEX_KEY"),
+		ErasureLogKey:              v.GetString("ERASURE_LOG_KEY"),
+		RateLimitEnabled:           v.GetBool("RATE_LIMIT_ENABLED"),
+		RateLimitRPS:               v.GetFloat64("RATE_LIMIT_RPS"),
+		RateLimitBurst:             v.GetInt("RATE_LIMIT_BURST"),
+		RateLimitTokenComplexity:   v.GetInt("RATE_LIMIT_TOKEN_COMPLEXITY"),
+		RateLimitIPRPS:             v.GetFloat64("RATE_LIMIT_IP_RPS"),
+		RateLimitIPBurst:           v.GetInt("RATE_LIMIT_IP_BURST"),
+		RateLimitTrustProxy:        v.GetBool("RATE_LIMIT_TRUST_PROXY"),
+		GraphQLMaxComplexity:       v.GetInt("GRAPHQL_MAX_COMPLEXITY"),
+		GraphQLMaxDepth:            v.GetInt("GRAPHQL_MAX_DEPTH"),
+		GraphQLAPQCacheSize:        v.GetInt("GRAPHQL_APQ_CACHE_SIZE"),
+		GraphQLAllowlist:           v.GetString("GRAPHQL_ALLOWLIST"),
 	}
 
+	config.ConfigFile = v.ConfigFileUsed()
+
 	if err := validateConfig(config); err != nil {
-		return nil, err // Return nil and the error if validation fails
+		return nil, err // Return nil and the errors if validation fails
 	}
 
 	return config, nil
 }
 
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("GRAPHQL_MAX_COMPLEXITY", 1000)
+	v.SetDefault("RATE_LIMIT_TOKEN_COMPLEXITY", 25)
+	v.SetDefault("RATE_LIMIT_IP_RPS", 50)
+	v.SetDefault("RATE_LIMIT_IP_BURST", 200)
+	v.SetDefault("GRAPHQL_MAX_DEPTH", 10)
+	v.SetDefault("GRAPHQL_APQ_CACHE_SIZE", 1000)
+	v.SetDefault("APP_ENV", "development")
+	v.SetDefault("STORAGE", StoragePostgres)
+	v.SetDefault("SQLITE_PATH", "iohk-golang-backend.db")
+	v.SetDefault("POSTGRES_APPLICATION_NAME", "iohk-golang-backend")
+	v.SetDefault("DB_REPLICA_CHECK_INTERVAL", 5*time.Second)
+	v.SetDefault("DB_REPLICA_PIN_WINDOW", 10*time.Second)
+	v.SetDefault("DB_PENDING_MIGRATIONS", PendingMigrationsFail)
+	v.SetDefault("SHUTDOWN_TIMEOUT", 30*time.Second)
+	v.SetDefault("READINESS_TIMEOUT", 2*time.Second)
+	v.SetDefault("ADMIN_ADDR", "localhost:9090")
+	v.SetDefault("TRACING_EXPORTER", "none")
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("LOG_FORMAT", "json")
+	v.SetDefault("TLS_RELOAD_INTERVAL", 30*time.Second)
+	v.SetDefault("CORS_ALLOWED_METHODS", "GET,POST,OPTIONS")
+	v.SetDefault("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,X-API-Key,X-Request-ID")
+	v.SetDefault("CORS_MAX_AGE", 10*time.Minute)
+}
+
+// IsProduction reports whether the application runs in production, where only
+// allowlisted GraphQL operations are served.
+func (c *Config) IsProduction() bool {
+	return c.AppEnv == EnvProduction
+}
+
+// UsesPostgres reports whether customers are stored in PostgreSQL, the only
+// storage that needs the database settings.
+func (c *Config) UsesPostgres() bool {
+	return c.Storage == StoragePostgres
+}
+
+// TLSEnabled reports whether the server listens on HTTPS.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
+
+// validTargetSessionAttrs are the target_session_attrs values pgx supports,
+// and "" to leave it unset.
+var validTargetSessionAttrs = map[string]bool{
+	"": true, "any": true, "read-write": true, "read-only": true, "primary": true, "standby": true, "prefer-standby": true,
+}
+
+func validLogLevel(level string) bool {
+	_, err := logging.ParseLevel(level)
+	return err == nil
+}
+
+func validAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	return err == nil && port != ""
+}
+
+// validateConfig reports every invalid setting at once, joined into one error.
 func validateConfig(c *Config) error {
 	validations := []struct {
 		valid  bool
 		errMsg string
 	}{
-		{c.PostgresUser != "", "POSTGRES_USER is not set"},
-		{c.PostgresPassword != "", "POSTGRES_PASSWORD is not set"},
-		{c.PostgresDB != "", "POSTGRES_DB is not set"},
-		{c.PostgresHost != "", "POSTGRES_HOST is not set"},
-		{c.PostgresPort != "", "POSTGRES_PORT is not set"},
-		{c.PostgresSSLMode != "", "POSTGRES_SSLMODE is not set"},
-		{c.DBMaxConns > 0, "DB_MAX_CONNS must be greater than 0"},
-		{c.DBMinConns > 0, "DB_MIN_CONNS must be greater than 0"},
-		{c.DBMaxConnLifetime > 0, "DB_MAX_CONN_LIFETIME must be greater than 0"},
-		{c.DBMaxConnIdleTime > 0, "DB_MAX_CONN_IDLE_TIME must be greater than 0"},
-		{c.DBHealthCheckPeriod > 0, "DB_HEALTH_CHECK_PERIOD must be greater than 0"},
+		{c.Storage == StoragePostgres || c.Storage == StorageSQLite || c.Storage == StorageMemory, "STORAGE must be postgres, sqlite or memory"},
+		{c.Storage != StorageSQLite || c.SQLitePath != "", "SQLITE_PATH is not set"},
+		// The database settings only apply to PostgreSQL storage, where
+		// DATABASE_URL replaces the individual connection fields
+		{!c.UsesPostgres() || c.DatabaseURL != "" || c.PostgresUser != "", "POSTGRES_USER is not set"},
+		{!c.UsesPostgres() || c.DatabaseURL != "" || c.PostgresPassword != "", "POSTGRES_PASSWORD is not set"},
+		{!c.UsesPostgres() || c.DatabaseURL != "" || c.PostgresDB != "", "POSTGRES_DB is not set"},
+		{!c.UsesPostgres() || c.DatabaseURL != "" || c.PostgresHost != "", "POSTGRES_HOST is not set"},
+		{!c.UsesPostgres() || c.DatabaseURL != "" || c.PostgresPort != "", "POSTGRES_PORT is not set"},
+		{!c.UsesPostgres() || c.DatabaseURL != "" || c.PostgresSSLMode != "", "POSTGRES_SSLMODE is not set"},
+		{!c.UsesPostgres() || c.PostgresStatementTimeout >= 0, "POSTGRES_STATEMENT_TIMEOUT must not be negative"},
+		{!c.UsesPostgres() || c.PostgresConnectTimeout >= 0, "POSTGRES_CONNECT_TIMEOUT must not be negative"},
+		{!c.UsesPostgres() || validTargetSessionAttrs[c.PostgresTargetSessionAttrs], "POSTGRES_TARGET_SESSION_ATTRS must be one of any, read-write, read-only, primary, standby or prefer-standby"},
+		{!c.UsesPostgres() || c.DBMaxConns > 0, "DB_MAX_CONNS must be greater than 0"},
+		{!c.UsesPostgres() || c.DBMinConns > 0, "DB_MIN_CONNS must be greater than 0"},
+		{!c.UsesPostgres() || c.DBMinConns <= c.DBMaxConns, "DB_MIN_CONNS must not be greater than DB_MAX_CONNS"},
+		{!c.UsesPostgres() || c.DBMaxConnLifetime > 0, "DB_MAX_CONN_LIFETIME must be greater than 0"},
+		{!c.UsesPostgres() || c.DBMaxConnIdleTime > 0, "DB_MAX_CONN_IDLE_TIME must be greater than 0"},
+		{!c.UsesPostgres() || c.DBHealthCheckPeriod > 0, "DB_HEALTH_CHECK_PERIOD must be greater than 0"},
+		{c.ReplicaDatabaseURL == "" || c.DBReplicaCheckInterval > 0, "DB_REPLICA_CHECK_INTERVAL must be greater than 0"},
+		{c.ReplicaDatabaseURL == "" || c.UsesPostgres(), "REPLICA_DATABASE_URL requires STORAGE=postgres"},
+		{c.ReplicaDatabaseURL == "" || c.DBReplicaPinWindow >= 0, "DB_REPLICA_PIN_WINDOW must not be negative"},
+		{c.DBPendingMigrations == PendingMigrationsFail || c.DBPendingMigrations == PendingMigrationsWarn, "DB_PENDING_MIGRATIONS must be fail or warn"},
 		{c.AppHost != "", "APP_HOST is not set"},
 		{c.AppPort != "", "APP_PORT is not set"},
+		{c.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT must be greater than 0"},
+		{c.ReadinessTimeout > 0, "READINESS_TIMEOUT must be greater than 0"},
+		{validAddr(c.AdminAddr), "ADMIN_ADDR must be host:port"},
+		{c.TracingExporter == "none" || c.TracingExporter == "stdout" || c.TracingExporter == "otlp", "TRACING_EXPORTER must be one of none, stdout or otlp"},
+		{c.TracingSampleRatio >= 0 && c.TracingSampleRatio <= 1, "TRACING_SAMPLE_RATIO must be between 0 and 1"},
+		{validLogLevel(c.LogLevel), "LOG_LEVEL must be debug, info, warn or error"},
+		{c.LogFormat == "json" || c.LogFormat == "text", "LOG_FORMAT must be json or text"},
+		{(c.TLSCertFile == "") == (c.TLSKeyFile == ""), "TLS_CERT_FILE and TLS_KEY_FILE must be set together"},
+		{c.TLSClientCAFile == "" || c.TLSEnabled(), "TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE"},
+		{!c.TLSEnabled() || c.TLSReloadInterval > 0, "TLS_RELOAD_INTERVAL must be greater than 0"},
+		{c.AuthClientCerts == "" || c.TLSClientCAFile != "", "AUTH_CLIENT_CERTS requires TLS_CLIENT_CA_FILE"},
+		{c.CORSMaxAge >= 0, "CORS_MAX_AGE must not be negative"},
+		{!c.RateLimitEnabled || c.RateLimitRPS > 0, "RATE_LIMIT_RPS must be greater than 0"},
+		{!c.RateLimitEnabled || c.RateLimitBurst > 0, "RATE_LIMIT_BURST must be greater than 0"},
+		{!c.RateLimitEnabled || c.RateLimitTokenComplexity > 0, "RATE_LIMIT_TOKEN_COMPLEXITY must be greater than 0"},
+		{!c.RateLimitEnabled || c.RateLimitIPRPS > 0, "RATE_LIMIT_IP_RPS must be greater than 0"},
+		{!c.RateLimitEnabled || c.RateLimitIPBurst > 0, "RATE_LIMIT_IP_BURST must be greater than 0"},
+		// Otherwise operations within the complexity limit could never run
+		{!c.RateLimitEnabled || c.RateLimitTokenComplexity <= 0 || c.RateLimitBurst <= 0 || (c.GraphQLMaxComplexity+c.RateLimitTokenComplexity-1)/c.RateLimitTokenComplexity <= c.RateLimitBurst, "RATE_LIMIT_BURST must be at least GRAPHQL_MAX_COMPLEXITY divided by RATE_LIMIT_TOKEN_COMPLEXITY"},
+		{c.GraphQLMaxComplexity > 0, "GRAPHQL_MAX_COMPLEXITY must be greater than 0"},
+		{c.GraphQLMaxDepth > 0, "GRAPHQL_MAX_DEPTH must be greater than 0"},
+		{c.GraphQLAPQCacheSize > 0, "GRAPHQL_APQ_CACHE_SIZE must be greater than 0"},
+		{!c.IsProduction() || c.GraphQLAllowlist != "", "GRAPHQL_ALLOWLIST must be set in production"},
+		{!c.IsProduction() || strings.TrimSpace(c.PIIEncryptionKeys) != "", "PII_ENCRYPTION_KEYS must be set in production"},
+		{!c.IsProduction() || c.ErasureLogKey != "", "ERASURE_LOG_KEY must be set in production"},
 	}
 
+	var errs []error
 	for _, v := range validations {
 		if !v.valid {
-			return fmt.Errorf(v.errMsg)
+			errs = append(errs, errors.New(v.errMsg))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
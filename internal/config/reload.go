@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadable lists the fields that can change while the server runs. All
+// other settings need a restart.
+var reloadable = map[string]bool{
+	"LogLevel":                 true,
+	"RateLimitEnabled":         true,
+	"RateLimitRPS":             true,
+	"RateLimitBurst":           true,
+	"RateLimitTokenComplexity": true,
+	"RateLimitIPRPS":           true,
+	"RateLimitIPBurst":         true,
+	"RateLimitTrustProxy":      true,
+	"GraphQLMaxComplexity":     true,
+	"GraphQLMaxDepth":          true,
+	"CORSAllowedOrigins":       true,
+	"CORSAllowedMethods":       true,
+	"CORSAllowedHeaders":       true,
+	"CORSAllowCredentials":     true,
+	"CORSMaxAge":               true,
+}
+
+// debounce coalesces the several events editors and Kubernetes ConfigMap
+// updates produce for one change.
+const debounce = 100 * time.Millisecond
+
+// Changes returns the names of the fields that differ between current and
+// next.
+func Changes(current, next *Config) []string {
+	var changed []string
+	cv, nv := reflect.ValueOf(*current), reflect.ValueOf(*next)
+	for i := 0; i < cv.NumField(); i++ {
+		if !reflect.DeepEqual(cv.Field(i).Interface(), nv.Field(i).Interface()) {
+			changed = append(changed, cv.Type().Field(i).Name)
+		}
+	}
+	return changed
+}
+
+// CheckReload returns the fields that changed between current and next. It
+// returns an error naming the changed fields that cannot be applied without a
+// restart.
+func CheckReload(current, next *Config) ([]string, error) {
+	changed := Changes(current, next)
+	var unsafe []string
+	for _, field := range changed {
+		if !reloadable[field] {
+			unsafe = append(unsafe, field)
+		}
+	}
+	if len(unsafe) > 0 {
+		return nil, fmt.Errorf("changes to %s require a restart", strings.Join(unsafe, ", "))
+	}
+	return changed, nil
+}
+
+// Watch calls onChange after the file at path is written, created or replaced,
+// until ctx is cancelled. It watches the directory, so files replaced by
+// renaming them over the old one, or by swapping a symlink as Kubernetes does
+// for mounted ConfigMaps, are picked up too.
+func Watch(ctx context.Context, path string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		name := filepath.Clean(path)
+		var timer <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Kubernetes swaps the ..data symlink instead of touching the file
+				if filepath.Clean(event.Name) == name || strings.HasPrefix(filepath.Base(event.Name), "..") {
+					if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+						timer = time.After(debounce)
+					}
+				}
+			case <-timer:
+				timer = nil
+				onChange()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("Config file watcher failed", "file", path, "error", err)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,177 @@
+//go:build testcoverage
+// +build testcoverage
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReload(t *testing.T) {
+	testCases := []struct {
+		name            string
+		modify          func(c *Config)
+		expectedChanged []string
+		expectedError   string
+	}{
+		{
+			name:            "No changes",
+			modify:          func(c *Config) {},
+			expectedChanged: nil,
+		},
+		{
+			name: "Safe changes",
+			modify: func(c *Config) {
+				c.LogLevel = "debug"
+				c.RateLimitEnabled = true
+				c.GraphQLMaxDepth = 8
+				c.CORSAllowedOrigins = "https://app.example.com"
+			},
+			expectedChanged: []string{"LogLevel", "CORSAllowedOrigins", "RateLimitEnabled", "GraphQLMaxDepth"},
+		},
+		{
+			name: "Unsafe changes",
+			modify: func(c *Config) {
+				c.LogLevel = "debug"
+				c.PostgresHost = "replica"
+				c.AppPort = "9090"
+			},
+			expectedError: "changes to PostgresHost, AppPort require a restart",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			current, next := validConfig(), validConfig()
+			tc.modify(next)
+
+			// Act
+			changed, err := CheckReload(current, next)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, changed)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChanged, changed)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	testCases := []struct {
+		name   string
+		change func(t *testing.T, path string)
+	}{
+		{
+			name: "File written",
+			change: func(t *testing.T, path string) {
+				require.NoError(t, os.WriteFile(path, []byte("log_level: debug\n"), 0o600))
+			},
+		},
+		{
+			name: "File replaced by rename",
+			change: func(t *testing.T, path string) {
+				tmp := path + ".tmp"
+				require.NoError(t, os.WriteFile(tmp, []byte("log_level: debug\n"), 0o600))
+				require.NoError(t, os.Rename(tmp, path))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(t.TempDir(), "development.yaml")
+			require.NoError(t, os.WriteFile(path, []byte("log_level: info\n"), 0o600))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			changes := make(chan struct{}, 10)
+			require.NoError(t, Watch(ctx, path, func() { changes <- struct{}{} }))
+
+			// Act
+			tc.change(t, path)
+
+			// Assert
+			select {
+			case <-changes:
+			case <-time.After(2 * time.Second):
+				t.Fatal("change was not noticed")
+			}
+		})
+	}
+}
+
+func TestWatchIgnoresOtherFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "development.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log_level: info\n"), 0o600))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan struct{}, 10)
+	require.NoError(t, Watch(ctx, path, func() { changes <- struct{}{} }))
+
+	// Act
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "production.yaml"), []byte("log_level: warn\n"), 0o600))
+
+	// Assert
+	select {
+	case <-changes:
+		t.Fatal("change to another file was reported")
+	case <-time.After(3 * debounce):
+	}
+}
+
+func TestLoadConfigRecordsConfigFile(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFile(t, filepath.Join(dir, "config", "development.yaml"), "log_level: debug\n")
+	setRequiredEnv(t)
+
+	// Act
+	config, err := LoadConfig(nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("config", "development.yaml"), config.ConfigFile)
+}
+
+func TestReloadAppliesConfigFileOverEnvFile(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFile(t, filepath.Join(dir, ".env.local"), "LOG_LEVEL=info\nRATE_LIMIT_ENABLED=true\nRATE_LIMIT_RPS=10\nRATE_LIMIT_BURST=40\nRATE_LIMIT_TOKEN_COMPLEXITY=25\nCORS_ALLOWED_ORIGINS=http://localhost:3000\n")
+	path := filepath.Join(dir, "config", "development.yaml")
+	writeFile(t, path, "log_level: warn\n")
+	setRequiredEnv(t)
+	args := []string{"--env-file=.env.local"}
+	current, err := LoadConfig(args)
+	require.NoError(t, err)
+	writeFile(t, path, "log_level: debug\nrate_limit_rps: 2\ncors_allowed_origins: https://app.example.com\n")
+
+	// Act
+	next, err := LoadConfig(args)
+	require.NoError(t, err)
+	changed, err := CheckReload(current, next)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "warn", current.LogLevel)
+	assert.Equal(t, []string{"LogLevel", "CORSAllowedOrigins", "RateLimitRPS"}, changed)
+	assert.Equal(t, "debug", next.LogLevel)
+	assert.Equal(t, 2.0, next.RateLimitRPS)
+	assert.Equal(t, "https://app.example.com", next.CORSAllowedOrigins)
+	assert.Equal(t, 40, next.RateLimitBurst, "settings missing from the file keep the env file value")
+}
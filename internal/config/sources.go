@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// keys lists every setting. Each one can be set in the config file, as an
+// environment variable, through a <KEY>_FILE secret file and as a flag.
+var keys = []string{
+	"APP_ENV",
+	"STORAGE",
+	"SQLITE_PATH",
+	"DATABASE_URL",
+	"POSTGRES_USER",
+	"POSTGRES_PASSWORD",
+	"POSTGRES_DB",
+	"POSTGRES_HOST",
+	"POSTGRES_PORT",
+	"POSTGRES_SSLMODE",
+	"POSTGRES_APPLICATION_NAME",
+	"POSTGRES_SEARCH_PATH",
+	"POSTGRES_STATEMENT_TIMEOUT",
+	"POSTGRES_CONNECT_TIMEOUT",
+	"POSTGRES_TARGET_SESSION_ATTRS",
+	"DB_MAX_CONNS",
+	"DB_MIN_CONNS",
+	"DB_MAX_CONN_LIFETIME",
+	"DB_MAX_CONN_IDLE_TIME",
+	"DB_HEALTH_CHECK_PERIOD",
+	"REPLICA_DATABASE_URL",
+	"DB_REPLICA_CHECK_INTERVAL",
+	"DB_REPLICA_PIN_WINDOW",
+	"DB_REPLICA_PIN_KEY",
+	"DB_PENDING_MIGRATIONS",
+	"DEV_DATABASE_URL",
+	"APP_HOST",
+	"APP_PORT",
+	"SHUTDOWN_TIMEOUT",
+	"READINESS_TIMEOUT",
+	"ADMIN_ADDR",
+	"TRACING_EXPORTER",
+	"TRACING_SAMPLE_RATIO",
+	"LOG_LEVEL",
+	"LOG_FORMAT",
+	"CORS_ALLOWED_ORIGINS",
+	"CORS_ALLOWED_METHODS",
+	"CORS_ALLOWED_HEADERS",
+	"CORS_ALLOW_CREDENTIALS",
+	"CORS_MAX_AGE",
+	"TLS_CERT_FILE",
+	"TLS_KEY_FILE",
+	"TLS_CLIENT_CA_FILE",
+	"TLS_RELOAD_INTERVAL",
+	"AUTH_API_KEYS",
+	"AUTH_CLIENT_CERTS",
+	"PII_ENCRYPTION_KEYS",
+	"PII_ACTIVE_KEY_VERSION",
+	"PII_BLIND_INDEX_KEY",
+	"ERASURE_LOG_KEY",
+	"RATE_LIMIT_ENABLED",
+	"RATE_LIMIT_RPS",
+	"RATE_LIMIT_BURST",
+	"RATE_LIMIT_TOKEN_COMPLEXITY",
+	"RATE_LIMIT_IP_RPS",
+	"RATE_LIMIT_IP_BURST",
+	"RATE_LIMIT_TRUST_PROXY",
+	"GRAPHQL_MAX_COMPLEXITY",
+	"GRAPHQL_MAX_DEPTH",
+	"GRAPHQL_APQ_CACHE_SIZE",
+	"GRAPHQL_ALLOWLIST",
+}
+
+// configDir holds the config files selected by APP_ENV.
+const configDir = "config"
+
+const (
+	configFlag  = "config"
+	envFileFlag = "env-file"
+)
+
+// flagName turns a key such as DB_MAX_CONNS into its flag name, db-max-conns.
+func flagName(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+}
+
+// parseFlags defines a flag for every key and binds it to v, which only uses
+// flags that were set on the command line.
+func parseFlags(v *viper.Viper, args []string) (*pflag.FlagSet, error) {
+	flags := pflag.NewFlagSet(filepath.Base(os.Args[0]), pflag.ContinueOnError)
+	flags.String(configFlag, "", "path of a YAML or TOML config file, instead of config/<APP_ENV>.yaml")
+	flags.String(envFileFlag, "", "path of a dotenv file such as .env.local, overridden by the config file")
+	for _, key := range keys {
+		flags.String(flagName(key), "", "overrides "+key)
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if err := v.BindPFlag(key, flags.Lookup(flagName(key))); err != nil {
+			return nil, err
+		}
+	}
+	return flags, nil
+}
+
+// readEnvFile reads the dotenv file passed with --env-file as defaults, so
+// the config file, environment variables and flags override its values. It
+// is not loaded into the process environment, and empty values leave the
+// built-in defaults in place.
+func readEnvFile(v *viper.Viper, flags *pflag.FlagSet) error {
+	path, _ := flags.GetString(envFileFlag)
+	if path == "" {
+		return nil
+	}
+	values, err := godotenv.Read(path)
+	if err != nil {
+		return fmt.Errorf("failed to read env file %s: %w", path, err)
+	}
+	for key, value := range values {
+		if value != "" && slices.Contains(keys, key) {
+			v.SetDefault(key, value)
+		}
+	}
+	return nil
+}
+
+// readSecretFiles reads <KEY>_FILE variables, e.g. POSTGRES_PASSWORD_FILE
+// pointing at a Docker or Kubernetes secret, with the precedence of
+// environment variables. A trailing newline is dropped.
+func readSecretFiles(v *viper.Viper, flags *pflag.FlagSet) error {
+	for _, key := range keys {
+		path, ok := os.LookupEnv(key + "_FILE")
+		if !ok || path == "" {
+			continue
+		}
+		if _, set := os.LookupEnv(key); set {
+			return fmt.Errorf("%s and %s_FILE are both set", key, key)
+		}
+		if flags.Changed(flagName(key)) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s_FILE: %w", key, err)
+		}
+		v.Set(key, strings.TrimRight(string(data), "\r\n"))
+	}
+	return nil
+}
+
+// readConfigFile reads the file passed with --config, which must exist, or
+// else the first of config/<APP_ENV>.yaml, .yml and .toml that exists.
+func readConfigFile(v *viper.Viper, flags *pflag.FlagSet) error {
+	path, _ := flags.GetString(configFlag)
+	if path == "" {
+		path = findConfigFile(v.GetString("APP_ENV"))
+		if path == "" {
+			return nil
+		}
+	}
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func findConfigFile(env string) string {
+	if env == "" || strings.ContainsAny(env, `/\`) {
+		return ""
+	}
+	for _, ext := range []string{".yaml", ".yml", ".toml"} {
+		path := filepath.Join(configDir, env+ext)
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,328 @@
+//go:build testcoverage
+// +build testcoverage
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setRequiredEnv clears the environment and sets every required setting
+// except those in skip.
+func setRequiredEnv(t *testing.T, skip ...string) {
+	t.Helper()
+	os.Clearenv()
+	required := map[string]string{
+		"POSTGRES_USER":          "testuser",
+		"POSTGRES_PASSWORD":      "testpass",
+		"POSTGRES_DB":            "testdb",
+		"POSTGRES_HOST":          "localhost",
+		"POSTGRES_PORT":          "5432",
+		"POSTGRES_SSLMODE":       "disable",
+		"DB_MAX_CONNS":           "25",
+		"DB_MIN_CONNS":           "5",
+		"DB_MAX_CONN_LIFETIME":   "5h",
+		"DB_MAX_CONN_IDLE_TIME":  "15m",
+		"DB_HEALTH_CHECK_PERIOD": "1m",
+		"APP_HOST":               "localhost",
+		"APP_PORT":               "8080",
+	}
+	for _, key := range skip {
+		delete(required, key)
+	}
+	for key, value := range required {
+		t.Setenv(key, value)
+	}
+}
+
+// chdir changes into dir for the rest of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFile(t, filepath.Join(dir, "config", "development.yaml"), "app_port: \"9000\"\nlog_level: debug\ndb_max_conns: 40\ngraphql_max_depth: 12\n")
+
+	testCases := []struct {
+		name         string
+		env          map[string]string
+		args         []string
+		expectedPort string
+		expectedMax  int
+	}{
+		{name: "Config file overrides defaults", env: map[string]string{"APP_PORT": ""}, expectedPort: "9000", expectedMax: 25},
+		{name: "Environment overrides config file", env: map[string]string{"APP_PORT": "8081"}, expectedPort: "8081", expectedMax: 25},
+		{name: "Flags override environment", env: map[string]string{"APP_PORT": "8081"}, args: []string{"--app-port=8082", "--db-max-conns", "50"}, expectedPort: "8082", expectedMax: 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			setRequiredEnv(t)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			config, err := LoadConfig(tc.args)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPort, config.AppPort)
+			assert.Equal(t, tc.expectedMax, config.DBMaxConns)
+			assert.Equal(t, "debug", config.LogLevel)
+			assert.Equal(t, 12, config.GraphQLMaxDepth)
+			assert.Equal(t, 1000, config.GraphQLMaxComplexity)
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string]string
+		appEnv        string
+		args          []string
+		expectedDepth int
+		expectedError string
+	}{
+		{
+			name:          "TOML file selected by APP_ENV",
+			files:         map[string]string{"config/staging.toml": "graphql_max_depth = 7\n"},
+			appEnv:        "staging",
+			expectedDepth: 7,
+		},
+		{
+			name:          "No file for APP_ENV",
+			appEnv:        "staging",
+			expectedDepth: 10,
+		},
+		{
+			name:          "File passed with --config",
+			files:         map[string]string{"custom.yml": "graphql_max_depth: 5\n", "config/development.yaml": "graphql_max_depth: 6\n"},
+			args:          []string{"--config", "custom.yml"},
+			expectedDepth: 5,
+		},
+		{
+			name:          "Missing file passed with --config",
+			args:          []string{"--config", "missing.yaml"},
+			expectedError: "failed to read config file missing.yaml",
+		},
+		{
+			name:          "Invalid file",
+			files:         map[string]string{"config/development.yaml": "graphql_max_depth: [\n"},
+			expectedError: "failed to read config file config/development.yaml",
+		},
+		{
+			name:          "Unknown flag",
+			args:          []string{"--graphql-max-width=3"},
+			expectedError: "unknown flag: --graphql-max-width",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			chdir(t, dir)
+			for name, content := range tc.files {
+				writeFile(t, filepath.Join(dir, name), content)
+			}
+			setRequiredEnv(t)
+			if tc.appEnv != "" {
+				t.Setenv("APP_ENV", tc.appEnv)
+			}
+
+			// Act
+			config, err := LoadConfig(tc.args)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.Nil(t, config)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedDepth, config.GraphQLMaxDepth)
+			}
+		})
+	}
+}
+
+func TestLoadConfigSecretFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "postgres_password")
+	writeFile(t, secret, "s3cret\n")
+
+	testCases := []struct {
+		name             string
+		env              map[string]string
+		args             []string
+		expectedPassword string
+		expectedError    string
+	}{
+		{
+			name:             "Secret file read without trailing newline",
+			env:              map[string]string{"POSTGRES_PASSWORD_FILE": secret},
+			expectedPassword: "s3cret",
+		},
+		{
+			name:             "Flag overrides secret file",
+			env:              map[string]string{"POSTGRES_PASSWORD_FILE": secret},
+			args:             []string{"--postgres-password=fromflag"},
+			expectedPassword: "fromflag",
+		},
+		{
+			name:          "Variable and secret file both set",
+			env:           map[string]string{"POSTGRES_PASSWORD": "plain", "POSTGRES_PASSWORD_FILE": secret},
+			expectedError: "POSTGRES_PASSWORD and POSTGRES_PASSWORD_FILE are both set",
+		},
+		{
+			name:          "Missing secret file",
+			env:           map[string]string{"POSTGRES_PASSWORD_FILE": filepath.Join(dir, "missing")},
+			expectedError: "failed to read POSTGRES_PASSWORD_FILE",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			chdir(t, t.TempDir())
+			setRequiredEnv(t, "POSTGRES_PASSWORD")
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			config, err := LoadConfig(tc.args)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.Nil(t, config)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedPassword, config.PostgresPassword)
+			}
+		})
+	}
+}
+
+func TestLoadConfigEnvFile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configFile    string
+		env           map[string]string
+		args          []string
+		expectedLevel string
+		expectedPort  string
+		expectedError string
+	}{
+		{
+			name:          "Env file overrides defaults",
+			expectedLevel: "warn",
+			expectedPort:  "9000",
+		},
+		{
+			name:          "Config file overrides env file",
+			configFile:    "log_level: debug\n",
+			expectedLevel: "debug",
+			expectedPort:  "9000",
+		},
+		{
+			name:          "Environment overrides env file",
+			env:           map[string]string{"APP_PORT": "8081"},
+			expectedLevel: "warn",
+			expectedPort:  "8081",
+		},
+		{
+			name:          "Flags override env file",
+			args:          []string{"--log-level=error"},
+			expectedLevel: "error",
+			expectedPort:  "9000",
+		},
+		{
+			name:          "Missing env file",
+			args:          []string{"--env-file=missing.env"},
+			expectedError: "failed to read env file missing.env",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			chdir(t, dir)
+			writeFile(t, filepath.Join(dir, ".env.local"), "LOG_LEVEL=warn\nAPP_PORT=9000\n")
+			if tc.configFile != "" {
+				writeFile(t, filepath.Join(dir, "config", "development.yaml"), tc.configFile)
+			}
+			setRequiredEnv(t, "APP_PORT")
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			// Act
+			config, err := LoadConfig(append([]string{"--env-file=.env.local"}, tc.args...))
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.Nil(t, config)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLevel, config.LogLevel)
+			assert.Equal(t, tc.expectedPort, config.AppPort)
+			_, set := os.LookupEnv("LOG_LEVEL")
+			assert.False(t, set, "the env file is not loaded into the environment")
+		})
+	}
+}
+
+func TestLoadConfigIgnoresEnvFileUnlessPassed(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFile(t, filepath.Join(dir, ".env.local"), "LOG_LEVEL=warn\n")
+	setRequiredEnv(t)
+
+	// Act
+	config, err := LoadConfig(nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "info", config.LogLevel)
+}
+
+func TestLoadConfigReportsEveryError(t *testing.T) {
+	// Arrange
+	chdir(t, t.TempDir())
+	setRequiredEnv(t, "POSTGRES_USER", "APP_HOST")
+	t.Setenv("DB_MIN_CONNS", "30")
+	t.Setenv("LOG_LEVEL", "verbose")
+
+	// Act
+	_, err := LoadConfig(nil)
+
+	// Assert
+	assert.EqualError(t, err, "POSTGRES_USER is not set\nDB_MIN_CONNS must not be greater than DB_MAX_CONNS\nAPP_HOST is not set\nLOG_LEVEL must be debug, info, warn or error")
+}
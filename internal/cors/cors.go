@@ -0,0 +1,177 @@
+// Package cors lets browser frontends on other origins call the API, and
+// applies the same origin allowlist to websocket upgrades.
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"iohk-golang-backend/internal/requestid"
+)
+
+// Options configures a Policy. Origins are either "*", which allows every
+// origin, or "scheme://host[:port]" with at most one "*" wildcard, e.g.
+// "https://*.example.com".
+type Options struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Policy decides which origins may call the API.
+type Policy struct {
+	origins     []pattern
+	allowAll    bool
+	methods     string
+	headers     string
+	credentials bool
+	maxAge      string
+}
+
+// pattern matches origins starting with prefix and ending with suffix. The
+// wildcard between them matches at least one character.
+type pattern struct {
+	prefix, suffix string
+	wildcard       bool
+}
+
+// NewPolicy validates the options and builds a Policy. A policy without
+// allowed origins only accepts same-origin requests.
+func NewPolicy(opts Options) (*Policy, error) {
+	p := &Policy{
+		methods:     strings.Join(opts.AllowedMethods, ", "),
+		headers:     strings.Join(opts.AllowedHeaders, ", "),
+		credentials: opts.AllowCredentials,
+		maxAge:      strconv.Itoa(int(opts.MaxAge.Seconds())),
+	}
+	for _, origin := range opts.AllowedOrigins {
+		if origin == "*" {
+			p.allowAll = true
+			continue
+		}
+		pat, err := parsePattern(origin)
+		if err != nil {
+			return nil, err
+		}
+		p.origins = append(p.origins, pat)
+	}
+	if p.allowAll && p.credentials {
+		return nil, fmt.Errorf("credentials cannot be allowed for every origin")
+	}
+	return p, nil
+}
+
+// ParseList splits a comma-separated setting, dropping empty entries.
+func ParseList(raw string) []string {
+	var list []string
+	for _, entry := range strings.Split(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+func parsePattern(origin string) (pattern, error) {
+	if strings.Count(origin, "*") > 1 {
+		return pattern{}, fmt.Errorf("invalid origin %q: at most one wildcard is allowed", origin)
+	}
+	u, err := url.Parse(strings.Replace(origin, "*", "wildcard", 1))
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return pattern{}, fmt.Errorf("invalid origin %q: expected scheme://host[:port]", origin)
+	}
+	origin = strings.TrimSuffix(strings.ToLower(origin), "/")
+	prefix, suffix, wildcard := strings.Cut(origin, "*")
+	return pattern{prefix: prefix, suffix: suffix, wildcard: wildcard}, nil
+}
+
+func (p pattern) match(origin string) bool {
+	if !p.wildcard {
+		return origin == p.prefix
+	}
+	return len(origin) > len(p.prefix)+len(p.suffix) &&
+		strings.HasPrefix(origin, p.prefix) &&
+		strings.HasSuffix(origin, p.suffix)
+}
+
+// Allowed reports whether origin may call the API.
+func (p *Policy) Allowed(origin string) bool {
+	if p.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, pat := range p.origins {
+		if pat.match(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware answers preflight requests and adds the CORS headers to the
+// responses of allowed origins. Requests from other origins are served
+// without them, so browsers do not let the calling page read the response.
+func (p *Policy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if !p.Allowed(origin) {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if p.allowAll {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.credentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			h.Set("Access-Control-Allow-Methods", p.methods)
+			h.Set("Access-Control-Allow-Headers", p.headers)
+			h.Set("Access-Control-Max-Age", p.maxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// Let the frontend show the request ID next to errors
+		h.Set("Access-Control-Expose-Headers", requestid.Header)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CheckOrigin is a websocket.Upgrader CheckOrigin applying the policy to
+// subscriptions. Like the gorilla default it accepts requests without an
+// Origin header and same-origin requests.
+func (p *Policy) CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return p.Allowed(origin)
+}
@@ -0,0 +1,194 @@
+//go:build testcoverage
+// +build testcoverage
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPolicy(t *testing.T, origins ...string) *Policy {
+	t.Helper()
+	p, err := NewPolicy(Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "X-API-Key"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewPolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		opts          Options
+		expectedError string
+	}{
+		{name: "Exact and wildcard origins", opts: Options{AllowedOrigins: []string{"http://localhost:3000", "https://*.example.com"}}},
+		{name: "Every origin", opts: Options{AllowedOrigins: []string{"*"}}},
+		{name: "No origins", opts: Options{}},
+		{
+			name:          "Every origin with credentials",
+			opts:          Options{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			expectedError: "credentials cannot be allowed for every origin",
+		},
+		{
+			name:          "Origin without scheme",
+			opts:          Options{AllowedOrigins: []string{"example.com"}},
+			expectedError: `invalid origin "example.com": expected scheme://host[:port]`,
+		},
+		{
+			name:          "Origin with path",
+			opts:          Options{AllowedOrigins: []string{"https://example.com/app"}},
+			expectedError: `invalid origin "https://example.com/app": expected scheme://host[:port]`,
+		},
+		{
+			name:          "Two wildcards",
+			opts:          Options{AllowedOrigins: []string{"https://*.*.example.com"}},
+			expectedError: `invalid origin "https://*.*.example.com": at most one wildcard is allowed`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := NewPolicy(tc.opts)
+
+			// Assert
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	// Arrange
+	p := newTestPolicy(t, "http://localhost:3000", "https://*.example.com")
+
+	testCases := []struct {
+		origin   string
+		expected bool
+	}{
+		{origin: "http://localhost:3000", expected: true},
+		{origin: "HTTP://LOCALHOST:3000", expected: true},
+		{origin: "http://localhost:3001", expected: false},
+		{origin: "https://staging.example.com", expected: true},
+		{origin: "https://a.b.example.com", expected: true},
+		{origin: "https://.example.com", expected: false},
+		{origin: "https://example.com", expected: false},
+		{origin: "http://staging.example.com", expected: false},
+		{origin: "https://staging.example.com.evil.com", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.origin, func(t *testing.T) {
+			// Act
+			allowed := p.Allowed(tc.origin)
+
+			// Assert
+			assert.Equal(t, tc.expected, allowed)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		origin         string
+		preflight      bool
+		expectedStatus int
+		expectedOrigin string
+		expectedNext   bool
+	}{
+		{name: "Same-origin request", method: http.MethodPost, expectedStatus: http.StatusOK, expectedNext: true},
+		{name: "Allowed request", method: http.MethodPost, origin: "https://app.example.com", expectedStatus: http.StatusOK, expectedOrigin: "https://app.example.com", expectedNext: true},
+		{name: "Disallowed request", method: http.MethodPost, origin: "https://evil.com", expectedStatus: http.StatusOK, expectedNext: true},
+		{name: "Allowed preflight", method: http.MethodOptions, origin: "https://app.example.com", preflight: true, expectedStatus: http.StatusNoContent, expectedOrigin: "https://app.example.com"},
+		{name: "Disallowed preflight", method: http.MethodOptions, origin: "https://evil.com", preflight: true, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			nextCalled := false
+			handler := newTestPolicy(t, "https://*.example.com").Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				nextCalled = true
+			}))
+			req := httptest.NewRequest(tc.method, "/query", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedNext, nextCalled)
+			assert.Equal(t, tc.expectedOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			if tc.expectedOrigin != "" {
+				assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+			}
+			if tc.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, "GET, POST, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, "Content-Type, X-API-Key", rec.Header().Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+			} else if tc.expectedOrigin != "" {
+				assert.Equal(t, "X-Request-ID", rec.Header().Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		origin   string
+		expected bool
+	}{
+		{name: "No origin", origin: "", expected: true},
+		{name: "Same origin", origin: "http://api.internal:8080", expected: true},
+		{name: "Allowed origin", origin: "https://app.example.com", expected: true},
+		{name: "Disallowed origin", origin: "https://evil.com", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, "http://api.internal:8080/query", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+
+			// Act
+			allowed := newTestPolicy(t, "https://*.example.com").CheckOrigin(req)
+
+			// Assert
+			assert.Equal(t, tc.expected, allowed)
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	// Act
+	list := ParseList(" http://localhost:3000 , ,https://*.example.com,")
+
+	// Assert
+	assert.Equal(t, []string{"http://localhost:3000", "https://*.example.com"}, list)
+}
@@ -0,0 +1,244 @@
+// Package customergen generates realistic synthetic customers. The same
+// options, seed included, always generate the same customers, so generated
+// data can serve as reproducible fixtures and benchmark datasets.
+package customergen
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	domainmodel "iohk-golang-backend/internal/domain/model"
+)
+
+// Default distributions, as comma-separated value:weight pairs. Weights are
+// relative and need not add up to 100.
+const (
+	DefaultCountries  = "USA:25,UK:10,Germany:10,Spain:8,Canada:8,Australia:8,China:8,India:8,Italy:5,Russia:4,Japan:3,Brazil:3"
+	DefaultGenders    = "MALE:49,FEMALE:51"
+	DefaultAges       = "18-29:20,30-44:27,45-64:33,65-90:20"
+	DefaultDependants = "0:35,1:20,2:25,3:12,4:5,5:3"
+)
+
+// DefaultAsOf is the date ages are counted from unless another is given. It
+// is fixed rather than today, which would change the data every day.
+var DefaultAsOf = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Options configures a Generator. Empty distributions and a zero AsOf use the
+// defaults.
+type Options struct {
+	Seed uint64
+	// Countries weights country names, e.g. "USA:3,Spain:1".
+	Countries string
+	// Genders weights MALE and FEMALE.
+	Genders string
+	// Ages weights inclusive age ranges in years, e.g. "18-29:1,30-90:2".
+	Ages string
+	// Dependants weights numbers of dependants, e.g. "0:2,1:1".
+	Dependants string
+	// AsOf is the date the ages are reached by.
+	AsOf time.Time
+}
+
+type ageRange struct{ min, max int }
+
+// Generator generates customers. It is not safe for concurrent use.
+type Generator struct {
+	rng        *rand.Rand
+	asOf       time.Time
+	countries  *weights[string]
+	genders    *weights[domainmodel.Gender]
+	ages       *weights[ageRange]
+	dependants *weights[int]
+}
+
+// New returns a Generator for opts, or an error naming the first invalid
+// distribution.
+func New(opts Options) (*Generator, error) {
+	g := &Generator{
+		// PCG is a specified algorithm, so its output is stable across Go
+		// releases, unlike the range helpers of math/rand/v2.
+		rng:  rand.New(rand.NewPCG(opts.Seed, 0x637573746f6d6572)),
+		asOf: opts.AsOf,
+	}
+	if g.asOf.IsZero() {
+		g.asOf = DefaultAsOf
+	}
+	g.asOf = time.Date(g.asOf.Year(), g.asOf.Month(), g.asOf.Day(), 0, 0, 0, 0, time.UTC)
+
+	var errs []error
+	var err error
+	if g.countries, err = parseWeights(or(opts.Countries, DefaultCountries), parseCountry); err != nil {
+		errs = append(errs, fmt.Errorf("invalid countries: %w", err))
+	}
+	if g.genders, err = parseWeights(or(opts.Genders, DefaultGenders), parseGender); err != nil {
+		errs = append(errs, fmt.Errorf("invalid genders: %w", err))
+	}
+	if g.ages, err = parseWeights(or(opts.Ages, DefaultAges), parseAgeRange); err != nil {
+		errs = append(errs, fmt.Errorf("invalid ages: %w", err))
+	}
+	if g.dependants, err = parseWeights(or(opts.Dependants, DefaultDependants), parseDependants); err != nil {
+		errs = append(errs, fmt.Errorf("invalid dependants: %w", err))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Next returns the next customer. Its ID is left zero.
+func (g *Generator) Next() *domainmodel.Customer {
+	gender := g.genders.pick(g.rng)
+	first := maleNames
+	if gender == domainmodel.GenderFemale {
+		first = femaleNames
+	}
+	age := g.ages.pick(g.rng)
+	years := age.min + g.intn(age.max-age.min+1)
+	// Any day of the year after the birthday that made them this old
+	birthDate := g.asOf.AddDate(-years, 0, -g.intn(365))
+
+	return &domainmodel.Customer{
+		Name:       first[g.intn(len(first))],
+		Surname:    surnames[g.intn(len(surnames))],
+		Number:     100000 + g.intn(900000),
+		Gender:     gender,
+		Country:    g.countries.pick(g.rng),
+		Dependants: g.dependants.pick(g.rng),
+		BirthDate:  birthDate,
+	}
+}
+
+// All yields the next n customers.
+func (g *Generator) All(n int) iter.Seq[*domainmodel.Customer] {
+	return func(yield func(*domainmodel.Customer) bool) {
+		for range n {
+			if !yield(g.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// intn returns a number in [0, n). The modulo bias is negligible for the
+// small n used here.
+func (g *Generator) intn(n int) int {
+	return int(g.rng.Uint64() % uint64(n))
+}
+
+// weights picks values with probabilities proportional to their weights.
+type weights[T any] struct {
+	values     []T
+	cumulative []uint64
+}
+
+func (w *weights[T]) pick(rng *rand.Rand) T {
+	total := w.cumulative[len(w.cumulative)-1]
+	n := rng.Uint64() % total
+	for i, c := range w.cumulative {
+		if n < c {
+			return w.values[i]
+		}
+	}
+	return w.values[len(w.values)-1]
+}
+
+// parseWeights parses value:weight pairs, keeping their order so the same
+// spec always maps random numbers to the same values.
+func parseWeights[T any](spec string, parse func(string) (T, error)) (*weights[T], error) {
+	w := &weights[T]{}
+	var total uint64
+	for _, pair := range strings.Split(spec, ",") {
+		value, weight, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("%q is not value:weight", pair)
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(weight), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("weight of %q must be a non-negative integer", value)
+		}
+		v, err := parse(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			continue
+		}
+		total += n
+		w.values = append(w.values, v)
+		w.cumulative = append(w.cumulative, total)
+	}
+	if total == 0 {
+		return nil, errors.New("weights must not all be zero")
+	}
+	return w, nil
+}
+
+func parseCountry(s string) (string, error) {
+	// Matches the length limit of the country field
+	if s == "" || len(s) > 50 {
+		return "", fmt.Errorf("country %q must have 1 to 50 characters", s)
+	}
+	return s, nil
+}
+
+func parseGender(s string) (domainmodel.Gender, error) {
+	switch g := domainmodel.Gender(strings.ToUpper(s)); g {
+	case domainmodel.GenderMale, domainmodel.GenderFemale:
+		return g, nil
+	default:
+		return "", fmt.Errorf("gender %q must be MALE or FEMALE", s)
+	}
+}
+
+func parseAgeRange(s string) (ageRange, error) {
+	lo, hi, ok := strings.Cut(s, "-")
+	if !ok {
+		hi = lo
+	}
+	minAge, err1 := strconv.Atoi(lo)
+	maxAge, err2 := strconv.Atoi(hi)
+	if err1 != nil || err2 != nil || minAge < 0 || maxAge < minAge || maxAge > 130 {
+		return ageRange{}, fmt.Errorf("age range %q must be MIN-MAX or AGE, between 0 and 130", s)
+	}
+	return ageRange{min: minAge, max: maxAge}, nil
+}
+
+func parseDependants(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("dependants %q must be a non-negative integer", s)
+	}
+	return n, nil
+}
+
+func or(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+var maleNames = []string{
+	"James", "John", "Robert", "Michael", "William", "David", "Richard", "Joseph", "Thomas", "Daniel",
+	"Matthew", "Lucas", "Noah", "Liam", "Ethan", "Mason", "Elijah", "Benjamin", "Henry", "Jack",
+	"Oliver", "Leon", "Mateo", "Hugo", "Luca", "Wei", "Hiroshi", "Arjun", "Ivan", "Pedro",
+}
+
+var femaleNames = []string{
+	"Mary", "Patricia", "Jennifer", "Linda", "Elizabeth", "Sarah", "Jessica", "Emily", "Emma", "Olivia",
+	"Sophia", "Isabella", "Mia", "Amelia", "Ava", "Grace", "Zoe", "Natalie", "Helen", "Anna",
+	"Lucia", "Chloe", "Hannah", "Giulia", "Mei", "Yuki", "Priya", "Olga", "Ana", "Jill",
+}
+
+var surnames = []string{
+	"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Martinez", "Lopez",
+	"Wilson", "Anderson", "Thomas", "Moore", "Taylor", "Lee", "Thompson", "White", "Harris", "Clark",
+	"Muller", "Schmidt", "Schneider", "Fischer", "Rossi", "Russo", "Ferrari", "Fernandez", "Gonzalez", "Perez",
+	"Chen", "Wang", "Li", "Zhang", "Suzuki", "Tanaka", "Kim", "Patel", "Sharma", "Singh",
+	"Ivanova", "Petrov", "Silva", "Santos", "Nguyen", "Cooper", "Parker", "Nelson", "Kova", "Van Que",
+}
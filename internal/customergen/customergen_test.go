@@ -0,0 +1,114 @@
+//go:build testcoverage
+// +build testcoverage
+
+package customergen_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	entcustomer "iohk-golang-backend/ent/customer"
+	_ "iohk-golang-backend/ent/runtime" // registers schema validators
+	"iohk-golang-backend/internal/customergen"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+)
+
+func generate(t *testing.T, opts customergen.Options, n int) []*domainmodel.Customer {
+	g, err := customergen.New(opts)
+	require.NoError(t, err)
+	return slices.Collect(g.All(n))
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	// Act
+	first := generate(t, customergen.Options{Seed: 42}, 100)
+	second := generate(t, customergen.Options{Seed: 42}, 100)
+	other := generate(t, customergen.Options{Seed: 43}, 100)
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	// Pinned, so a change to the generator that alters existing datasets fails
+	assert.Equal(t, &domainmodel.Customer{
+		Name: "Arjun", Surname: "Kova", Number: 341331, Gender: domainmodel.GenderMale,
+		Country: "Japan", Dependants: 2, BirthDate: time.Date(1937, time.May, 18, 0, 0, 0, 0, time.UTC),
+	}, first[0])
+}
+
+func TestGeneratorPassesValidation(t *testing.T) {
+	// Act
+	customers := generate(t, customergen.Options{Seed: 1}, 1000)
+
+	// Assert
+	for _, c := range customers {
+		require.NoError(t, entcustomer.NameValidator(c.Name))
+		require.NoError(t, entcustomer.SurnameValidator(c.Surname))
+		require.NoError(t, entcustomer.NumberValidator(c.Number))
+		require.NoError(t, entcustomer.GenderValidator(c.Gender.ToEntGender()))
+		require.NoError(t, entcustomer.CountryValidator(c.Country))
+		require.NoError(t, entcustomer.DependantsValidator(c.Dependants))
+		require.False(t, c.BirthDate.After(customergen.DefaultAsOf))
+	}
+}
+
+func TestGeneratorDistributions(t *testing.T) {
+	// Arrange
+	asOf := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	opts := customergen.Options{
+		Seed:       7,
+		Countries:  "Spain:3,Italy:1,Peru:0",
+		Genders:    "female:1",
+		Ages:       "20-29:1,70:1",
+		Dependants: "2:1",
+		AsOf:       asOf,
+	}
+
+	// Act
+	customers := generate(t, opts, 4000)
+
+	// Assert
+	countries := map[string]int{}
+	for _, c := range customers {
+		countries[c.Country]++
+		assert.Equal(t, domainmodel.GenderFemale, c.Gender)
+		assert.Equal(t, 2, c.Dependants)
+		age := asOf.Year() - c.BirthDate.Year()
+		if c.BirthDate.AddDate(age, 0, 0).After(asOf) {
+			age--
+		}
+		assert.True(t, (age >= 20 && age <= 29) || age == 70, "age %d of %s", age, c.BirthDate)
+	}
+	assert.Len(t, countries, 2)
+	assert.InDelta(t, 3000, countries["Spain"], 150)
+	assert.InDelta(t, 1000, countries["Italy"], 150)
+}
+
+func TestNewRejectsInvalidDistributions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    customergen.Options
+		wantErr string
+	}{
+		{name: "Missing weight", opts: customergen.Options{Countries: "USA"}, wantErr: `invalid countries: "USA" is not value:weight`},
+		{name: "Negative weight", opts: customergen.Options{Countries: "USA:-1"}, wantErr: `weight of "USA" must be a non-negative integer`},
+		{name: "Zero weights", opts: customergen.Options{Dependants: "0:0,1:0"}, wantErr: "invalid dependants: weights must not all be zero"},
+		{name: "Unknown gender", opts: customergen.Options{Genders: "OTHER:1"}, wantErr: `gender "OTHER" must be MALE or FEMALE`},
+		{name: "Reversed age range", opts: customergen.Options{Ages: "60-20:1"}, wantErr: `age range "60-20"`},
+		{name: "Negative dependants", opts: customergen.Options{Dependants: "-1:1"}, wantErr: `dependants "-1"`},
+		{name: "Long country", opts: customergen.Options{Countries: "The Country With A Name Much Longer Than Fifty Letters:1"}, wantErr: "must have 1 to 50 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := customergen.New(tt.opts)
+
+			// Assert
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -1,10 +1,24 @@
 package model
 
 import (
+	"errors"
 	"iohk-golang-backend/ent/customer"
 	"time"
 )
 
+// ErrFutureBirthDate rejects a birth date after the current time.
+var ErrFutureBirthDate = errors.New("birth date cannot be in the future")
+
+// BirthDateValidator checks a birth date like the ent validators check the
+// other fields. The database cannot, since the column may be encrypted, so
+// every path writing customers calls it.
+func BirthDateValidator(birthDate time.Time) error {
+	if birthDate.After(time.Now()) {
+		return ErrFutureBirthDate
+	}
+	return nil
+}
+
 type Gender string
 
 const (
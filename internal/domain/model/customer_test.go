@@ -0,0 +1,33 @@
+//go:build testcoverage
+// +build testcoverage
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBirthDateValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		birthDate time.Time
+		wantErr   error
+	}{
+		{name: "Past", birthDate: time.Date(1906, time.December, 9, 0, 0, 0, 0, time.UTC)},
+		{name: "Now", birthDate: time.Now().Add(-time.Second)},
+		{name: "Tomorrow", birthDate: time.Now().AddDate(0, 0, 1), wantErr: ErrFutureBirthDate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := BirthDateValidator(tc.birthDate)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
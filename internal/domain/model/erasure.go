@@ -0,0 +1,140 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type ErasureMode string
+
+const (
+	ErasureModeHardDelete ErasureMode = "HARD_DELETE"
+	ErasureModeAnonymise  ErasureMode = "ANONYMISE"
+)
+
+// Placeholder values written over a customer's fields when the record is
+// anonymised instead of deleted. Country and dependants are blanked too, as
+// together with the birth date they can single a person out. Only gender, a
+// two-valued field, and the generalised birth date are kept for reporting.
+const (
+	AnonymisedName       = "Erased"
+	AnonymisedSurname    = "Erased"
+	AnonymisedNumber     = 1
+	AnonymisedCountry    = "Erased"
+	AnonymisedDependants = 0
+)
+
+// ErasureRecord is one entry of the tamper-evident erasure log.
+type ErasureRecord struct {
+	ID          int
+	CustomerID  int
+	Mode        ErasureMode
+	RequestedBy string
+	ErasedAt    time.Time
+	PrevHash    string
+	Hash        string
+}
+
+// The erasure log key is installed once at startup, like the PII keyring,
+// because records are hashed by every repository implementation.
+var erasureLogKey atomic.Pointer[[]byte]
+
+// SetErasureLogKey installs the base64 HMAC key the erasure log is chained
+// with. Without a key the chain is plain SHA-256, which anyone able to write
+// to the table can recompute after editing it.
+func SetErasureLogKey(rawKey string) error {
+	if rawKey == "" {
+		erasureLogKey.Store(nil)
+		return nil
+	}
+	key, err := base64.StdEncoding.DecodeString(rawKey)
+	if err != nil || len(key) < 32 {
+		return errors.New("erasure log key must be at least 32 base64-encoded bytes")
+	}
+	erasureLogKey.Store(&key)
+	return nil
+}
+
+// ComputeHash returns the chain hash of the record, covering every field
+// except the database ID and the hash itself. It is an HMAC-SHA256 when an
+// erasure log key is installed.
+func (r *ErasureRecord) ComputeHash() string {
+	payload := strings.Join([]string{
+		r.PrevHash,
+		strconv.Itoa(r.CustomerID),
+		string(r.Mode),
+		r.RequestedBy,
+		r.ErasedAt.UTC().Format(time.RFC3339Nano),
+	}, "|")
+	var h hash.Hash
+	if key := erasureLogKey.Load(); key != nil {
+		h = hmac.New(sha256.New, *key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ErasureLogVerification is the result of walking the erasure log hash chain.
+type ErasureLogVerification struct {
+	Valid          bool
+	Entries        int
+	FirstInvalidID int
+}
+
+// VerifyErasureLog checks that every record links to the hash of the one
+// before it and that its own hash matches its contents. Records must be in
+// insertion order.
+func VerifyErasureLog(records []*ErasureRecord) *ErasureLogVerification {
+	prevHash := ""
+	for _, r := range records {
+		if r.PrevHash != prevHash || r.ComputeHash() != r.Hash {
+			return &ErasureLogVerification{Valid: false, Entries: len(records), FirstInvalidID: r.ID}
+		}
+		prevHash = r.Hash
+	}
+	return &ErasureLogVerification{Valid: true, Entries: len(records)}
+}
+
+// AnonymiseBirthDate keeps only the decade of birth, so reporting by age band
+// still works without retaining the exact date or year.
+func AnonymiseBirthDate(birthDate time.Time) time.Time {
+	return time.Date(birthDate.Year()-birthDate.Year()%10, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// CustomerDataExport is the machine-readable bundle of everything stored about
+// a customer, returned for data-subject access requests.
+type CustomerDataExport struct {
+	FormatVersion int                   `json:"formatVersion"`
+	ExportedAt    time.Time             `json:"exportedAt"`
+	Customer      CustomerExport        `json:"customer"`
+	ErasureLog    []ErasureRecordExport `json:"erasureLog"`
+}
+
+type CustomerExport struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Surname    string `json:"surname"`
+	Number     int    `json:"number"`
+	Gender     Gender `json:"gender"`
+	Country    string `json:"country"`
+	Dependants int    `json:"dependants"`
+	BirthDate  string `json:"birthDate"`
+}
+
+type ErasureRecordExport struct {
+	ID          int         `json:"id"`
+	Mode        ErasureMode `json:"mode"`
+	RequestedBy string      `json:"requestedBy"`
+	ErasedAt    time.Time   `json:"erasedAt"`
+	Hash        string      `json:"hash"`
+}
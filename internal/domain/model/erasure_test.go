@@ -0,0 +1,141 @@
+//go:build testcoverage
+// +build testcoverage
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildErasureChain(n int) []*ErasureRecord {
+	records := make([]*ErasureRecord, n)
+	prevHash := ""
+	for i := range records {
+		r := &ErasureRecord{
+			ID:          i + 1,
+			CustomerID:  100 + i,
+			Mode:        ErasureModeAnonymise,
+			RequestedBy: "dpo",
+			ErasedAt:    time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+			PrevHash:    prevHash,
+		}
+		r.Hash = r.ComputeHash()
+		prevHash = r.Hash
+		records[i] = r
+	}
+	return records
+}
+
+func TestVerifyErasureLog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tamper   func(records []*ErasureRecord) []*ErasureRecord
+		expected *ErasureLogVerification
+	}{
+		{
+			name:     "Intact chain",
+			tamper:   func(records []*ErasureRecord) []*ErasureRecord { return records },
+			expected: &ErasureLogVerification{Valid: true, Entries: 3},
+		},
+		{
+			name:     "Empty log",
+			tamper:   func(records []*ErasureRecord) []*ErasureRecord { return nil },
+			expected: &ErasureLogVerification{Valid: true, Entries: 0},
+		},
+		{
+			name: "Edited entry",
+			tamper: func(records []*ErasureRecord) []*ErasureRecord {
+				records[1].CustomerID = 999
+				return records
+			},
+			expected: &ErasureLogVerification{Valid: false, Entries: 3, FirstInvalidID: 2},
+		},
+		{
+			name: "Removed entry",
+			tamper: func(records []*ErasureRecord) []*ErasureRecord {
+				return []*ErasureRecord{records[0], records[2]}
+			},
+			expected: &ErasureLogVerification{Valid: false, Entries: 2, FirstInvalidID: 3},
+		},
+		{
+			name: "Rehashed edit without relinking",
+			tamper: func(records []*ErasureRecord) []*ErasureRecord {
+				records[0].RequestedBy = "someone else"
+				records[0].Hash = records[0].ComputeHash()
+				return records
+			},
+			expected: &ErasureLogVerification{Valid: false, Entries: 3, FirstInvalidID: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			records := tc.tamper(buildErasureChain(3))
+
+			// Act
+			result := VerifyErasureLog(records)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestAnonymiseBirthDate(t *testing.T) {
+	// Act
+	result := AnonymiseBirthDate(time.Date(1985, 5, 15, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Equal(t, time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), result)
+}
+
+func TestSetErasureLogKey(t *testing.T) {
+	testCases := []struct {
+		name          string
+		key           string
+		expectedError string
+	}{
+		{name: "No key", key: ""},
+		{name: "Valid key", key: "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="},
+		{name: "Short key", key: "c2hvcnQ=", expectedError: "erasure log key must be at least 32 base64-encoded bytes"},
+		{name: "Not base64", key: "not base64!", expectedError: "erasure log key must be at least 32 base64-encoded bytes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			t.Cleanup(func() { _ = SetErasureLogKey("") })
+
+			// Act
+			err := SetErasureLogKey(tc.key)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyErasureLogWithKey(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { _ = SetErasureLogKey("") })
+	unkeyed := buildErasureChain(2)
+	assert.NoError(t, SetErasureLogKey("MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="))
+	keyed := buildErasureChain(2)
+
+	// Act
+	keyedResult := VerifyErasureLog(keyed)
+	unkeyedResult := VerifyErasureLog(unkeyed)
+
+	// Assert
+	assert.NotEqual(t, unkeyed[0].Hash, keyed[0].Hash)
+	assert.Equal(t, &ErasureLogVerification{Valid: true, Entries: 2}, keyedResult)
+	assert.Equal(t, &ErasureLogVerification{Valid: false, Entries: 2, FirstInvalidID: 1}, unkeyedResult, "a chain recomputed without the key is rejected")
+}
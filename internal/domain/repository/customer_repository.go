@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"iohk-golang-backend/ent"
+	entcustomer "iohk-golang-backend/ent/customer"
+	_ "iohk-golang-backend/ent/runtime" // registers schema validators and hooks
 	"iohk-golang-backend/graph/model"
 	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/db"
 	"iohk-golang-backend/internal/infra/mapper"
 )
 
@@ -17,18 +20,27 @@ type CustomerRepository interface {
 	GetAll(ctx context.Context) ([]*domainmodel.Customer, error)
 	Update(ctx context.Context, id string, input *model.UpdateCustomerInput) (*domainmodel.Customer, error)
 	Delete(ctx context.Context, id string) error
+	Erase(ctx context.Context, id string, mode domainmodel.ErasureMode, requestedBy string) (*domainmodel.ErasureRecord, error)
+	ErasureLog(ctx context.Context) ([]*domainmodel.ErasureRecord, error)
+	ErasureLogForCustomer(ctx context.Context, customerID int) ([]*domainmodel.ErasureRecord, error)
 }
 
 type customerRepository struct {
-	client *ent.Client
+	router *db.Router
 }
 
 func NewCustomerRepository(client *ent.Client) CustomerRepository {
-	return &customerRepository{client: client}
+	return NewRoutedCustomerRepository(db.NewRouter(client, nil, nil))
+}
+
+// NewRoutedCustomerRepository returns a repository that reads customers from
+// the read replica of router where it can and writes to the primary.
+func NewRoutedCustomerRepository(router *db.Router) CustomerRepository {
+	return &customerRepository{router: router}
 }
 
 func (r *customerRepository) Create(ctx context.Context, customer *domainmodel.Customer) (*domainmodel.Customer, error) {
-	entCustomer, err := r.client.Customer.
+	entCustomer, err := r.router.Writer(ctx).Customer.
 		Create().
 		SetName(customer.Name).
 		SetSurname(customer.Surname).
@@ -39,7 +51,7 @@ func (r *customerRepository) Create(ctx context.Context, customer *domainmodel.C
 		SetBirthDate(customer.BirthDate).
 		Save(ctx)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	return mapper.EntToDomain(entCustomer), nil
 }
@@ -47,21 +59,22 @@ func (r *customerRepository) Create(ctx context.Context, customer *domainmodel.C
 func (r *customerRepository) GetByID(ctx context.Context, id string) (*domainmodel.Customer, error) {
 	customerID, err := strconv.Atoi(id)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
-	c, err := r.client.Customer.Get(ctx, customerID)
+	c, err := r.router.Reader(ctx).Customer.Get(ctx, customerID)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
 	return mapper.EntToDomain(c), nil
 }
 
+// GetAll returns the customers in ID order.
 func (r *customerRepository) GetAll(ctx context.Context) ([]*domainmodel.Customer, error) {
-	customers, err := r.client.Customer.Query().All(ctx)
+	customers, err := r.router.Reader(ctx).Customer.Query().Order(ent.Asc(entcustomer.FieldID)).All(ctx)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
 	result := make([]*domainmodel.Customer, len(customers))
@@ -74,10 +87,10 @@ func (r *customerRepository) GetAll(ctx context.Context) ([]*domainmodel.Custome
 func (r *customerRepository) Update(ctx context.Context, id string, input *model.UpdateCustomerInput) (*domainmodel.Customer, error) {
 	customerID, err := strconv.Atoi(id)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
-	update := r.client.Customer.UpdateOneID(customerID)
+	update := r.router.Writer(ctx).Customer.UpdateOneID(customerID)
 
 	if input.Name != nil {
 		update.SetName(*input.Name)
@@ -100,14 +113,14 @@ func (r *customerRepository) Update(ctx context.Context, id string, input *model
 	if input.BirthDate != nil {
 		birthDate, err := time.Parse("2006-01-02", *input.BirthDate)
 		if err != nil {
-			return nil, err
+			return nil, classify(err)
 		}
 		update.SetBirthDate(birthDate)
 	}
 
 	c, err := update.Save(ctx)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
 	return mapper.EntToDomain(c), nil
@@ -116,8 +129,8 @@ func (r *customerRepository) Update(ctx context.Context, id string, input *model
 func (r *customerRepository) Delete(ctx context.Context, id string) error {
 	customerID, err := strconv.Atoi(id)
 	if err != nil {
-		return err
+		return classify(err)
 	}
 
-	return r.client.Customer.DeleteOneID(customerID).Exec(ctx)
+	return classify(r.router.Writer(ctx).Customer.DeleteOneID(customerID).Exec(ctx))
 }
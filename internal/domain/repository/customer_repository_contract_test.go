@@ -0,0 +1,37 @@
+//go:build testcoverage
+// +build testcoverage
+
+package repository_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+
+	"iohk-golang-backend/ent/enttest"
+	"iohk-golang-backend/internal/domain/repository"
+	"iohk-golang-backend/internal/domain/repository/repositorytest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestMemoryCustomerRepositoryContract(t *testing.T) {
+	repositorytest.TestCustomerRepository(t, func(t *testing.T) repositorytest.Store {
+		return repositorytest.Store{Repository: repository.NewMemoryCustomerRepository()}
+	})
+}
+
+func TestSQLiteCustomerRepositoryContract(t *testing.T) {
+	repositorytest.TestCustomerRepository(t, func(t *testing.T) repositorytest.Store {
+		// A file rather than a shared in-memory database, so every test
+		// starts empty
+		path := filepath.Join(t.TempDir(), "customers.db")
+		client := enttest.Open(t, dialect.SQLite, "file:"+path+"?_fk=1&_busy_timeout=5000")
+		t.Cleanup(func() { client.Close() })
+		return repositorytest.Store{
+			Repository:         repository.NewCustomerRepository(client),
+			AppendErasureEntry: repositorytest.EntErasureLog(client),
+		}
+	})
+}
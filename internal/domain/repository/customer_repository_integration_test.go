@@ -0,0 +1,75 @@
+//go:build integration && testcoverage
+// +build integration,testcoverage
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/ent/migrate/migrations"
+	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/domain/repository"
+	"iohk-golang-backend/internal/domain/repository/repositorytest"
+	"iohk-golang-backend/internal/infra/db"
+)
+
+// TestPostgresCustomerRepositoryContract runs the contract suite against the
+// schema the migrations create, emptied before every test.
+func TestPostgresCustomerRepositoryContract(t *testing.T) {
+	ctx := context.Background()
+	pgContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(t, err)
+	defer func() {
+		if err := pgContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	dsn, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	pool, err := db.NewDBPool(ctx, &config.Config{
+		DatabaseURL:         dsn,
+		DBMaxConns:          5,
+		DBMinConns:          1,
+		DBMaxConnLifetime:   time.Hour,
+		DBMaxConnIdleTime:   time.Minute * 30,
+		DBHealthCheckPeriod: time.Minute,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+	loaded, err := db.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	_, err = db.NewMigrator(pool, loaded).Up(ctx, 0)
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, stdlib.OpenDBFromPool(pool))))
+	defer client.Close()
+
+	repositorytest.TestCustomerRepository(t, func(t *testing.T) repositorytest.Store {
+		// TRUNCATE is not blocked by the append-only trigger of erasure_logs
+		_, err := pool.Exec(ctx, "TRUNCATE customers, erasure_logs RESTART IDENTITY")
+		require.NoError(t, err)
+		return repositorytest.Store{
+			Repository:         repository.NewCustomerRepository(client),
+			AppendErasureEntry: repositorytest.EntErasureLog(client),
+		}
+	})
+}
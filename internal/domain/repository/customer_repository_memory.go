@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	entcustomer "iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/graph/model"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+)
+
+// errMemoryNotFound has the message of ent's not found error, so API
+// responses do not depend on the storage.
+var errMemoryNotFound = withKind(ErrNotFound, errors.New("ent: customer not found"))
+
+type memoryCustomerRepository struct {
+	mu        sync.RWMutex
+	customers map[int]domainmodel.Customer
+	lastID    int
+	erasures  []domainmodel.ErasureRecord
+}
+
+// NewMemoryCustomerRepository returns a repository keeping customers in
+// memory until the process exits. It validates customers, assigns IDs and
+// fails like the ent repository does, and stores birth dates as dates only,
+// as the database does.
+func NewMemoryCustomerRepository() CustomerRepository {
+	return &memoryCustomerRepository{customers: map[int]domainmodel.Customer{}}
+}
+
+// Create returns the customer as given with its new ID, like ent, the stored
+// copy has its birth date truncated to the day.
+func (r *memoryCustomerRepository) Create(ctx context.Context, customer *domainmodel.Customer) (*domainmodel.Customer, error) {
+	if err := validateCustomer(customer); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	created := *customer
+	created.ID = r.lastID
+	r.customers[created.ID] = stored(created)
+	return &created, nil
+}
+
+func (r *memoryCustomerRepository) GetByID(ctx context.Context, id string) (*domainmodel.Customer, error) {
+	customerID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.customers[customerID]
+	if !ok {
+		return nil, errMemoryNotFound
+	}
+	return &c, nil
+}
+
+// GetAll returns the customers in ID order.
+func (r *memoryCustomerRepository) GetAll(ctx context.Context) ([]*domainmodel.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.filter(func(*domainmodel.Customer) bool { return true }), nil
+}
+
+func (r *memoryCustomerRepository) Update(ctx context.Context, id string, input *model.UpdateCustomerInput) (*domainmodel.Customer, error) {
+	customerID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, classify(err)
+	}
+	var birthDate time.Time
+	if input.BirthDate != nil {
+		birthDate, err = time.Parse("2006-01-02", *input.BirthDate)
+		if err != nil {
+			return nil, classify(err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.customers[customerID]
+	if !ok {
+		return nil, errMemoryNotFound
+	}
+	if input.Name != nil {
+		c.Name = *input.Name
+	}
+	if input.Surname != nil {
+		c.Surname = *input.Surname
+	}
+	if input.Number != nil {
+		c.Number = *input.Number
+	}
+	if input.Gender != nil {
+		c.Gender = domainmodel.Gender(*input.Gender)
+	}
+	if input.Country != nil {
+		c.Country = *input.Country
+	}
+	if input.Dependants != nil {
+		c.Dependants = *input.Dependants
+	}
+	if input.BirthDate != nil {
+		c.BirthDate = birthDate
+	}
+	if err := validateCustomer(&c); err != nil {
+		return nil, err
+	}
+
+	c = stored(c)
+	r.customers[customerID] = c
+	return &c, nil
+}
+
+func (r *memoryCustomerRepository) Delete(ctx context.Context, id string) error {
+	customerID, err := strconv.Atoi(id)
+	if err != nil {
+		return classify(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.customers[customerID]; !ok {
+		return errMemoryNotFound
+	}
+	delete(r.customers, customerID)
+	return nil
+}
+
+// Erase holds the lock while it changes the customer and appends to the
+// erasure log, so both happen or neither does, and erasures cannot race for
+// the same previous hash. Like the unique prev_hash index, it refuses to
+// extend an entry another one already extends.
+func (r *memoryCustomerRepository) Erase(ctx context.Context, id string, mode domainmodel.ErasureMode, requestedBy string) (*domainmodel.ErasureRecord, error) {
+	customerID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.customers[customerID]
+	if !ok {
+		return nil, errMemoryNotFound
+	}
+	prevHash := ""
+	if len(r.erasures) > 0 {
+		prevHash = r.erasures[len(r.erasures)-1].Hash
+	}
+
+	switch mode {
+	case domainmodel.ErasureModeHardDelete:
+		delete(r.customers, customerID)
+	case domainmodel.ErasureModeAnonymise:
+		c.Name = domainmodel.AnonymisedName
+		c.Surname = domainmodel.AnonymisedSurname
+		c.Number = domainmodel.AnonymisedNumber
+		c.Country = domainmodel.AnonymisedCountry
+		c.Dependants = domainmodel.AnonymisedDependants
+		c.BirthDate = domainmodel.AnonymiseBirthDate(c.BirthDate)
+		r.customers[customerID] = c
+	default:
+		return nil, fmt.Errorf("unsupported erasure mode %q", mode)
+	}
+
+	record := domainmodel.ErasureRecord{
+		ID:          len(r.erasures) + 1,
+		CustomerID:  customerID,
+		Mode:        mode,
+		RequestedBy: requestedBy,
+		ErasedAt:    time.Now().UTC().Truncate(time.Microsecond),
+		PrevHash:    prevHash,
+	}
+	record.Hash = record.ComputeHash()
+	r.erasures = append(r.erasures, record)
+	return &record, nil
+}
+
+func (r *memoryCustomerRepository) ErasureLog(ctx context.Context) ([]*domainmodel.ErasureRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*domainmodel.ErasureRecord, len(r.erasures))
+	for i, e := range r.erasures {
+		result[i] = &e
+	}
+	return result, nil
+}
+
+func (r *memoryCustomerRepository) ErasureLogForCustomer(ctx context.Context, customerID int) ([]*domainmodel.ErasureRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []*domainmodel.ErasureRecord{}
+	for _, e := range r.erasures {
+		if e.CustomerID == customerID {
+			result = append(result, &e)
+		}
+	}
+	return result, nil
+}
+
+// filter returns copies of the customers matching keep, in ID order. The
+// caller holds the lock.
+func (r *memoryCustomerRepository) filter(keep func(*domainmodel.Customer) bool) []*domainmodel.Customer {
+	result := []*domainmodel.Customer{}
+	for _, id := range slices.Sorted(maps.Keys(r.customers)) {
+		c := r.customers[id]
+		if keep(&c) {
+			result = append(result, &c)
+		}
+	}
+	return result
+}
+
+// validateCustomer runs the checks the ent schema runs on a write, in the
+// same order and with the same messages.
+func validateCustomer(c *domainmodel.Customer) error {
+	if err := domainmodel.BirthDateValidator(c.BirthDate); err != nil {
+		return withKind(ErrInvalidCustomer, fmt.Errorf(`ent: validator failed for field "Customer.birth_date": %w`, err))
+	}
+	for _, v := range []struct {
+		field string
+		err   error
+	}{
+		{entcustomer.FieldName, entcustomer.NameValidator(c.Name)},
+		{entcustomer.FieldSurname, entcustomer.SurnameValidator(c.Surname)},
+		{entcustomer.FieldNumber, entcustomer.NumberValidator(c.Number)},
+		{entcustomer.FieldGender, entcustomer.GenderValidator(c.Gender.ToEntGender())},
+		{entcustomer.FieldCountry, entcustomer.CountryValidator(c.Country)},
+		{entcustomer.FieldDependants, entcustomer.DependantsValidator(c.Dependants)},
+	} {
+		if v.err != nil {
+			return withKind(ErrInvalidCustomer, fmt.Errorf(`ent: validator failed for field "Customer.%s": %w`, v.field, v.err))
+		}
+	}
+	return nil
+}
+
+// stored returns c as the database returns it, with only the date of the
+// birth date, in UTC.
+func stored(c domainmodel.Customer) domainmodel.Customer {
+	y, m, d := c.BirthDate.Date()
+	c.BirthDate = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return c
+}
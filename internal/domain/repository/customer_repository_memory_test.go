@@ -0,0 +1,270 @@
+//go:build testcoverage
+// +build testcoverage
+
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	graphModel "iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/domain/model"
+)
+
+func newMemoryCustomer() *model.Customer {
+	return &model.Customer{
+		Name:       "Ada",
+		Surname:    "Lovelace",
+		Number:     1815,
+		Gender:     model.GenderFemale,
+		Country:    "UK",
+		Dependants: 3,
+		BirthDate:  time.Date(1815, 12, 10, 15, 30, 0, 0, time.FixedZone("GMT+1", 3600)),
+	}
+}
+
+func TestMemoryCreateAndGet(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryCustomerRepository()
+
+	// Act
+	first, err := repo.Create(ctx, newMemoryCustomer())
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newMemoryCustomer())
+	require.NoError(t, err)
+	got, err := repo.GetByID(ctx, strconv.Itoa(first.ID))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, 2, second.ID)
+	expected := newMemoryCustomer()
+	expected.ID = 1
+	expected.BirthDate = time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, expected, got)
+}
+
+func TestMemoryReturnsCopies(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryCustomerRepository()
+	created, err := repo.Create(ctx, newMemoryCustomer())
+	require.NoError(t, err)
+
+	// Act
+	created.Name = "Changed"
+	got, err := repo.GetByID(ctx, "1")
+	require.NoError(t, err)
+	got.Surname = "Changed"
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, all, 1)
+	assert.Equal(t, "Ada", all[0].Name)
+	assert.Equal(t, "Lovelace", all[0].Surname)
+}
+
+func TestMemoryErrors(t *testing.T) {
+	ctx := context.Background()
+	future := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	testCases := []struct {
+		name          string
+		act           func(repo CustomerRepository) error
+		expectedKind  error
+		expectedError string
+	}{
+		{
+			name: "Get invalid ID",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.GetByID(ctx, "abc")
+				return err
+			},
+			expectedKind:  ErrInvalidID,
+			expectedError: `strconv.Atoi: parsing "abc": invalid syntax`,
+		},
+		{
+			name: "Get missing customer",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.GetByID(ctx, "99")
+				return err
+			},
+			expectedKind:  ErrNotFound,
+			expectedError: "ent: customer not found",
+		},
+		{
+			name: "Create with empty name",
+			act: func(repo CustomerRepository) error {
+				c := newMemoryCustomer()
+				c.Name = ""
+				_, err := repo.Create(ctx, c)
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: `ent: validator failed for field "Customer.name": value is less than the required length`,
+		},
+		{
+			name: "Create with unknown gender",
+			act: func(repo CustomerRepository) error {
+				c := newMemoryCustomer()
+				c.Gender = "OTHER"
+				_, err := repo.Create(ctx, c)
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: `validator failed for field "Customer.gender"`,
+		},
+		{
+			name: "Update with negative dependants",
+			act: func(repo CustomerRepository) error {
+				dependants := -1
+				_, err := repo.Update(ctx, "1", &graphModel.UpdateCustomerInput{Dependants: &dependants})
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: `ent: validator failed for field "Customer.dependants": value out of range`,
+		},
+		{
+			name: "Update with future birth date",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.Update(ctx, "1", &graphModel.UpdateCustomerInput{BirthDate: &future})
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: "birth date cannot be in the future",
+		},
+		{
+			name: "Update missing customer",
+			act: func(repo CustomerRepository) error {
+				name := "New"
+				_, err := repo.Update(ctx, "99", &graphModel.UpdateCustomerInput{Name: &name})
+				return err
+			},
+			expectedKind:  ErrNotFound,
+			expectedError: "ent: customer not found",
+		},
+		{
+			name: "Delete missing customer",
+			act: func(repo CustomerRepository) error {
+				return repo.Delete(ctx, "99")
+			},
+			expectedKind:  ErrNotFound,
+			expectedError: "ent: customer not found",
+		},
+		{
+			name: "Erase invalid ID",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.Erase(ctx, "", model.ErasureModeHardDelete, "dpo")
+				return err
+			},
+			expectedKind:  ErrInvalidID,
+			expectedError: `strconv.Atoi: parsing "": invalid syntax`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo := NewMemoryCustomerRepository()
+			_, err := repo.Create(ctx, newMemoryCustomer())
+			require.NoError(t, err)
+
+			// Act
+			err = tc.act(repo)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expectedKind)
+			assert.ErrorContains(t, err, tc.expectedError)
+			all, err := repo.GetAll(ctx)
+			require.NoError(t, err)
+			assert.Len(t, all, 1, "a failed write changes nothing")
+			assert.Equal(t, "Ada", all[0].Name)
+			assert.Equal(t, 3, all[0].Dependants)
+		})
+	}
+}
+
+func TestMemoryUpdateChangesOnlyGivenFields(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryCustomerRepository()
+	_, err := repo.Create(ctx, newMemoryCustomer())
+	require.NoError(t, err)
+	country := "France"
+	birthDate := "1816-01-02"
+
+	// Act
+	updated, err := repo.Update(ctx, "1", &graphModel.UpdateCustomerInput{Country: &country, BirthDate: &birthDate})
+	require.NoError(t, err)
+	got, err := repo.GetByID(ctx, "1")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, updated, got)
+	assert.Equal(t, "Ada", got.Name)
+	assert.Equal(t, "France", got.Country)
+	assert.Equal(t, time.Date(1816, 1, 2, 0, 0, 0, 0, time.UTC), got.BirthDate)
+}
+
+func TestMemoryEraseChainsTheLog(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryCustomerRepository()
+	for range 2 {
+		_, err := repo.Create(ctx, newMemoryCustomer())
+		require.NoError(t, err)
+	}
+
+	// Act
+	first, err := repo.Erase(ctx, "1", model.ErasureModeAnonymise, "dpo")
+	require.NoError(t, err)
+	second, err := repo.Erase(ctx, "2", model.ErasureModeHardDelete, "dpo")
+	require.NoError(t, err)
+	_, err = repo.Erase(ctx, "2", model.ErasureModeHardDelete, "dpo")
+	log, logErr := repo.ErasureLog(ctx)
+	require.NoError(t, logErr)
+	anonymised, getErr := repo.GetByID(ctx, "1")
+	require.NoError(t, getErr)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, []*model.ErasureRecord{first, second}, log)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.True(t, model.VerifyErasureLog(log).Valid)
+	assert.Equal(t, model.AnonymisedName, anonymised.Name)
+	assert.Equal(t, model.AnonymisedCountry, anonymised.Country)
+	assert.Equal(t, time.Date(1810, 1, 1, 0, 0, 0, 0, time.UTC), anonymised.BirthDate)
+}
+
+func TestMemoryConcurrentCreatesGetDistinctIDs(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewMemoryCustomerRepository()
+	var wg sync.WaitGroup
+
+	// Act
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Create(ctx, newMemoryCustomer())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, all, 50)
+	for i, c := range all {
+		assert.Equal(t, i+1, c.ID)
+	}
+}
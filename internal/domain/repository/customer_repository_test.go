@@ -4,8 +4,12 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/base64"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +20,8 @@ import (
 	"iohk-golang-backend/ent/enttest"
 	graphModel "iohk-golang-backend/graph/model" // Add this import
 	"iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/db"
+	"iohk-golang-backend/internal/infra/piicrypto"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -209,6 +215,110 @@ func TestGetAll(t *testing.T) {
 	}
 }
 
+func TestSurnameBlindIndex(t *testing.T) {
+	testCases := []struct {
+		name          string
+		keyring       *piicrypto.Keyring
+		surname       string
+		expectedCount int
+	}{
+		{
+			name:          "Exact match without encryption",
+			surname:       "Smith",
+			expectedCount: 1,
+		},
+		{
+			name:          "Match ignores case and surrounding whitespace",
+			surname:       " smith ",
+			expectedCount: 1,
+		},
+		{
+			name:          "Exact match with encryption",
+			keyring:       newTestKeyring(t),
+			surname:       "Smith",
+			expectedCount: 1,
+		},
+		{
+			name:          "No partial matches",
+			surname:       "Smi",
+			expectedCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			piicrypto.SetDefault(tc.keyring)
+			defer piicrypto.SetDefault(nil)
+			client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+			defer client.Close()
+			repo := NewCustomerRepository(client)
+			_, err := repo.Create(context.Background(), &model.Customer{
+				Name:      "Alice",
+				Surname:   "Smith",
+				Number:    123,
+				Gender:    model.GenderFemale,
+				Country:   "UK",
+				BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+			})
+			assert.NoError(t, err, "Setup should not fail")
+
+			// Act
+			count, err := client.Customer.Query().
+				Where(customer.SurnameBidx(piicrypto.BlindIndex(tc.surname))).
+				Count(context.Background())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestCreateEncryptsPII(t *testing.T) {
+	// Arrange
+	piicrypto.SetDefault(newTestKeyring(t))
+	defer piicrypto.SetDefault(nil)
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	repo := NewCustomerRepository(client)
+	raw, err := sql.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	assert.NoError(t, err)
+	defer raw.Close()
+
+	// Act
+	created, err := repo.Create(context.Background(), &model.Customer{
+		Name:      "Alice",
+		Surname:   "Smith",
+		Number:    123,
+		Gender:    model.GenderFemale,
+		Country:   "UK",
+		BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	var name, surname, birthDate string
+	err = raw.QueryRow("SELECT name, surname, birth_date FROM customers WHERE id = ?", created.ID).Scan(&name, &surname, &birthDate)
+	assert.NoError(t, err)
+	for _, stored := range []string{name, surname, birthDate} {
+		assert.True(t, strings.HasPrefix(stored, "enc:v1:"), "expected ciphertext, got %q", stored)
+	}
+	fetched, err := repo.GetByID(context.Background(), strconv.Itoa(created.ID))
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", fetched.Name)
+	assert.Equal(t, "Smith", fetched.Surname)
+}
+
+func newTestKeyring(t *testing.T) *piicrypto.Keyring {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	keyring, err := piicrypto.NewKeyring("1:"+key, 0, key)
+	if err != nil {
+		t.Fatalf("Failed to create test keyring: %v", err)
+	}
+	return keyring
+}
+
 func TestUpdate(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -346,3 +456,153 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestErase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mode          model.ErasureMode
+		id            func(created *model.Customer) string
+		expectedError string
+		verify        func(t *testing.T, repo CustomerRepository, created *model.Customer)
+	}{
+		{
+			name: "Hard delete removes the customer",
+			mode: model.ErasureModeHardDelete,
+			id:   func(created *model.Customer) string { return strconv.Itoa(created.ID) },
+			verify: func(t *testing.T, repo CustomerRepository, created *model.Customer) {
+				_, err := repo.GetByID(context.Background(), strconv.Itoa(created.ID))
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "not found")
+			},
+		},
+		{
+			name: "Anonymise overwrites identifying fields",
+			mode: model.ErasureModeAnonymise,
+			id:   func(created *model.Customer) string { return strconv.Itoa(created.ID) },
+			verify: func(t *testing.T, repo CustomerRepository, created *model.Customer) {
+				c, err := repo.GetByID(context.Background(), strconv.Itoa(created.ID))
+				assert.NoError(t, err)
+				assert.Equal(t, model.AnonymisedName, c.Name)
+				assert.Equal(t, model.AnonymisedSurname, c.Surname)
+				assert.Equal(t, model.AnonymisedNumber, c.Number)
+				assert.Equal(t, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), c.BirthDate)
+				assert.Equal(t, model.AnonymisedCountry, c.Country)
+				assert.Equal(t, model.AnonymisedDependants, c.Dependants)
+			},
+		},
+		{
+			name:          "Non-existing customer",
+			mode:          model.ErasureModeHardDelete,
+			id:            func(created *model.Customer) string { return "999" },
+			expectedError: "not found",
+		},
+		{
+			name:          "Invalid ID",
+			mode:          model.ErasureModeHardDelete,
+			id:            func(created *model.Customer) string { return "non-existing-id" },
+			expectedError: "strconv.Atoi: parsing \"non-existing-id\": invalid syntax",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+			defer client.Close()
+			repo := NewCustomerRepository(client)
+			created, err := repo.Create(context.Background(), &model.Customer{
+				Name:      "Alice",
+				Surname:   "Smith",
+				Number:    123,
+				Gender:    model.GenderFemale,
+				Country:   "UK",
+				BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+			})
+			assert.NoError(t, err, "Setup should not fail")
+
+			// Act
+			record, err := repo.Erase(context.Background(), tc.id(created), tc.mode, "dpo")
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Nil(t, record)
+				log, err := repo.ErasureLog(context.Background())
+				assert.NoError(t, err)
+				assert.Empty(t, log, "failed erasures must not be logged")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, created.ID, record.CustomerID)
+			assert.Equal(t, tc.mode, record.Mode)
+			assert.Equal(t, "dpo", record.RequestedBy)
+			tc.verify(t, repo, created)
+		})
+	}
+}
+
+func TestErasureLogChain(t *testing.T) {
+	// Arrange
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	repo := NewCustomerRepository(client)
+	for i := 0; i < 3; i++ {
+		created, err := repo.Create(context.Background(), &model.Customer{
+			Name:      "Alice",
+			Surname:   "Smith",
+			Number:    123,
+			Gender:    model.GenderFemale,
+			Country:   "UK",
+			BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err, "Setup should not fail")
+		_, err = repo.Erase(context.Background(), strconv.Itoa(created.ID), model.ErasureModeHardDelete, "dpo")
+		assert.NoError(t, err, "Setup should not fail")
+	}
+
+	// Act
+	log, err := repo.ErasureLog(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, log, 3)
+	assert.Equal(t, "", log[0].PrevHash)
+	assert.Equal(t, log[0].Hash, log[1].PrevHash)
+	assert.Equal(t, log[1].Hash, log[2].PrevHash)
+	assert.True(t, model.VerifyErasureLog(log).Valid)
+}
+
+func TestRoutedRepositoryReadsOwnWrites(t *testing.T) {
+	// Arrange
+	primary := enttest.Open(t, "sqlite3", "file:routed-primary?mode=memory&cache=shared&_fk=1")
+	defer primary.Close()
+	replica := enttest.Open(t, "sqlite3", "file:routed-replica?mode=memory&cache=shared&_fk=1")
+	defer replica.Close()
+	router := db.NewRouter(primary, replica, func(context.Context) error { return nil })
+	router.Check(context.Background())
+	repo := NewRoutedCustomerRepository(router)
+	writeSession := db.WithSession(context.Background())
+	otherSession := db.WithSession(context.Background())
+
+	// Act
+	created, err := repo.Create(writeSession, &model.Customer{
+		Name:      "Routed",
+		Surname:   "Customer",
+		Number:    123,
+		Gender:    model.GenderFemale,
+		Country:   "Testland",
+		BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	ownRead, ownErr := repo.GetAll(writeSession)
+	otherRead, otherErr := repo.GetAll(otherSession)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, ownErr)
+	assert.NoError(t, otherErr)
+	if assert.Len(t, ownRead, 1, "the writing session reads from the primary") {
+		assert.Equal(t, created.ID, ownRead[0].ID)
+	}
+	assert.Empty(t, otherRead, "other sessions read from the replica, which has not caught up")
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"iohk-golang-backend/graph/model"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type tracedCustomerRepository struct {
+	next CustomerRepository
+}
+
+// NewTracedCustomerRepository wraps next so that every call gets a span.
+// Customer IDs are recorded, customer data is not.
+func NewTracedCustomerRepository(next CustomerRepository) CustomerRepository {
+	return &tracedCustomerRepository{next: next}
+}
+
+func (r *tracedCustomerRepository) Create(ctx context.Context, customer *domainmodel.Customer) (_ *domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Create")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Create(ctx, customer)
+}
+
+func (r *tracedCustomerRepository) GetByID(ctx context.Context, id string) (_ *domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.GetByID", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *tracedCustomerRepository) GetAll(ctx context.Context) (_ []*domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.GetAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.GetAll(ctx)
+}
+
+func (r *tracedCustomerRepository) Update(ctx context.Context, id string, input *model.UpdateCustomerInput) (_ *domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Update", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, id, input)
+}
+
+func (r *tracedCustomerRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Delete", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tracedCustomerRepository) Erase(ctx context.Context, id string, mode domainmodel.ErasureMode, requestedBy string) (_ *domainmodel.ErasureRecord, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.Erase",
+		attribute.String("customer.id", id),
+		attribute.String("erasure.mode", string(mode)),
+	)
+	defer func() { tracing.End(span, err) }()
+	return r.next.Erase(ctx, id, mode, requestedBy)
+}
+
+func (r *tracedCustomerRepository) ErasureLog(ctx context.Context) (_ []*domainmodel.ErasureRecord, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ErasureLog")
+	defer func() { tracing.End(span, err) }()
+	return r.next.ErasureLog(ctx)
+}
+
+func (r *tracedCustomerRepository) ErasureLogForCustomer(ctx context.Context, customerID int) (_ []*domainmodel.ErasureRecord, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerRepository.ErasureLogForCustomer", attribute.String("customer.id", strconv.Itoa(customerID)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.ErasureLogForCustomer(ctx, customerID)
+}
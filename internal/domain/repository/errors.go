@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"iohk-golang-backend/ent"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+)
+
+// Kinds of errors every CustomerRepository returns, whatever stores the
+// customers. Check for them with errors.Is, the messages stay those of the
+// underlying error.
+var (
+	ErrNotFound        = errors.New("customer not found")
+	ErrInvalidID       = errors.New("invalid customer ID")
+	ErrInvalidCustomer = errors.New("invalid customer")
+	ErrConstraint      = errors.New("constraint failed")
+)
+
+// kindError adds one of the error kinds above to err without changing its
+// message, so callers matching on ent errors keep working.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func withKind(kind, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+// classify adds the kind of err, if it has one, to an error from ent or from
+// parsing the arguments.
+func classify(err error) error {
+	var numErr *strconv.NumError
+	var parseErr *time.ParseError
+	switch {
+	case err == nil:
+		return nil
+	case ent.IsNotFound(err):
+		return withKind(ErrNotFound, err)
+	case ent.IsValidationError(err), errors.Is(err, domainmodel.ErrFutureBirthDate), errors.As(err, &parseErr):
+		return withKind(ErrInvalidCustomer, err)
+	case ent.IsConstraintError(err):
+		return withKind(ErrConstraint, err)
+	case errors.As(err, &numErr):
+		return withKind(ErrInvalidID, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,96 @@
+//go:build testcoverage
+// +build testcoverage
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"iohk-golang-backend/ent/enttest"
+	graphModel "iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/domain/model"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestEntRepositoryErrorKinds(t *testing.T) {
+	ctx := context.Background()
+	badDate := "04/03/1990"
+	testCases := []struct {
+		name          string
+		act           func(repo CustomerRepository) error
+		expectedKind  error
+		expectedError string
+	}{
+		{
+			name: "Invalid ID",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.GetByID(ctx, "abc")
+				return err
+			},
+			expectedKind:  ErrInvalidID,
+			expectedError: `strconv.Atoi: parsing "abc": invalid syntax`,
+		},
+		{
+			name: "Missing customer",
+			act: func(repo CustomerRepository) error {
+				return repo.Delete(ctx, "99")
+			},
+			expectedKind:  ErrNotFound,
+			expectedError: "ent: customer not found",
+		},
+		{
+			name: "Failed validator",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.Create(ctx, &model.Customer{Name: "Test", Surname: "User", Number: -1, Gender: model.GenderMale, Country: "Testland"})
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: `ent: validator failed for field "Customer.number": value out of range`,
+		},
+		{
+			name: "Future birth date",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.Create(ctx, &model.Customer{Name: "Test", Surname: "User", Number: 1, Gender: model.GenderMale, Country: "Testland", BirthDate: time.Now().AddDate(1, 0, 0)})
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: `ent: validator failed for field "Customer.birth_date": birth date cannot be in the future`,
+		},
+		{
+			name: "Unparsable birth date",
+			act: func(repo CustomerRepository) error {
+				_, err := repo.Update(ctx, "1", &graphModel.UpdateCustomerInput{BirthDate: &badDate})
+				return err
+			},
+			expectedKind:  ErrInvalidCustomer,
+			expectedError: `parsing time "04/03/1990"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+			defer client.Close()
+			repo := NewCustomerRepository(client)
+
+			// Act
+			err := tc.act(repo)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expectedKind)
+			assert.ErrorContains(t, err, tc.expectedError)
+			for _, kind := range []error{ErrNotFound, ErrInvalidID, ErrInvalidCustomer, ErrConstraint} {
+				if kind != tc.expectedKind {
+					assert.False(t, errors.Is(err, kind), "also %v", kind)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,508 @@
+//go:build testcoverage
+// +build testcoverage
+
+// Package repositorytest holds the behaviour every
+// repository.CustomerRepository must have, as one test suite each
+// implementation runs.
+package repositorytest
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"iohk-golang-backend/ent"
+	graphModel "iohk-golang-backend/graph/model"
+	"iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/domain/repository"
+	"iohk-golang-backend/internal/infra/mapper"
+)
+
+// Store is an implementation under test.
+type Store struct {
+	Repository repository.CustomerRepository
+	// AppendErasureEntry writes entry to the erasure log directly, as another
+	// client could, to set up states the repository never creates itself. It
+	// is nil for stores that no other client can write to.
+	AppendErasureEntry func(t *testing.T, entry model.ErasureRecord)
+}
+
+// EntErasureLog returns a Store.AppendErasureEntry writing with client.
+func EntErasureLog(client *ent.Client) func(t *testing.T, entry model.ErasureRecord) {
+	return func(t *testing.T, entry model.ErasureRecord) {
+		t.Helper()
+		_, err := client.ErasureLog.Create().
+			SetCustomerID(entry.CustomerID).
+			SetMode(mapper.ErasureModeToEnt(entry.Mode)).
+			SetRequestedBy(entry.RequestedBy).
+			SetErasedAt(entry.ErasedAt).
+			SetPrevHash(entry.PrevHash).
+			SetHash(entry.Hash).
+			Save(context.Background())
+		require.NoError(t, err)
+	}
+}
+
+// TestCustomerRepository runs the suite. newStore is called once per test
+// and must return an empty store.
+func TestCustomerRepository(t *testing.T, newStore func(t *testing.T) Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo repository.CustomerRepository)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"AssignsIncreasingIDs", testAssignsIncreasingIDs},
+		{"GetAllInIDOrder", testGetAllInIDOrder},
+		{"PartialUpdates", testPartialUpdates},
+		{"InvalidCreates", testInvalidCreates},
+		{"InvalidUpdates", testInvalidUpdates},
+		{"NotFound", testNotFound},
+		{"InvalidIDs", testInvalidIDs},
+		{"BirthDates", testBirthDates},
+		{"Delete", testDelete},
+		{"EraseAnonymise", testEraseAnonymise},
+		{"EraseHardDelete", testEraseHardDelete},
+		{"EraseUnsupportedMode", testEraseUnsupportedMode},
+		{"ErasureLogForCustomer", testErasureLogForCustomer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t).Repository)
+		})
+	}
+	t.Run("EraseForkingErasureLog", func(t *testing.T) {
+		store := newStore(t)
+		if store.AppendErasureEntry == nil {
+			t.Skip("the erasure log cannot be written by another client")
+		}
+		testEraseForkingErasureLog(t, store)
+	})
+}
+
+func newCustomer() *model.Customer {
+	return &model.Customer{
+		Name:       "Grace",
+		Surname:    "Hopper",
+		Number:     1906,
+		Gender:     model.GenderFemale,
+		Country:    "USA",
+		Dependants: 2,
+		BirthDate:  time.Date(1906, time.December, 9, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func create(t *testing.T, repo repository.CustomerRepository, change func(c *model.Customer)) *model.Customer {
+	t.Helper()
+	c := newCustomer()
+	change(c)
+	created, err := repo.Create(context.Background(), c)
+	require.NoError(t, err)
+	return created
+}
+
+func get(t *testing.T, repo repository.CustomerRepository, id int) *model.Customer {
+	t.Helper()
+	c, err := repo.GetByID(context.Background(), strconv.Itoa(id))
+	require.NoError(t, err)
+	return c
+}
+
+func ids(customers []*model.Customer) []int {
+	result := make([]int, len(customers))
+	for i, c := range customers {
+		result[i] = c.ID
+	}
+	return result
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testCreateAndGet(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	created, err := repo.Create(ctx, newCustomer())
+	require.NoError(t, err)
+	got, err := repo.GetByID(ctx, strconv.Itoa(created.ID))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Positive(t, created.ID)
+	expected := newCustomer()
+	expected.ID = created.ID
+	assert.Equal(t, expected, created)
+	assert.Equal(t, expected, got)
+}
+
+func testAssignsIncreasingIDs(t *testing.T, repo repository.CustomerRepository) {
+	// Act
+	first := create(t, repo, func(*model.Customer) {})
+	second := create(t, repo, func(*model.Customer) {})
+	third := create(t, repo, func(*model.Customer) {})
+
+	// Assert
+	assert.Less(t, first.ID, second.ID)
+	assert.Less(t, second.ID, third.ID)
+}
+
+func testGetAllInIDOrder(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	empty, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	var created []int
+	for _, name := range []string{"Carol", "Alice", "Bob"} {
+		created = append(created, create(t, repo, func(c *model.Customer) { c.Name = name }).ID)
+	}
+	// An update moves the row in PostgreSQL, the order must not change
+	_, err = repo.Update(ctx, strconv.Itoa(created[0]), &graphModel.UpdateCustomerInput{Country: ptr("Canada")})
+	require.NoError(t, err)
+
+	// Act
+	all, err := repo.GetAll(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+	assert.Equal(t, created, ids(all))
+	assert.Equal(t, "Canada", all[0].Country)
+}
+
+func testPartialUpdates(t *testing.T, repo repository.CustomerRepository) {
+	tests := []struct {
+		name   string
+		input  graphModel.UpdateCustomerInput
+		change func(c *model.Customer)
+	}{
+		{name: "Nothing", input: graphModel.UpdateCustomerInput{}, change: func(*model.Customer) {}},
+		{name: "Name", input: graphModel.UpdateCustomerInput{Name: ptr("Amazing")}, change: func(c *model.Customer) { c.Name = "Amazing" }},
+		{name: "Surname", input: graphModel.UpdateCustomerInput{Surname: ptr("Murray")}, change: func(c *model.Customer) { c.Surname = "Murray" }},
+		{name: "Number", input: graphModel.UpdateCustomerInput{Number: ptr(7)}, change: func(c *model.Customer) { c.Number = 7 }},
+		{name: "Gender", input: graphModel.UpdateCustomerInput{Gender: ptr(graphModel.GenderMale)}, change: func(c *model.Customer) { c.Gender = model.GenderMale }},
+		{name: "Country", input: graphModel.UpdateCustomerInput{Country: ptr("Canada")}, change: func(c *model.Customer) { c.Country = "Canada" }},
+		{name: "Zero dependants", input: graphModel.UpdateCustomerInput{Dependants: ptr(0)}, change: func(c *model.Customer) { c.Dependants = 0 }},
+		{name: "Birth date", input: graphModel.UpdateCustomerInput{BirthDate: ptr("1910-05-06")}, change: func(c *model.Customer) {
+			c.BirthDate = time.Date(1910, time.May, 6, 0, 0, 0, 0, time.UTC)
+		}},
+		{name: "Several fields", input: graphModel.UpdateCustomerInput{Name: ptr("Amazing"), Dependants: ptr(5)}, change: func(c *model.Customer) {
+			c.Name = "Amazing"
+			c.Dependants = 5
+		}},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			other := create(t, repo, func(*model.Customer) {})
+			c := create(t, repo, func(*model.Customer) {})
+
+			// Act
+			updated, err := repo.Update(ctx, strconv.Itoa(c.ID), &tt.input)
+			require.NoError(t, err)
+
+			// Assert
+			expected := newCustomer()
+			expected.ID = c.ID
+			tt.change(expected)
+			assert.Equal(t, expected, updated)
+			assert.Equal(t, expected, get(t, repo, c.ID))
+			assert.Equal(t, other, get(t, repo, other.ID), "other customers are unchanged")
+		})
+	}
+}
+
+// invalidChanges break one rule of the schema each.
+var invalidChanges = []struct {
+	name   string
+	change func(c *model.Customer)
+	input  graphModel.UpdateCustomerInput
+}{
+	{"Empty name", func(c *model.Customer) { c.Name = "" }, graphModel.UpdateCustomerInput{Name: ptr("")}},
+	{"Name too long", func(c *model.Customer) { c.Name = strings.Repeat("a", 101) }, graphModel.UpdateCustomerInput{Name: ptr(strings.Repeat("a", 101))}},
+	{"Empty surname", func(c *model.Customer) { c.Surname = "" }, graphModel.UpdateCustomerInput{Surname: ptr("")}},
+	{"Zero number", func(c *model.Customer) { c.Number = 0 }, graphModel.UpdateCustomerInput{Number: ptr(0)}},
+	{"Negative number", func(c *model.Customer) { c.Number = -5 }, graphModel.UpdateCustomerInput{Number: ptr(-5)}},
+	{"Unknown gender", func(c *model.Customer) { c.Gender = "OTHER" }, graphModel.UpdateCustomerInput{Gender: ptr(graphModel.Gender("OTHER"))}},
+	{"Empty country", func(c *model.Customer) { c.Country = "" }, graphModel.UpdateCustomerInput{Country: ptr("")}},
+	{"Country too long", func(c *model.Customer) { c.Country = strings.Repeat("a", 51) }, graphModel.UpdateCustomerInput{Country: ptr(strings.Repeat("a", 51))}},
+	{"Negative dependants", func(c *model.Customer) { c.Dependants = -1 }, graphModel.UpdateCustomerInput{Dependants: ptr(-1)}},
+	{"Future birth date", func(c *model.Customer) { c.BirthDate = time.Now().AddDate(0, 0, 2) }, graphModel.UpdateCustomerInput{BirthDate: ptr(time.Now().AddDate(0, 0, 2).Format("2006-01-02"))}},
+}
+
+func testInvalidCreates(t *testing.T, repo repository.CustomerRepository) {
+	ctx := context.Background()
+	for _, tt := range invalidChanges {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			c := newCustomer()
+			tt.change(c)
+
+			// Act
+			created, err := repo.Create(ctx, c)
+
+			// Assert
+			assert.ErrorIs(t, err, repository.ErrInvalidCustomer)
+			assert.Nil(t, created)
+			all, err := repo.GetAll(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, all, "nothing is stored")
+		})
+	}
+}
+
+func testInvalidUpdates(t *testing.T, repo repository.CustomerRepository) {
+	ctx := context.Background()
+	c := create(t, repo, func(*model.Customer) {})
+	for _, tt := range append(invalidChanges, struct {
+		name   string
+		change func(c *model.Customer)
+		input  graphModel.UpdateCustomerInput
+	}{"Malformed birth date", nil, graphModel.UpdateCustomerInput{Name: ptr("Amazing"), BirthDate: ptr("1906-02-30")}}) {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			updated, err := repo.Update(ctx, strconv.Itoa(c.ID), &tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, repository.ErrInvalidCustomer)
+			assert.Nil(t, updated)
+			assert.Equal(t, c, get(t, repo, c.ID), "the customer is unchanged")
+		})
+	}
+}
+
+func testNotFound(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	deleted := create(t, repo, func(*model.Customer) {})
+	require.NoError(t, repo.Delete(ctx, strconv.Itoa(deleted.ID)))
+	for _, id := range []string{strconv.Itoa(deleted.ID), "999999", "0", "-1"} {
+		t.Run(id, func(t *testing.T) {
+			// Act
+			got, getErr := repo.GetByID(ctx, id)
+			updated, updateErr := repo.Update(ctx, id, &graphModel.UpdateCustomerInput{Name: ptr("Amazing")})
+			deleteErr := repo.Delete(ctx, id)
+			record, eraseErr := repo.Erase(ctx, id, model.ErasureModeHardDelete, "dpo")
+
+			// Assert
+			assert.ErrorIs(t, getErr, repository.ErrNotFound)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, updateErr, repository.ErrNotFound)
+			assert.Nil(t, updated)
+			assert.ErrorIs(t, deleteErr, repository.ErrNotFound)
+			assert.ErrorIs(t, eraseErr, repository.ErrNotFound)
+			assert.Nil(t, record)
+		})
+	}
+	log, err := repo.ErasureLog(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, log, "failed erasures are not logged")
+}
+
+func testInvalidIDs(t *testing.T, repo repository.CustomerRepository) {
+	ctx := context.Background()
+	create(t, repo, func(*model.Customer) {})
+	for _, id := range []string{"", "abc", "1.5", "1 ", "99999999999999999999"} {
+		t.Run(strconv.Quote(id), func(t *testing.T) {
+			// Act
+			_, getErr := repo.GetByID(ctx, id)
+			_, updateErr := repo.Update(ctx, id, &graphModel.UpdateCustomerInput{Name: ptr("Amazing")})
+			deleteErr := repo.Delete(ctx, id)
+			_, eraseErr := repo.Erase(ctx, id, model.ErasureModeHardDelete, "dpo")
+
+			// Assert
+			for _, err := range []error{getErr, updateErr, deleteErr, eraseErr} {
+				assert.ErrorIs(t, err, repository.ErrInvalidID)
+				assert.NotErrorIs(t, err, repository.ErrNotFound)
+			}
+		})
+	}
+}
+
+func testBirthDates(t *testing.T, repo repository.CustomerRepository) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	y, m, d := yesterday.Date()
+	tests := []struct {
+		name      string
+		birthDate time.Time
+		expected  time.Time
+	}{
+		{name: "Leap day", birthDate: time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC), expected: time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "Before 1900", birthDate: time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC), expected: time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "Time of day is dropped", birthDate: time.Date(1985, time.July, 13, 23, 59, 59, 999, time.UTC), expected: time.Date(1985, time.July, 13, 0, 0, 0, 0, time.UTC)},
+		{name: "Date in its own time zone", birthDate: time.Date(1985, time.July, 13, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)), expected: time.Date(1985, time.July, 13, 0, 0, 0, 0, time.UTC)},
+		{name: "Yesterday", birthDate: yesterday, expected: time.Date(y, m, d, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			c := create(t, repo, func(c *model.Customer) { c.BirthDate = tt.birthDate })
+
+			// Assert
+			assert.Equal(t, tt.expected, get(t, repo, c.ID).BirthDate)
+		})
+	}
+}
+
+func testDelete(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	first := create(t, repo, func(*model.Customer) {})
+	second := create(t, repo, func(*model.Customer) {})
+
+	// Act
+	err := repo.Delete(ctx, strconv.Itoa(first.ID))
+	require.NoError(t, err)
+	again := repo.Delete(ctx, strconv.Itoa(first.ID))
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.ErrorIs(t, again, repository.ErrNotFound)
+	assert.Equal(t, []int{second.ID}, ids(all))
+	log, err := repo.ErasureLog(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, log, "deletion is not an erasure")
+}
+
+func testEraseAnonymise(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	c := create(t, repo, func(*model.Customer) {})
+	before := time.Now().UTC().Add(-time.Second)
+
+	// Act
+	record, err := repo.Erase(ctx, strconv.Itoa(c.ID), model.ErasureModeAnonymise, "dpo")
+	require.NoError(t, err)
+	log, err := repo.ErasureLog(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, &model.Customer{
+		ID:         c.ID,
+		Name:       model.AnonymisedName,
+		Surname:    model.AnonymisedSurname,
+		Number:     model.AnonymisedNumber,
+		Gender:     c.Gender,
+		Country:    model.AnonymisedCountry,
+		Dependants: model.AnonymisedDependants,
+		BirthDate:  time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}, get(t, repo, c.ID))
+	assert.Positive(t, record.ID)
+	assert.Equal(t, c.ID, record.CustomerID)
+	assert.Equal(t, model.ErasureModeAnonymise, record.Mode)
+	assert.Equal(t, "dpo", record.RequestedBy)
+	assert.WithinRange(t, record.ErasedAt, before, time.Now().UTC().Add(time.Second))
+	assert.Empty(t, record.PrevHash)
+	assert.Equal(t, []*model.ErasureRecord{record}, log)
+}
+
+func testEraseHardDelete(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	first := create(t, repo, func(*model.Customer) {})
+	second := create(t, repo, func(*model.Customer) {})
+	kept := create(t, repo, func(*model.Customer) {})
+
+	// Act
+	firstRecord, err := repo.Erase(ctx, strconv.Itoa(first.ID), model.ErasureModeHardDelete, "dpo")
+	require.NoError(t, err)
+	secondRecord, err := repo.Erase(ctx, strconv.Itoa(second.ID), model.ErasureModeHardDelete, "support")
+	require.NoError(t, err)
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	log, err := repo.ErasureLog(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []int{kept.ID}, ids(all))
+	assert.Equal(t, []*model.ErasureRecord{firstRecord, secondRecord}, log, "the log is in erasure order")
+	assert.Less(t, firstRecord.ID, secondRecord.ID)
+	assert.Equal(t, firstRecord.Hash, secondRecord.PrevHash)
+	assert.Equal(t, &model.ErasureLogVerification{Valid: true, Entries: 2}, model.VerifyErasureLog(log))
+}
+
+func testEraseUnsupportedMode(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	c := create(t, repo, func(*model.Customer) {})
+
+	// Act
+	record, err := repo.Erase(ctx, strconv.Itoa(c.ID), "SHRED", "dpo")
+
+	// Assert
+	assert.ErrorContains(t, err, `unsupported erasure mode "SHRED"`)
+	assert.Nil(t, record)
+	assert.Equal(t, c, get(t, repo, c.ID))
+	log, err := repo.ErasureLog(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, log)
+}
+
+func testErasureLogForCustomer(t *testing.T, repo repository.CustomerRepository) {
+	// Arrange
+	ctx := context.Background()
+	first := create(t, repo, func(*model.Customer) {})
+	second := create(t, repo, func(*model.Customer) {})
+	untouched := create(t, repo, func(*model.Customer) {})
+	firstRecord, err := repo.Erase(ctx, strconv.Itoa(first.ID), model.ErasureModeAnonymise, "dpo")
+	require.NoError(t, err)
+	secondRecord, err := repo.Erase(ctx, strconv.Itoa(second.ID), model.ErasureModeHardDelete, "dpo")
+	require.NoError(t, err)
+	againRecord, err := repo.Erase(ctx, strconv.Itoa(first.ID), model.ErasureModeHardDelete, "dpo")
+	require.NoError(t, err)
+
+	// Act
+	firstLog, err := repo.ErasureLogForCustomer(ctx, first.ID)
+	require.NoError(t, err)
+	secondLog, err := repo.ErasureLogForCustomer(ctx, second.ID)
+	require.NoError(t, err)
+	untouchedLog, err := repo.ErasureLogForCustomer(ctx, untouched.ID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []*model.ErasureRecord{firstRecord, againRecord}, firstLog, "the entries of one customer in erasure order")
+	assert.Equal(t, []*model.ErasureRecord{secondRecord}, secondLog, "hard-deleted customers keep their entries")
+	assert.NotNil(t, untouchedLog)
+	assert.Empty(t, untouchedLog)
+}
+
+func testEraseForkingErasureLog(t *testing.T, store Store) {
+	// Arrange
+	ctx := context.Background()
+	repo := store.Repository
+	c := create(t, repo, func(*model.Customer) {})
+	// The next erasure extends the last entry, whose hash is already the
+	// previous hash of that entry
+	forked := strings.Repeat("f", 64)
+	store.AppendErasureEntry(t, model.ErasureRecord{
+		CustomerID:  c.ID,
+		Mode:        model.ErasureModeAnonymise,
+		RequestedBy: "another client",
+		ErasedAt:    time.Now().UTC().Truncate(time.Microsecond),
+		PrevHash:    forked,
+		Hash:        forked,
+	})
+
+	// Act
+	record, err := repo.Erase(ctx, strconv.Itoa(c.ID), model.ErasureModeAnonymise, "dpo")
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrConstraint)
+	assert.Nil(t, record)
+	assert.Equal(t, c, get(t, repo, c.ID), "the customer is unchanged")
+	log, err := repo.ErasureLog(ctx)
+	require.NoError(t, err)
+	assert.Len(t, log, 1, "nothing is appended")
+}
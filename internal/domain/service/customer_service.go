@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"time"
 
 	domainmodel "iohk-golang-backend/internal/domain/model"
 	"iohk-golang-backend/internal/domain/repository"
 	"iohk-golang-backend/internal/infra/mapper"
+	"iohk-golang-backend/internal/logging"
 )
 
 type CustomerService interface {
@@ -14,6 +16,9 @@ type CustomerService interface {
 	GetAllCustomers(ctx context.Context) ([]*domainmodel.Customer, error)
 	UpdateCustomer(ctx context.Context, id string, customer *domainmodel.Customer) (*domainmodel.Customer, error)
 	DeleteCustomer(ctx context.Context, id string) (bool, error)
+	ExportCustomerData(ctx context.Context, id string) (*domainmodel.CustomerDataExport, error)
+	EraseCustomer(ctx context.Context, id string, mode domainmodel.ErasureMode, requestedBy string) (*domainmodel.ErasureRecord, error)
+	VerifyErasureLog(ctx context.Context) (*domainmodel.ErasureLogVerification, error)
 }
 
 type customerService struct {
@@ -45,3 +50,45 @@ func (s *customerService) DeleteCustomer(ctx context.Context, id string) (bool,
 	err := s.repo.Delete(ctx, id)
 	return err == nil, err
 }
+
+// ExportCustomerData collects the customer record and any erasure log entries
+// that refer to it.
+func (s *customerService) ExportCustomerData(ctx context.Context, id string) (*domainmodel.CustomerDataExport, error) {
+	customer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.repo.ErasureLogForCustomer(ctx, customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &domainmodel.CustomerDataExport{
+		FormatVersion: 1,
+		ExportedAt:    time.Now().UTC(),
+		Customer:      mapper.DomainToCustomerExport(customer),
+		ErasureLog:    []domainmodel.ErasureRecordExport{},
+	}
+	for _, r := range records {
+		export.ErasureLog = append(export.ErasureLog, mapper.ErasureRecordToExport(r))
+	}
+	return export, nil
+}
+
+func (s *customerService) EraseCustomer(ctx context.Context, id string, mode domainmodel.ErasureMode, requestedBy string) (*domainmodel.ErasureRecord, error) {
+	record, err := s.repo.Erase(ctx, id, mode, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Customer erased",
+		"customerId", record.CustomerID, "mode", record.Mode, "erasureId", record.ID)
+	return record, nil
+}
+
+func (s *customerService) VerifyErasureLog(ctx context.Context) (*domainmodel.ErasureLogVerification, error) {
+	records, err := s.repo.ErasureLog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domainmodel.VerifyErasureLog(records), nil
+}
@@ -14,6 +14,7 @@ import (
 
 	graphModel "iohk-golang-backend/graph/model"
 	"iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/domain/repository"
 )
 
 type MockCustomerRepository struct {
@@ -51,6 +52,24 @@ func (m *MockCustomerRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockCustomerRepository) Erase(ctx context.Context, id string, mode model.ErasureMode, requestedBy string) (*model.ErasureRecord, error) {
+	args := m.Called(ctx, id, mode, requestedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ErasureRecord), args.Error(1)
+}
+
+func (m *MockCustomerRepository) ErasureLog(ctx context.Context) ([]*model.ErasureRecord, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*model.ErasureRecord), args.Error(1)
+}
+
+func (m *MockCustomerRepository) ErasureLogForCustomer(ctx context.Context, customerID int) ([]*model.ErasureRecord, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*model.ErasureRecord), args.Error(1)
+}
+
 func TestCreateCustomer(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -372,3 +391,144 @@ func TestDeleteCustomer(t *testing.T) {
 		})
 	}
 }
+
+func TestExportCustomerData(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(m *MockCustomerRepository)
+		expectedCustomer   model.CustomerExport
+		expectedErasureIDs []int
+		expectedError      error
+	}{
+		{
+			name: "Customer with erasure history",
+			mockBehavior: func(m *MockCustomerRepository) {
+				m.On("GetByID", mock.Anything, "1").Return(&model.Customer{
+					ID: 1, Name: "Erased", Surname: "Erased", Number: 1, Gender: model.GenderFemale,
+					Country: "UK", BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				}, nil)
+				m.On("ErasureLogForCustomer", mock.Anything, 1).Return([]*model.ErasureRecord{
+					{ID: 2, CustomerID: 1, Mode: model.ErasureModeAnonymise},
+				}, nil)
+			},
+			expectedCustomer: model.CustomerExport{
+				ID: 1, Name: "Erased", Surname: "Erased", Number: 1, Gender: model.GenderFemale,
+				Country: "UK", BirthDate: "1990-01-01",
+			},
+			expectedErasureIDs: []int{2},
+		},
+		{
+			name: "Customer without erasure history",
+			mockBehavior: func(m *MockCustomerRepository) {
+				m.On("GetByID", mock.Anything, "1").Return(&model.Customer{
+					ID: 1, Name: "Jane", Surname: "Doe", Number: 1, Gender: model.GenderFemale,
+					Country: "UK", BirthDate: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				}, nil)
+				m.On("ErasureLogForCustomer", mock.Anything, 1).Return([]*model.ErasureRecord{}, nil)
+			},
+			expectedCustomer: model.CustomerExport{
+				ID: 1, Name: "Jane", Surname: "Doe", Number: 1, Gender: model.GenderFemale,
+				Country: "UK", BirthDate: "1990-01-01",
+			},
+			expectedErasureIDs: []int{},
+		},
+		{
+			name: "Customer not found",
+			mockBehavior: func(m *MockCustomerRepository) {
+				m.On("GetByID", mock.Anything, "1").Return(nil, errors.New("customer not found"))
+			},
+			expectedError: errors.New("customer not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockCustomerRepository)
+			service := NewCustomerService(mockRepo)
+			tc.mockBehavior(mockRepo)
+
+			// Act
+			result, err := service.ExportCustomerData(context.Background(), "1")
+
+			// Assert
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.FormatVersion)
+				assert.Equal(t, tc.expectedCustomer, result.Customer)
+				ids := []int{}
+				for _, r := range result.ErasureLog {
+					ids = append(ids, r.ID)
+				}
+				assert.Equal(t, tc.expectedErasureIDs, ids)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEraseCustomer(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+	record := &model.ErasureRecord{ID: 1, CustomerID: 1, Mode: model.ErasureModeHardDelete, RequestedBy: "dpo"}
+	mockRepo.On("Erase", mock.Anything, "1", model.ErasureModeHardDelete, "dpo").Return(record, nil)
+
+	// Act
+	result, err := service.EraseCustomer(context.Background(), "1", model.ErasureModeHardDelete, "dpo")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, record, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVerifyErasureLog(t *testing.T) {
+	// Arrange
+	first := &model.ErasureRecord{ID: 1, CustomerID: 1, Mode: model.ErasureModeHardDelete, RequestedBy: "dpo", ErasedAt: time.Now()}
+	first.Hash = first.ComputeHash()
+	second := &model.ErasureRecord{ID: 2, CustomerID: 2, Mode: model.ErasureModeAnonymise, RequestedBy: "dpo", ErasedAt: time.Now(), PrevHash: first.Hash}
+	second.Hash = second.ComputeHash()
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+	mockRepo.On("ErasureLog", mock.Anything).Return([]*model.ErasureRecord{first, second}, nil)
+
+	// Act
+	result, err := service.VerifyErasureLog(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ErasureLogVerification{Valid: true, Entries: 2}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestErasureWithMemoryRepository(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	service := NewCustomerService(repository.NewMemoryCustomerRepository())
+	customer, err := service.CreateCustomer(ctx, &model.Customer{
+		Name: "Jane", Surname: "Doe", Number: 42, Gender: model.GenderFemale,
+		Country: "Ireland", Dependants: 2, BirthDate: time.Date(1990, 3, 4, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+
+	// Act
+	_, eraseErr := service.EraseCustomer(ctx, "1", model.ErasureModeAnonymise, "dpo")
+	export, exportErr := service.ExportCustomerData(ctx, "1")
+	verification, verifyErr := service.VerifyErasureLog(ctx)
+	_, missingErr := service.GetCustomer(ctx, "2")
+
+	// Assert
+	assert.NoError(t, eraseErr)
+	assert.NoError(t, exportErr)
+	assert.NoError(t, verifyErr)
+	assert.Equal(t, customer.ID, export.Customer.ID)
+	assert.Equal(t, model.AnonymisedName, export.Customer.Name)
+	assert.Equal(t, "1990-01-01", export.Customer.BirthDate)
+	assert.Len(t, export.ErasureLog, 1)
+	assert.Equal(t, &model.ErasureLogVerification{Valid: true, Entries: 1}, verification)
+	assert.ErrorIs(t, missingErr, repository.ErrNotFound)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type tracedCustomerService struct {
+	next CustomerService
+}
+
+// NewTracedCustomerService wraps next so that every call gets a span.
+// Customer IDs are recorded, customer data is not.
+func NewTracedCustomerService(next CustomerService) CustomerService {
+	return &tracedCustomerService{next: next}
+}
+
+func (s *tracedCustomerService) CreateCustomer(ctx context.Context, customer *domainmodel.Customer) (_ *domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.CreateCustomer")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateCustomer(ctx, customer)
+}
+
+func (s *tracedCustomerService) GetCustomer(ctx context.Context, id string) (_ *domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetCustomer", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetCustomer(ctx, id)
+}
+
+func (s *tracedCustomerService) GetAllCustomers(ctx context.Context) (_ []*domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.GetAllCustomers")
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetAllCustomers(ctx)
+}
+
+func (s *tracedCustomerService) UpdateCustomer(ctx context.Context, id string, customer *domainmodel.Customer) (_ *domainmodel.Customer, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.UpdateCustomer", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateCustomer(ctx, id, customer)
+}
+
+func (s *tracedCustomerService) DeleteCustomer(ctx context.Context, id string) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.DeleteCustomer", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteCustomer(ctx, id)
+}
+
+func (s *tracedCustomerService) ExportCustomerData(ctx context.Context, id string) (_ *domainmodel.CustomerDataExport, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.ExportCustomerData", attribute.String("customer.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ExportCustomerData(ctx, id)
+}
+
+func (s *tracedCustomerService) EraseCustomer(ctx context.Context, id string, mode domainmodel.ErasureMode, requestedBy string) (_ *domainmodel.ErasureRecord, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.EraseCustomer",
+		attribute.String("customer.id", id),
+		attribute.String("erasure.mode", string(mode)),
+	)
+	defer func() { tracing.End(span, err) }()
+	return s.next.EraseCustomer(ctx, id, mode, requestedBy)
+}
+
+func (s *tracedCustomerService) VerifyErasureLog(ctx context.Context) (_ *domainmodel.ErasureLogVerification, err error) {
+	ctx, span := tracing.Start(ctx, "CustomerService.VerifyErasureLog")
+	defer func() { tracing.End(span, err) }()
+	return s.next.VerifyErasureLog(ctx)
+}
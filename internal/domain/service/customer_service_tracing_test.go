@@ -0,0 +1,58 @@
+//go:build testcoverage
+// +build testcoverage
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"iohk-golang-backend/internal/domain/model"
+)
+
+func TestTracedCustomerService(t *testing.T) {
+	testCases := []struct {
+		name           string
+		repoErr        error
+		expectedStatus codes.Code
+	}{
+		{name: "Success", expectedStatus: codes.Unset},
+		{name: "Error", repoErr: errors.New("customer not found"), expectedStatus: codes.Error},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			recorder := tracetest.NewSpanRecorder()
+			previous := otel.GetTracerProvider()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			defer otel.SetTracerProvider(previous)
+
+			mockRepo := new(MockCustomerRepository)
+			mockRepo.On("GetByID", mock.Anything, "1").Return(&model.Customer{ID: 1}, tc.repoErr)
+			service := NewTracedCustomerService(NewCustomerService(mockRepo))
+
+			// Act
+			_, err := service.GetCustomer(context.Background(), "1")
+
+			// Assert
+			assert.Equal(t, tc.repoErr, err)
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "CustomerService.GetCustomer", spans[0].Name())
+			assert.Equal(t, tc.expectedStatus, spans[0].Status().Code)
+			assert.Contains(t, spans[0].Attributes(), attribute.String("customer.id", "1"))
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
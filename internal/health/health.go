@@ -0,0 +1,173 @@
+// Package health serves the liveness, readiness and diagnostics endpoints.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"time"
+
+	"iohk-golang-backend/internal/buildinfo"
+	"iohk-golang-backend/internal/logging"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Pinger is the database readiness pings, such as a *pgxpool.Pool.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingerFunc adapts a function, such as the PingContext method of a
+// *sql.DB, to a Pinger.
+type PingerFunc func(ctx context.Context) error
+
+// Ping calls f.
+func (f PingerFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+// statPool is a Pinger with connection pool figures for /debug/info, which
+// only a *pgxpool.Pool has.
+type statPool interface {
+	Stat() *pgxpool.Stat
+}
+
+// Handler serves /healthz, /readyz and /debug/info.
+type Handler struct {
+	database    Pinger
+	pingTimeout time.Duration
+	draining    func() bool
+	startedAt   time.Time
+}
+
+// NewHandler returns a Handler that pings database with pingTimeout for
+// readiness and reports not ready once draining returns true. database is nil
+// when customers are kept in memory.
+func NewHandler(database Pinger, pingTimeout time.Duration, draining func() bool) *Handler {
+	return &Handler{
+		database:    database,
+		pingTimeout: pingTimeout,
+		draining:    draining,
+		startedAt:   time.Now(),
+	}
+}
+
+// Register adds the probes, /healthz and /readyz, to mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", h.Liveness)
+	mux.HandleFunc("/readyz", h.Readiness)
+}
+
+// RegisterDiagnostics adds /debug/info to mux. It reveals the version and
+// the database load, so it belongs on the admin listener, not the public one.
+func (h *Handler) RegisterDiagnostics(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/info", h.Info)
+}
+
+type status struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Liveness reports that the process is up and serving HTTP. It does not
+// check dependencies, a database outage should not get the process restarted.
+func (h *Handler) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, status{Status: "ok"})
+}
+
+// Readiness reports whether the process should receive traffic: it is not
+// draining and the database, if there is one, answers a ping within the
+// timeout.
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.draining() {
+		writeJSON(w, http.StatusServiceUnavailable, status{Status: "draining"})
+		return
+	}
+
+	if h.database == nil {
+		writeJSON(w, http.StatusOK, status{Status: "ok"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), h.pingTimeout)
+	defer cancel()
+	if err := h.database.Ping(ctx); err != nil {
+		logging.FromContext(r.Context()).Warn("Readiness check failed", "error", err)
+		writeJSON(w, http.StatusServiceUnavailable, status{Status: "unavailable", Error: "database is unavailable"})
+		return
+	}
+	writeJSON(w, http.StatusOK, status{Status: "ok"})
+}
+
+// Info is the /debug/info response.
+type Info struct {
+	Version       string     `json:"version"`
+	Commit        string     `json:"commit"`
+	GoVersion     string     `json:"goVersion"`
+	StartedAt     time.Time  `json:"startedAt"`
+	Uptime        string     `json:"uptime"`
+	UptimeSeconds int64      `json:"uptimeSeconds"`
+	Database      *PoolStats `json:"database,omitempty"`
+}
+
+// PoolStats are the pgxpool.Stat figures.
+type PoolStats struct {
+	MaxConns                int32  `json:"maxConns"`
+	TotalConns              int32  `json:"totalConns"`
+	AcquiredConns           int32  `json:"acquiredConns"`
+	IdleConns               int32  `json:"idleConns"`
+	ConstructingConns       int32  `json:"constructingConns"`
+	AcquireCount            int64  `json:"acquireCount"`
+	AcquireDuration         string `json:"acquireDuration"`
+	EmptyAcquireCount       int64  `json:"emptyAcquireCount"`
+	CanceledAcquireCount    int64  `json:"canceledAcquireCount"`
+	NewConnsCount           int64  `json:"newConnsCount"`
+	MaxLifetimeDestroyCount int64  `json:"maxLifetimeDestroyCount"`
+	MaxIdleDestroyCount     int64  `json:"maxIdleDestroyCount"`
+}
+
+// Info reports the build, uptime and, for PostgreSQL, connection pool
+// figures.
+func (h *Handler) Info(w http.ResponseWriter, r *http.Request) {
+	uptime := time.Since(h.startedAt)
+	info := Info{
+		Version:       buildinfo.Version,
+		Commit:        buildinfo.Commit,
+		GoVersion:     runtime.Version(),
+		StartedAt:     h.startedAt.UTC(),
+		Uptime:        uptime.Round(time.Second).String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+	}
+	if pool, ok := h.database.(statPool); ok {
+		info.Database = poolStats(pool.Stat())
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func poolStats(stat *pgxpool.Stat) *PoolStats {
+	return &PoolStats{
+		MaxConns:                stat.MaxConns(),
+		TotalConns:              stat.TotalConns(),
+		AcquiredConns:           stat.AcquiredConns(),
+		IdleConns:               stat.IdleConns(),
+		ConstructingConns:       stat.ConstructingConns(),
+		AcquireCount:            stat.AcquireCount(),
+		AcquireDuration:         stat.AcquireDuration().String(),
+		EmptyAcquireCount:       stat.EmptyAcquireCount(),
+		CanceledAcquireCount:    stat.CanceledAcquireCount(),
+		NewConnsCount:           stat.NewConnsCount(),
+		MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Failed to write health response", "error", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Package customerfile reads and writes customers as JSON or CSV files, in
+// the shape of the customer data export.
+package customerfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/mapper"
+)
+
+// Format is the encoding of a customer file.
+type Format string
+
+const (
+	JSON Format = "json"
+	CSV  Format = "csv"
+)
+
+// header is the CSV header, named like the JSON fields.
+var header = []string{"id", "name", "surname", "number", "gender", "country", "dependants", "birthDate"}
+
+// ParseFormat returns the format named s, or the format of the extension of
+// path if s is empty. Anything but .csv is read as JSON.
+func ParseFormat(s, path string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case JSON:
+		return JSON, nil
+	case CSV:
+		return CSV, nil
+	case "":
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			return CSV, nil
+		}
+		return JSON, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected json or csv", s)
+	}
+}
+
+// Write writes customers to w. JSON is an array of customer exports, CSV has
+// one row per customer after a header.
+func Write(w io.Writer, format Format, customers []*domainmodel.Customer) error {
+	records := make([]domainmodel.CustomerExport, len(customers))
+	for i, c := range customers {
+		records[i] = mapper.DomainToCustomerExport(c)
+	}
+	if format == JSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{strconv.Itoa(r.ID), r.Name, r.Surname, strconv.Itoa(r.Number), string(r.Gender),
+			r.Country, strconv.Itoa(r.Dependants), r.BirthDate}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Read reads the customers written by Write. IDs are kept, so callers
+// creating the customers anew must ignore them. Only the shape of the
+// records is checked here, the values are validated when they are saved.
+func Read(r io.Reader, format Format) ([]*domainmodel.Customer, error) {
+	var records []domainmodel.CustomerExport
+	if format == JSON {
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&records); err != nil {
+			return nil, fmt.Errorf("invalid JSON customer file: %w", err)
+		}
+	} else {
+		var err error
+		if records, err = readCSV(r); err != nil {
+			return nil, err
+		}
+	}
+
+	customers := make([]*domainmodel.Customer, len(records))
+	for i, rec := range records {
+		c, err := toDomain(rec)
+		if err != nil {
+			return nil, fmt.Errorf("customer %d: %w", i+1, err)
+		}
+		customers[i] = c
+	}
+	return customers, nil
+}
+
+func readCSV(r io.Reader) ([]domainmodel.CustomerExport, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(header)
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV customer file: %w", err)
+	}
+	if len(rows) == 0 || strings.Join(rows[0], ",") != strings.Join(header, ",") {
+		return nil, errors.New("invalid CSV customer file: header must be " + strings.Join(header, ","))
+	}
+
+	records := make([]domainmodel.CustomerExport, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		rec := domainmodel.CustomerExport{
+			Name:      row[1],
+			Surname:   row[2],
+			Gender:    domainmodel.Gender(row[4]),
+			Country:   row[5],
+			BirthDate: row[7],
+		}
+		ints := []struct {
+			col   int
+			value *int
+		}{{0, &rec.ID}, {3, &rec.Number}, {6, &rec.Dependants}}
+		for _, n := range ints {
+			// New customers may leave the id empty
+			if n.col == 0 && row[0] == "" {
+				continue
+			}
+			v, err := strconv.Atoi(row[n.col])
+			if err != nil {
+				return nil, fmt.Errorf("customer %d: invalid %s %q", i+1, header[n.col], row[n.col])
+			}
+			*n.value = v
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func toDomain(rec domainmodel.CustomerExport) (*domainmodel.Customer, error) {
+	gender, err := ParseGender(string(rec.Gender))
+	if err != nil {
+		return nil, err
+	}
+	birthDate, err := ParseBirthDate(rec.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+	return &domainmodel.Customer{
+		ID:         rec.ID,
+		Name:       rec.Name,
+		Surname:    rec.Surname,
+		Number:     rec.Number,
+		Gender:     gender,
+		Country:    rec.Country,
+		Dependants: rec.Dependants,
+		BirthDate:  birthDate,
+	}, nil
+}
+
+// ParseGender accepts a gender in any case, such as Female as stored in the
+// database.
+func ParseGender(s string) (domainmodel.Gender, error) {
+	gender := domainmodel.Gender(strings.ToUpper(s))
+	if gender != domainmodel.GenderMale && gender != domainmodel.GenderFemale {
+		return "", fmt.Errorf("invalid gender %q, expected MALE or FEMALE", s)
+	}
+	return gender, nil
+}
+
+// ParseBirthDate parses a YYYY-MM-DD date.
+func ParseBirthDate(s string) (time.Time, error) {
+	birthDate, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid birthDate %q, expected YYYY-MM-DD", s)
+	}
+	return birthDate, nil
+}
@@ -0,0 +1,132 @@
+//go:build testcoverage
+// +build testcoverage
+
+package customerfile_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/customerfile"
+)
+
+var customers = []*domainmodel.Customer{
+	{ID: 1, Name: "Jack", Surname: "Front", Number: 123, Gender: domainmodel.GenderMale, Country: "USA", Dependants: 5, BirthDate: time.Date(1981, 10, 3, 0, 0, 0, 0, time.UTC)},
+	{ID: 2, Name: "Chun Li", Surname: "Suzuki, Jr.", Number: 987, Gender: domainmodel.GenderFemale, Country: "China", Dependants: 0, BirthDate: time.Date(2001, 11, 9, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []customerfile.Format{customerfile.JSON, customerfile.CSV} {
+		t.Run(string(format), func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+
+			// Act
+			require.NoError(t, customerfile.Write(&buf, format, customers))
+			read, err := customerfile.Read(&buf, format)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, customers, read)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  customerfile.Format
+		input   string
+		want    *domainmodel.Customer
+		wantErr string
+	}{
+		{
+			name:   "CSV without id and with a database gender",
+			format: customerfile.CSV,
+			input:  "id,name,surname,number,gender,country,dependants,birthDate\n,Jill,Human,654,Female,Spain,0,1983-06-02\n",
+			want:   &domainmodel.Customer{Name: "Jill", Surname: "Human", Number: 654, Gender: domainmodel.GenderFemale, Country: "Spain", BirthDate: time.Date(1983, 6, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "CSV with another header",
+			format:  customerfile.CSV,
+			input:   "name,surname,number,gender,country,dependants,birthDate,id\n",
+			wantErr: "header must be id,name,surname,number,gender,country,dependants,birthDate",
+		},
+		{
+			name:    "CSV with an invalid number",
+			format:  customerfile.CSV,
+			input:   "id,name,surname,number,gender,country,dependants,birthDate\n,Jill,Human,x,Female,Spain,0,1983-06-02\n",
+			wantErr: `customer 1: invalid number "x"`,
+		},
+		{
+			name:    "JSON with an unknown field",
+			format:  customerfile.JSON,
+			input:   `[{"name": "Jill", "email": "jill@example.com"}]`,
+			wantErr: `unknown field "email"`,
+		},
+		{
+			name:    "JSON with an invalid gender",
+			format:  customerfile.JSON,
+			input:   `[{"name": "Jill", "gender": "OTHER", "birthDate": "1983-06-02"}]`,
+			wantErr: `customer 1: invalid gender "OTHER"`,
+		},
+		{
+			name:    "JSON with an invalid birth date",
+			format:  customerfile.JSON,
+			input:   `[{"name": "Jill", "gender": "FEMALE", "birthDate": "6/2/1983"}]`,
+			wantErr: `customer 1: invalid birthDate "6/2/1983"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			read, err := customerfile.Read(strings.NewReader(tt.input), tt.format)
+
+			// Assert
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []*domainmodel.Customer{tt.want}, read)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		path    string
+		want    customerfile.Format
+		wantErr bool
+	}{
+		{name: "Explicit format wins", format: "JSON", path: "customers.csv", want: customerfile.JSON},
+		{name: "CSV extension", path: "customers.CSV", want: customerfile.CSV},
+		{name: "Other extension", path: "customers.txt", want: customerfile.JSON},
+		{name: "Standard input", path: "-", want: customerfile.JSON},
+		{name: "Unknown format", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			format, err := customerfile.ParseFormat(tt.format, tt.path)
+
+			// Assert
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, format)
+		})
+	}
+}
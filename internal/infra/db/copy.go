@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	entcustomer "iohk-golang-backend/ent/customer"
+	_ "iohk-golang-backend/ent/runtime" // registers the field validators
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/piicrypto"
+)
+
+var copyColumns = []string{"name", "surname", "surname_bidx", "number", "gender", "country", "dependants", "birth_date"}
+
+// CopyCustomers bulk-loads customers with COPY, which is much faster than
+// creating them one by one through ent for large datasets. COPY bypasses the
+// ent hooks, so it runs the field validators and encrypts the personal data
+// and computes the surname blind index itself, with the default keyring. Customers
+// are streamed, so the sequence may be far larger than memory. It returns the
+// number of customers copied.
+func CopyCustomers(ctx context.Context, pool *pgxpool.Pool, customers iter.Seq[*domainmodel.Customer]) (int64, error) {
+	next, stop := iter.Pull(customers)
+	defer stop()
+	var n int
+	rows := pgx.CopyFromFunc(func() ([]any, error) {
+		c, ok := next()
+		if !ok {
+			return nil, nil
+		}
+		n++
+		row, err := copyRow(c)
+		if err != nil {
+			return nil, fmt.Errorf("customer %d: %w", n, err)
+		}
+		return row, nil
+	})
+	return pool.CopyFrom(ctx, pgx.Identifier{"customers"}, copyColumns, rows)
+}
+
+func copyRow(c *domainmodel.Customer) ([]any, error) {
+	gender := c.Gender.ToEntGender()
+	for _, err := range []error{
+		entcustomer.NameValidator(c.Name),
+		entcustomer.SurnameValidator(c.Surname),
+		entcustomer.NumberValidator(c.Number),
+		entcustomer.GenderValidator(gender),
+		entcustomer.CountryValidator(c.Country),
+		entcustomer.DependantsValidator(c.Dependants),
+		domainmodel.BirthDateValidator(c.BirthDate),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	name, err := piicrypto.EncryptedString{Column: entcustomer.FieldName}.Value(c.Name)
+	if err != nil {
+		return nil, err
+	}
+	surname, err := piicrypto.EncryptedString{Column: entcustomer.FieldSurname}.Value(c.Surname)
+	if err != nil {
+		return nil, err
+	}
+	birthDate, err := piicrypto.EncryptedDate{Column: entcustomer.FieldBirthDate}.Value(c.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+	return []any{
+		name,
+		surname, piicrypto.BlindIndex(c.Surname),
+		c.Number, string(gender), c.Country, c.Dependants, birthDate,
+	}, nil
+}
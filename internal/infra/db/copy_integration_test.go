@@ -0,0 +1,103 @@
+//go:build integration && testcoverage
+// +build integration,testcoverage
+
+package db_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"slices"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/ent/migrate/migrations"
+	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/customergen"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/domain/repository"
+	"iohk-golang-backend/internal/infra/db"
+	"iohk-golang-backend/internal/infra/piicrypto"
+)
+
+func TestCopyCustomersIntegration(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pgContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(t, err)
+	defer func() {
+		if err := pgContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+	dsn, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	pool, err := db.NewDBPool(ctx, &config.Config{
+		DatabaseURL:         dsn,
+		DBMaxConns:          5,
+		DBMinConns:          1,
+		DBMaxConnLifetime:   time.Hour,
+		DBMaxConnIdleTime:   time.Minute * 30,
+		DBHealthCheckPeriod: time.Minute,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+	loaded, err := db.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	_, err = db.NewMigrator(pool, loaded).Up(ctx, 0)
+	require.NoError(t, err)
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	keyring, err := piicrypto.NewKeyring("1:"+key, 0, key)
+	require.NoError(t, err)
+	piicrypto.SetDefault(keyring)
+	defer piicrypto.SetDefault(nil)
+	generator, err := customergen.New(customergen.Options{Seed: 3})
+	require.NoError(t, err)
+	expected, err := customergen.New(customergen.Options{Seed: 3})
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, stdlib.OpenDBFromPool(pool))))
+	defer client.Close()
+	repo := repository.NewCustomerRepository(client)
+
+	// Act
+	copied, err := db.CopyCustomers(ctx, pool, generator.All(500))
+	require.NoError(t, err)
+	stored, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	slices.SortFunc(stored, func(a, b *domainmodel.Customer) int { return a.ID - b.ID })
+	want := slices.Collect(expected.All(500))
+	bySurname, err := client.Customer.Query().
+		Where(customer.SurnameBidx(piicrypto.BlindIndex(want[0].Surname))).
+		Count(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.EqualValues(t, 500, copied)
+	require.Len(t, stored, 500)
+	for i, c := range stored {
+		want[i].ID = c.ID
+		assert.Equal(t, want[i], c)
+	}
+	assert.NotZero(t, bySurname, "blind indexes are computed")
+}
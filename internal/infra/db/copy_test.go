@@ -0,0 +1,71 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainmodel "iohk-golang-backend/internal/domain/model"
+	"iohk-golang-backend/internal/infra/piicrypto"
+)
+
+func TestCopyRow(t *testing.T) {
+	valid := domainmodel.Customer{
+		Name: "Jill", Surname: "Human", Number: 654, Gender: domainmodel.GenderFemale,
+		Country: "Spain", Dependants: 1, BirthDate: time.Date(1983, 6, 2, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name    string
+		change  func(c *domainmodel.Customer)
+		wantErr string
+	}{
+		{name: "Valid customer", change: func(*domainmodel.Customer) {}},
+		{name: "Empty name", change: func(c *domainmodel.Customer) { c.Name = "" }, wantErr: "value is less than the required length"},
+		{name: "Unknown gender", change: func(c *domainmodel.Customer) { c.Gender = "OTHER" }, wantErr: "invalid enum value"},
+		{name: "Negative dependants", change: func(c *domainmodel.Customer) { c.Dependants = -1 }, wantErr: "value out of range"},
+		{name: "Future birth date", change: func(c *domainmodel.Customer) { c.BirthDate = time.Now().AddDate(1, 0, 0) }, wantErr: "birth date cannot be in the future"},
+	}
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	keyring, err := piicrypto.NewKeyring("1:"+key, 0, key)
+	require.NoError(t, err)
+	piicrypto.SetDefault(keyring)
+	defer piicrypto.SetDefault(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			c := valid
+			tt.change(&c)
+
+			// Act
+			row, err := copyRow(&c)
+
+			// Assert
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, row, len(copyColumns))
+			name, err := piicrypto.Decrypt(row[0].(string), "name")
+			require.NoError(t, err)
+			surname, err := piicrypto.Decrypt(row[1].(string), "surname")
+			require.NoError(t, err)
+			birthDate, err := piicrypto.Decrypt(row[7].(string), "birth_date")
+			require.NoError(t, err)
+			assert.Equal(t, "Jill", name)
+			assert.Equal(t, "Human", surname)
+			assert.Equal(t, piicrypto.BlindIndex("Human"), row[2])
+			assert.Equal(t, []any{654, "Female", "Spain", 1}, row[3:7])
+			assert.Equal(t, "1983-06-02", birthDate)
+		})
+	}
+}
@@ -3,23 +3,64 @@ package db
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/logging"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewDBPool(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.PostgresUser,
-		cfg.PostgresPassword,
-		cfg.PostgresHost,
-		cfg.PostgresPort,
-		cfg.PostgresDB,
-		cfg.PostgresSSLMode,
+// NewDBPool connects a pool to the configured database. Tracers, if any, are
+// attached to every connection of the pool.
+func NewDBPool(ctx context.Context, cfg *config.Config, tracers ...pgx.QueryTracer) (*pgxpool.Pool, error) {
+	dsn, err := BuildDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := newPool(ctx, cfg, dsn, tracers)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("unable to ping database: %w", err)
+	}
+	logging.FromContext(ctx).Info("Created database connection pool",
+		"host", pool.Config().ConnConfig.Host,
+		"database", pool.Config().ConnConfig.Database,
+		"maxConns", cfg.DBMaxConns,
+	)
+	return pool, nil
+}
+
+// NewReplicaPool creates a pool for the read replica in REPLICA_DATABASE_URL,
+// sized like the primary's. It returns nil if no replica is configured.
+// Unlike NewDBPool it does not ping, an unavailable replica only means reads
+// go to the primary until a Router health check finds it available.
+func NewReplicaPool(ctx context.Context, cfg *config.Config, tracers ...pgx.QueryTracer) (*pgxpool.Pool, error) {
+	if cfg.ReplicaDatabaseURL == "" {
+		return nil, nil
+	}
+	dsn, err := BuildReplicaDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := newPool(ctx, cfg, dsn, tracers)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Created read replica connection pool",
+		"host", pool.Config().ConnConfig.Host,
+		"database", pool.Config().ConnConfig.Database,
+		"maxConns", cfg.DBMaxConns,
 	)
+	return pool, nil
+}
 
+func newPool(ctx context.Context, cfg *config.Config, dsn string, tracers []pgx.QueryTracer) (*pgxpool.Pool, error) {
 	poolConfig, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection string: %w", err)
@@ -30,24 +71,20 @@ func NewDBPool(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
 	poolConfig.MaxConnLifetime = cfg.DBMaxConnLifetime
 	poolConfig.MaxConnIdleTime = cfg.DBMaxConnIdleTime
 	poolConfig.HealthCheckPeriod = cfg.DBHealthCheckPeriod
+	if len(tracers) > 0 {
+		poolConfig.ConnConfig.Tracer = multitracer.New(tracers...)
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
-
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("unable to ping database: %w", err)
-	}
-	log.Println("Successfully created connection pool to the database")
-
 	return pool, nil
 }
 
 func CloseDBPool(pool *pgxpool.Pool) {
 	if pool != nil {
 		pool.Close()
-		log.Println("Database connection pool closed")
+		slog.Info("Closed database connection pool")
 	}
 }
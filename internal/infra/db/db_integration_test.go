@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"iohk-golang-backend/ent/migrate/migrations"
 	"iohk-golang-backend/internal/config"
 	"iohk-golang-backend/internal/domain/model"
 	"iohk-golang-backend/internal/infra/db"
@@ -77,25 +78,18 @@ func TestDatabaseIntegration(t *testing.T) {
 	defer pool.Close()
 
 	// Run the tests
-	t.Run("CreateSchema", testCreateSchema(ctx, pool))
+	t.Run("MigrateSchema", testMigrateSchema(ctx, pool))
 	t.Run("InsertCustomer", testInsertCustomer(ctx, pool))
 	t.Run("GetCustomer", testGetCustomer(ctx, pool))
 }
 
-func testCreateSchema(ctx context.Context, pool *pgxpool.Pool) func(*testing.T) {
+// testMigrateSchema creates the schema the application runs on, rather than
+// a copy of it that could drift apart.
+func testMigrateSchema(ctx context.Context, pool *pgxpool.Pool) func(*testing.T) {
 	return func(t *testing.T) {
-		_, err := pool.Exec(ctx, `
-			CREATE TABLE IF NOT EXISTS customers (
-				id SERIAL PRIMARY KEY,
-				name VARCHAR(255) NOT NULL,
-				surname VARCHAR(255) NOT NULL,
-				number INTEGER NOT NULL,
-				gender VARCHAR(10) CHECK (gender IN ('Male', 'Female')),
-				country VARCHAR(255),
-				dependants INTEGER CHECK (dependants >= 0),
-				birth_date DATE CHECK (birth_date <= CURRENT_DATE)
-			)
-		`)
+		loaded, err := db.LoadMigrations(migrations.FS)
+		require.NoError(t, err)
+		_, err = db.NewMigrator(pool, loaded).Up(ctx, 0)
 		assert.NoError(t, err)
 	}
 }
@@ -117,7 +111,7 @@ func testInsertCustomer(ctx context.Context, pool *pgxpool.Pool) func(*testing.T
 			INSERT INTO customers (name, surname, number, gender, country, dependants, birth_date)
 			VALUES ($1, $2, $3, $4, $5, $6, $7)
 		`, customer.Name, customer.Surname, customer.Number, customer.Gender,
-			customer.Country, customer.Dependants, customer.BirthDate)
+			customer.Country, customer.Dependants, customer.BirthDate.Format(time.DateOnly))
 		assert.NoError(t, err)
 	}
 }
@@ -125,13 +119,17 @@ func testInsertCustomer(ctx context.Context, pool *pgxpool.Pool) func(*testing.T
 func testGetCustomer(ctx context.Context, pool *pgxpool.Pool) func(*testing.T) {
 	return func(t *testing.T) {
 		var customer TestCustomer
+		var birthDate string
 		err := pool.QueryRow(ctx, `
 			SELECT id, name, surname, number, gender, country, dependants, birth_date
 			FROM customers
 			WHERE name = $1 AND surname = $2
 		`, "John", "Doe").Scan(
 			&customer.ID, &customer.Name, &customer.Surname, &customer.Number,
-			&customer.Gender, &customer.Country, &customer.Dependants, &customer.BirthDate)
+			&customer.Gender, &customer.Country, &customer.Dependants, &birthDate)
+		assert.NoError(t, err)
+		// birth_date is text, so it can hold ciphertext when PII is encrypted
+		customer.BirthDate, err = time.Parse(time.DateOnly, birthDate)
 		assert.NoError(t, err)
 
 		assert.Equal(t, "John", customer.Name)
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// DriftKind names a difference between the ent schema and the database.
+type DriftKind string
+
+const (
+	MissingTable  DriftKind = "missing_table"
+	MissingColumn DriftKind = "missing_column"
+	ExtraColumn   DriftKind = "extra_column"
+	TypeMismatch  DriftKind = "type_mismatch"
+	SizeMismatch  DriftKind = "size_mismatch"
+	NullMismatch  DriftKind = "null_mismatch"
+	MissingCheck  DriftKind = "missing_check"
+	MissingIndex  DriftKind = "missing_index"
+	IndexMismatch DriftKind = "index_mismatch"
+)
+
+// Drift is one difference between the ent schema and the database. Object is
+// the column, index or check it concerns, and is empty for a missing table.
+type Drift struct {
+	Kind     DriftKind `json:"kind"`
+	Table    string    `json:"table"`
+	Object   string    `json:"object,omitempty"`
+	Expected string    `json:"expected,omitempty"`
+	Actual   string    `json:"actual,omitempty"`
+}
+
+// DetectDrift compares the tables of the database behind pool with the ent
+// schema in tables, as the migrations generated from it would create them.
+// Extra tables, indexes and checks are not reported, since migrations may add
+// them by hand.
+func DetectDrift(ctx context.Context, pool *pgxpool.Pool, tables []*schema.Table) ([]Drift, error) {
+	sqlDB := stdlib.OpenDBFromPool(pool)
+	defer sqlDB.Close()
+
+	differ, err := schema.NewMigrate(entsql.OpenDB(dialect.Postgres, sqlDB))
+	if err != nil {
+		return nil, err
+	}
+	realm, err := differ.StateReader(tables...).ReadState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading ent schema: %w", err)
+	}
+	desired := realm.Schemas[0]
+
+	names := make([]string, len(desired.Tables))
+	for i, t := range desired.Tables {
+		names[i] = t.Name
+	}
+	inspector, err := postgres.Open(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := inspector.InspectSchema(ctx, "", &atlas.InspectOptions{Tables: names})
+	if err != nil {
+		return nil, fmt.Errorf("inspecting database schema: %w", err)
+	}
+	return diffSchemas(desired, actual), nil
+}
+
+// diffSchemas reports how actual differs from desired, table by table in the
+// order of desired.
+func diffSchemas(desired, actual *atlas.Schema) []Drift {
+	var drift []Drift
+	for _, want := range desired.Tables {
+		got, ok := actual.Table(want.Name)
+		if !ok {
+			drift = append(drift, Drift{Kind: MissingTable, Table: want.Name})
+			continue
+		}
+		drift = append(drift, diffColumns(want, got)...)
+		drift = append(drift, diffChecks(want, got)...)
+		drift = append(drift, diffIndexes(want, got)...)
+	}
+	return drift
+}
+
+func diffColumns(want, got *atlas.Table) []Drift {
+	var drift []Drift
+	for _, wc := range want.Columns {
+		gc, ok := got.Column(wc.Name)
+		if !ok {
+			drift = append(drift, Drift{Kind: MissingColumn, Table: want.Name, Object: wc.Name, Expected: formatType(wc.Type)})
+			continue
+		}
+		if wt, gt := formatType(wc.Type), formatType(gc.Type); wt != gt {
+			kind := TypeMismatch
+			if baseType(wt) == baseType(gt) {
+				kind = SizeMismatch
+			}
+			drift = append(drift, Drift{Kind: kind, Table: want.Name, Object: wc.Name, Expected: wt, Actual: gt})
+		}
+		if wc.Type.Null != gc.Type.Null {
+			drift = append(drift, Drift{Kind: NullMismatch, Table: want.Name, Object: wc.Name, Expected: nullability(wc.Type), Actual: nullability(gc.Type)})
+		}
+	}
+	for _, gc := range got.Columns {
+		if _, ok := want.Column(gc.Name); !ok {
+			drift = append(drift, Drift{Kind: ExtraColumn, Table: want.Name, Object: gc.Name, Actual: formatType(gc.Type)})
+		}
+	}
+	return drift
+}
+
+// diffChecks matches checks by name, since Postgres rewrites their
+// expressions when storing them.
+func diffChecks(want, got *atlas.Table) []Drift {
+	var drift []Drift
+	for _, wc := range checks(want) {
+		if !slices.ContainsFunc(checks(got), func(gc *atlas.Check) bool { return gc.Name == wc.Name }) {
+			drift = append(drift, Drift{Kind: MissingCheck, Table: want.Name, Object: wc.Name, Expected: wc.Expr})
+		}
+	}
+	return drift
+}
+
+// diffIndexes matches indexes by name. An index with another name but the
+// same columns and uniqueness counts as present.
+func diffIndexes(want, got *atlas.Table) []Drift {
+	var drift []Drift
+	for _, wi := range want.Indexes {
+		if gi, ok := got.Index(wi.Name); ok {
+			if indexDef(wi) != indexDef(gi) {
+				drift = append(drift, Drift{Kind: IndexMismatch, Table: want.Name, Object: wi.Name, Expected: indexDef(wi), Actual: indexDef(gi)})
+			}
+			continue
+		}
+		if !slices.ContainsFunc(got.Indexes, func(gi *atlas.Index) bool { return indexDef(gi) == indexDef(wi) }) {
+			drift = append(drift, Drift{Kind: MissingIndex, Table: want.Name, Object: wi.Name, Expected: indexDef(wi)})
+		}
+	}
+	return drift
+}
+
+func checks(t *atlas.Table) []*atlas.Check {
+	var checks []*atlas.Check
+	for _, attr := range t.Attrs {
+		if c, ok := attr.(*atlas.Check); ok {
+			checks = append(checks, c)
+		}
+	}
+	return checks
+}
+
+// formatType returns the canonical Postgres spelling of t, such as
+// "character varying(15)" for VARCHAR(15).
+func formatType(t *atlas.ColumnType) string {
+	if f, err := postgres.FormatType(t.Type); err == nil {
+		return f
+	}
+	return t.Raw
+}
+
+// baseType strips the size or precision from a formatted type.
+func baseType(t string) string {
+	base, _, _ := strings.Cut(t, "(")
+	return base
+}
+
+func nullability(t *atlas.ColumnType) string {
+	if t.Null {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// indexDef describes an index as "UNIQUE (a, b)" or "(a, b)".
+func indexDef(idx *atlas.Index) string {
+	parts := make([]string, len(idx.Parts))
+	for i, p := range idx.Parts {
+		if p.C != nil {
+			parts[i] = p.C.Name
+		} else if x, ok := p.X.(*atlas.RawExpr); ok {
+			parts[i] = x.X
+		}
+	}
+	def := "(" + strings.Join(parts, ", ") + ")"
+	if idx.Unique {
+		def = "UNIQUE " + def
+	}
+	return def
+}
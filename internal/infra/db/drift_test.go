@@ -0,0 +1,126 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// customersTable builds a customers table like the init migration creates,
+// which change then alters.
+func customersTable(change func(t *atlas.Table)) *atlas.Schema {
+	t := atlas.NewTable("customers").AddColumns(
+		atlas.NewIntColumn("id", postgres.TypeBigInt),
+		atlas.NewStringColumn("gender", postgres.TypeVarChar),
+		atlas.NewNullStringColumn("name_bidx", postgres.TypeVarChar),
+		atlas.NewIntColumn("dependants", postgres.TypeBigInt),
+	)
+	t.AddIndexes(atlas.NewIndex("customer_name_bidx").AddColumns(t.Columns[2]))
+	t.AddChecks(atlas.NewCheck().SetName("customers_gender_check").SetExpr("gender IN ('Male', 'Female')"))
+	if change != nil {
+		change(t)
+	}
+	return atlas.New("public").AddTables(t)
+}
+
+func TestDiffSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *atlas.Table)
+		want   []Drift
+	}{
+		{
+			name: "No drift",
+		},
+		{
+			name: "Missing table",
+			change: func(t *atlas.Table) {
+				t.Name = "customers_test"
+			},
+			want: []Drift{{Kind: MissingTable, Table: "customers"}},
+		},
+		{
+			name: "Missing and extra column",
+			change: func(t *atlas.Table) {
+				t.Columns[3].Name = "children"
+			},
+			want: []Drift{
+				{Kind: MissingColumn, Table: "customers", Object: "dependants", Expected: "bigint"},
+				{Kind: ExtraColumn, Table: "customers", Object: "children", Actual: "bigint"},
+			},
+		},
+		{
+			name: "Size mismatch",
+			change: func(t *atlas.Table) {
+				t.Columns[1].Type.Type = &atlas.StringType{T: postgres.TypeVarChar, Size: 15}
+			},
+			want: []Drift{{Kind: SizeMismatch, Table: "customers", Object: "gender", Expected: "character varying", Actual: "character varying(15)"}},
+		},
+		{
+			name: "Type mismatch",
+			change: func(t *atlas.Table) {
+				t.Columns[3].Type.Type = &atlas.IntegerType{T: postgres.TypeInteger}
+			},
+			want: []Drift{{Kind: TypeMismatch, Table: "customers", Object: "dependants", Expected: "bigint", Actual: "integer"}},
+		},
+		{
+			name: "Nullability mismatch",
+			change: func(t *atlas.Table) {
+				t.Columns[1].Type.Null = true
+			},
+			want: []Drift{{Kind: NullMismatch, Table: "customers", Object: "gender", Expected: "NOT NULL", Actual: "NULL"}},
+		},
+		{
+			name: "Missing check",
+			change: func(t *atlas.Table) {
+				t.Attrs = nil
+			},
+			want: []Drift{{Kind: MissingCheck, Table: "customers", Object: "customers_gender_check", Expected: "gender IN ('Male', 'Female')"}},
+		},
+		{
+			name: "Check with another expression spelling",
+			change: func(t *atlas.Table) {
+				t.Attrs = []atlas.Attr{atlas.NewCheck().SetName("customers_gender_check").SetExpr("((gender)::text = ANY (ARRAY['Male', 'Female']))")}
+			},
+		},
+		{
+			name: "Missing index",
+			change: func(t *atlas.Table) {
+				t.Indexes = nil
+			},
+			want: []Drift{{Kind: MissingIndex, Table: "customers", Object: "customer_name_bidx", Expected: "(name_bidx)"}},
+		},
+		{
+			name: "Renamed index",
+			change: func(t *atlas.Table) {
+				t.Indexes[0].Name = "customers_name_bidx_idx"
+			},
+		},
+		{
+			name: "Index mismatch",
+			change: func(t *atlas.Table) {
+				t.Indexes[0].Unique = true
+			},
+			want: []Drift{{Kind: IndexMismatch, Table: "customers", Object: "customer_name_bidx", Expected: "(name_bidx)", Actual: "UNIQUE (name_bidx)"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			desired := customersTable(nil)
+			actual := customersTable(tt.change)
+
+			// Act
+			drift := diffSchemas(desired, actual)
+
+			// Assert
+			assert.Equal(t, tt.want, drift)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"iohk-golang-backend/internal/config"
+)
+
+// BuildDSN returns the connection URL for cfg. It starts from DATABASE_URL
+// if that is set, and otherwise from the individual POSTGRES_* fields, which
+// are escaped so passwords may contain characters such as "@" or "/". A host
+// starting with "/" is a unix socket directory. The optional connection
+// parameters are added unless the URL already sets them.
+func BuildDSN(cfg *config.Config) (string, error) {
+	var u *url.URL
+	if cfg.DatabaseURL != "" {
+		parsed, err := parseURL(cfg.DatabaseURL, "DATABASE_URL")
+		if err != nil {
+			return "", err
+		}
+		u = parsed
+	} else {
+		u = fieldsURL(cfg)
+	}
+	setParams(u, cfg, cfg.PostgresTargetSessionAttrs)
+	return u.String(), nil
+}
+
+// BuildReplicaDSN returns the connection URL of the read replica from
+// REPLICA_DATABASE_URL. The optional connection parameters are added as for
+// the primary, except POSTGRES_TARGET_SESSION_ATTRS, which usually asks for
+// a read-write server and would reject a replica.
+func BuildReplicaDSN(cfg *config.Config) (string, error) {
+	u, err := parseURL(cfg.ReplicaDatabaseURL, "REPLICA_DATABASE_URL")
+	if err != nil {
+		return "", err
+	}
+	setParams(u, cfg, "")
+	return u.String(), nil
+}
+
+// parseURL parses the postgres URL set as the variable name.
+func parseURL(raw, name string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		// Do not wrap err, it would include the password
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("invalid %s: scheme must be postgres or postgresql", name)
+	}
+	return u, nil
+}
+
+func setParams(u *url.URL, cfg *config.Config, targetSessionAttrs string) {
+	query := u.Query()
+	setDefault := func(key, value string) {
+		if value != "" && !query.Has(key) {
+			query.Set(key, value)
+		}
+	}
+	setDefault("sslmode", cfg.PostgresSSLMode)
+	setDefault("application_name", cfg.PostgresApplicationName)
+	setDefault("search_path", cfg.PostgresSearchPath)
+	setDefault("target_session_attrs", targetSessionAttrs)
+	if cfg.PostgresStatementTimeout > 0 {
+		setDefault("statement_timeout", strconv.FormatInt(cfg.PostgresStatementTimeout.Milliseconds(), 10))
+	}
+	if cfg.PostgresConnectTimeout > 0 {
+		setDefault("connect_timeout", strconv.Itoa(int((cfg.PostgresConnectTimeout+time.Second-1)/time.Second)))
+	}
+	u.RawQuery = query.Encode()
+}
+
+func fieldsURL(cfg *config.Config) *url.URL {
+	u := &url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(cfg.PostgresUser, cfg.PostgresPassword),
+		Path:   "/" + cfg.PostgresDB,
+	}
+	if strings.HasPrefix(cfg.PostgresHost, "/") {
+		// libpq style: the socket directory and port go into the query
+		query := url.Values{"host": {cfg.PostgresHost}}
+		if cfg.PostgresPort != "" {
+			query.Set("port", cfg.PostgresPort)
+		}
+		u.RawQuery = query.Encode()
+		return u
+	}
+	u.Host = cfg.PostgresHost
+	if cfg.PostgresPort != "" {
+		u.Host = net.JoinHostPort(cfg.PostgresHost, cfg.PostgresPort)
+	}
+	return u
+}
@@ -0,0 +1,527 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	entmigrate "iohk-golang-backend/ent/migrate"
+)
+
+// MigrationsDir is where `migrate new` writes migrations, relative to the
+// repository root.
+const MigrationsDir = "ent/migrate/migrations"
+
+// revisionsTable records the applied migrations.
+const revisionsTable = "schema_revisions"
+
+// migrationLockID is the advisory lock held while migrating, so two
+// processes never apply the same migration.
+const migrationLockID = 0x6d696772617465
+
+// Migration is one versioned schema change, read from a pair of
+// <version>_<name>.up.sql and .down.sql files.
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a migration and whether it was applied.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// ErrNoDownMigration is returned when rolling back a migration without a
+// .down.sql file.
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// ErrUnversionedSchema is returned when migrating a database whose tables
+// were created without migrations, such as by the former scripts/init.sql.
+// UpgradeInitSQL converts such a database, Baseline records the migrations a
+// matching schema already has.
+var ErrUnversionedSchema = errors.New("database has tables but no recorded migrations, convert a scripts/init.sql schema with migrate upgrade or record a matching one with migrate baseline VERSION")
+
+// ErrSchemaMismatch is returned when baselining a database whose tables
+// differ from those the baselined migrations create.
+var ErrSchemaMismatch = errors.New("database schema differs from the migrations")
+
+// ErrVersionedSchema is returned when upgrading a database that already
+// records its migrations, or has no tables to upgrade.
+var ErrVersionedSchema = errors.New("database has no unversioned schema to upgrade")
+
+// upgradeInitSQL converts a schema created by the former scripts/init.sql to
+// the one of the first migration.
+//
+//go:embed upgrade_init_sql.sql
+var upgradeInitSQL string
+
+// ErrUnknownMigration is returned when baselining a version without a
+// migration.
+var ErrUnknownMigration = errors.New("unknown migration version")
+
+// LoadMigrations reads the migrations in fsys, ordered by version. It
+// refuses a directory whose files do not match its atlas.sum, which catches
+// migrations edited after they were generated.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	dir := &migrate.MemDir{}
+	for _, name := range append(names, migrate.HashFileName) {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := dir.WriteFile(name, b); err != nil {
+			return nil, err
+		}
+	}
+	if err := migrate.Validate(dir); err != nil {
+		return nil, fmt.Errorf("invalid migration directory: %w", err)
+	}
+
+	byVersion := make(map[string]*Migration)
+	for _, name := range names {
+		base, up := strings.CutSuffix(name, ".up.sql")
+		if !up {
+			continue
+		}
+		version, desc, _ := strings.Cut(base, "_")
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		m := &Migration{Version: version, Name: desc, Up: string(b)}
+		if down, err := fs.ReadFile(fsys, base+".down.sql"); err == nil {
+			m.Down = string(down)
+		}
+		if _, dup := byVersion[version]; dup {
+			return nil, fmt.Errorf("invalid migration directory: version %s is used twice", version)
+		}
+		byVersion[version] = m
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies and rolls back migrations on a Postgres database.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// NewMigrator returns a Migrator applying migrations to pool.
+func NewMigrator(pool *pgxpool.Pool, migrations []Migration) *Migrator {
+	return &Migrator{pool: pool, migrations: migrations}
+}
+
+// Status reports every known migration and whether it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx, m.pool)
+	if err != nil {
+		return nil, err
+	}
+	status := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		at, ok := applied[migration.Version]
+		status[i] = MigrationStatus{Migration: migration, Applied: ok, AppliedAt: at}
+	}
+	return status, nil
+}
+
+// Pending returns the migrations that were not applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range status {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies up to n pending migrations in version order, or all of them if
+// n is 0, and returns those it applied. Each migration runs in its own
+// transaction together with its revision record.
+func (m *Migrator) Up(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if unversioned, err := m.unversioned(ctx, conn, applied); err != nil {
+			return err
+		} else if unversioned {
+			return ErrUnversionedSchema
+		}
+		for _, migration := range m.migrations {
+			if n > 0 && len(done) == n {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "INSERT INTO "+revisionsTable+" (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("applying migration %s_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline records every migration up to and including version as applied
+// without running it, and returns those it recorded. It is for databases
+// whose schema was created before migrations were introduced, which Up
+// refuses with ErrUnversionedSchema. Later migrations stay pending. A
+// database whose tables differ from those the recorded migrations create is
+// refused with ErrSchemaMismatch, since recording them would hide the
+// difference.
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]Migration, error) {
+	if !slices.ContainsFunc(m.migrations, func(migration Migration) bool { return migration.Version == version }) {
+		return nil, fmt.Errorf("%w %s", ErrUnknownMigration, version)
+	}
+	drift, err := m.versionDrift(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(drift) > 0 {
+		return nil, fmt.Errorf("%w: %d differences", ErrSchemaMismatch, len(drift))
+	}
+	var done []Migration
+	err = m.locked(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+				if _, ok := applied[migration.Version]; ok {
+					continue
+				}
+				_, err := tx.Exec(ctx, "INSERT INTO "+revisionsTable+" (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+				if err != nil {
+					return fmt.Errorf("recording migration %s_%s: %w", migration.Version, migration.Name, err)
+				}
+				done = append(done, migration)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return done, nil
+}
+
+// baselineSchema is the scratch schema versionDrift replays migrations in.
+const baselineSchema = "schema_baseline_check"
+
+// versionDrift compares the tables of the database with those the
+// migrations up to and including version create. The migrations are
+// replayed in a scratch schema, in a transaction that is rolled back.
+func (m *Migrator) versionDrift(ctx context.Context, version string) ([]Drift, error) {
+	sqlDB := stdlib.OpenDBFromPool(m.pool)
+	defer sqlDB.Close()
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing is kept, the scratch schema is only inspected
+	defer func() { _ = tx.Rollback() }()
+
+	var current string
+	if err := tx.QueryRowContext(ctx, "SELECT current_schema()").Scan(&current); err != nil {
+		return nil, fmt.Errorf("reading current schema: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "CREATE SCHEMA "+baselineSchema); err != nil {
+		return nil, fmt.Errorf("creating scratch schema: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "SET LOCAL search_path TO "+baselineSchema); err != nil {
+		return nil, fmt.Errorf("selecting scratch schema: %w", err)
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return nil, fmt.Errorf("replaying migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	inspector, err := postgres.Open(tx)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := inspector.InspectSchema(ctx, baselineSchema, nil)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting migrated schema: %w", err)
+	}
+	names := make([]string, len(desired.Tables))
+	for i, t := range desired.Tables {
+		names[i] = t.Name
+	}
+	actual, err := inspector.InspectSchema(ctx, current, &atlas.InspectOptions{Tables: names})
+	if err != nil {
+		return nil, fmt.Errorf("inspecting database schema: %w", err)
+	}
+	return diffSchemas(desired, actual), nil
+}
+
+// UpgradeInitSQL converts the unversioned schema of a database created by
+// the former scripts/init.sql to the one of the first migration, in one
+// transaction, and baselines that migration. It refuses with
+// ErrVersionedSchema when there is nothing to upgrade. Later migrations stay
+// pending, including the one adding the erasure log trigger.
+func (m *Migrator) UpgradeInitSQL(ctx context.Context) ([]Migration, error) {
+	if len(m.migrations) == 0 {
+		return nil, ErrVersionedSchema
+	}
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if unversioned, err := m.unversioned(ctx, conn, applied); err != nil {
+			return err
+		} else if !unversioned {
+			return ErrVersionedSchema
+		}
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, upgradeInitSQL); err != nil {
+				return fmt.Errorf("upgrading scripts/init.sql schema: %w", err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.Baseline(ctx, m.migrations[0].Version)
+}
+
+// Unversioned reports whether the database has tables of the ent schema but
+// no recorded migrations, so it needs Baseline before Up.
+func (m *Migrator) Unversioned(ctx context.Context) (bool, error) {
+	applied, err := m.applied(ctx, m.pool)
+	if err != nil {
+		return false, err
+	}
+	return m.unversioned(ctx, m.pool, applied)
+}
+
+func (m *Migrator) unversioned(ctx context.Context, q querier, applied map[string]time.Time) (bool, error) {
+	if len(applied) > 0 {
+		return false, nil
+	}
+	for _, table := range entmigrate.Tables {
+		var exists bool
+		if err := q.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", table.Name).Scan(&exists); err != nil {
+			return false, fmt.Errorf("checking for unversioned tables: %w", err)
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Down rolls back the last n applied migrations, newest first, and returns
+// those it rolled back.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < n; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("rolling back migration %s_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM "+revisionsTable+" WHERE version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("rolling back migration %s_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// locked runs fn on a connection holding the migration lock, after making
+// sure the revisions table exists.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer func() {
+		// A fresh context, so the lock is released even if ctx was cancelled
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+	}()
+	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+revisionsTable+` (
+		version character varying PRIMARY KEY,
+		name character varying NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("creating %s table: %w", revisionsTable, err)
+	}
+	return fn(conn)
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// applied returns the application time of every applied version. A missing
+// revisions table means nothing was applied yet.
+func (m *Migrator) applied(ctx context.Context, q querier) (map[string]time.Time, error) {
+	applied := make(map[string]time.Time)
+	var exists bool
+	if err := q.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", revisionsTable).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("reading applied migrations: %w", err)
+	}
+	if !exists {
+		return applied, nil
+	}
+	rows, err := q.Query(ctx, "SELECT version, applied_at FROM "+revisionsTable)
+	if err != nil {
+		return nil, fmt.Errorf("reading applied migrations: %w", err)
+	}
+	var version string
+	var at time.Time
+	_, err = pgx.ForEachRow(rows, []any{&version, &at}, func() error {
+		applied[version] = at
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// NewMigration writes a migration named name to dir with the changes that
+// bring the schema of the migrations already in dir to the ent schema. The
+// current schema is computed by replaying dir on the empty database at
+// devURL, which is cleaned up afterwards. Without changes it writes empty
+// files for a hand-written migration. It returns the names of the files
+// written.
+func NewMigration(ctx context.Context, dir, devURL, name string) ([]string, error) {
+	migrationDir, err := sqltool.NewGolangMigrateDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	before, err := fs.Glob(migrationDir, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	devDB, err := sql.Open("pgx", devURL)
+	if err != nil {
+		return nil, err
+	}
+	defer devDB.Close()
+	differ, err := schema.NewMigrate(entsql.OpenDB(dialect.Postgres, devDB),
+		schema.WithDir(migrationDir),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithDialect(dialect.Postgres),
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+		schema.WithDropColumn(true),
+		schema.WithDropIndex(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := differ.NamedDiff(ctx, name, entmigrate.Tables...); err != nil {
+		return nil, err
+	}
+	written, err := newFiles(migrationDir, before)
+	if err != nil || len(written) > 0 {
+		return written, err
+	}
+	planner := migrate.NewPlanner(nil, migrationDir, migrate.PlanFormat(sqltool.GolangMigrateFormatter))
+	if err := planner.WritePlan(&migrate.Plan{Name: name}); err != nil {
+		return nil, err
+	}
+	return newFiles(migrationDir, before)
+}
+
+func newFiles(dir fs.FS, before []string) ([]string, error) {
+	after, err := fs.Glob(dir, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	existed := make(map[string]bool, len(before))
+	for _, name := range before {
+		existed[name] = true
+	}
+	var written []string
+	for _, name := range after {
+		if !existed[name] {
+			written = append(written, filepath.Base(name))
+		}
+	}
+	return written, nil
+}
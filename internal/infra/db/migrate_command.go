@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ErrUnknownMigrateCommand is returned by RunMigrateCommand for commands it
+// does not implement, so a binary can add its own.
+var ErrUnknownMigrateCommand = errors.New("unknown migrate command")
+
+// RunMigrateCommand runs the `up [N]`, `down [N]`, `baseline VERSION`,
+// `upgrade` or `status` command in args, shared by the server and customerctl, and writes
+// what it did to w. Statuses are passed to printStatus, so each binary keeps
+// its own output format.
+func RunMigrateCommand(ctx context.Context, w io.Writer, migrator *Migrator, args []string, printStatus func([]MigrationStatus) error) error {
+	if len(args) == 0 {
+		return errors.New("expected up [N], down [N], baseline VERSION, upgrade or status")
+	}
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "up":
+		n, err := MigrationCount(args, 0)
+		if err != nil {
+			return err
+		}
+		applied, err := migrator.Up(ctx, n)
+		for _, m := range applied {
+			fmt.Fprintf(w, "Applied %s_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(w, "No pending migrations")
+		}
+		return err
+	case "down":
+		n, err := MigrationCount(args, 1)
+		if err != nil {
+			return err
+		}
+		rolledBack, err := migrator.Down(ctx, n)
+		for _, m := range rolledBack {
+			fmt.Fprintf(w, "Rolled back %s_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(rolledBack) == 0 {
+			fmt.Fprintln(w, "No applied migrations")
+		}
+		return err
+	case "baseline":
+		if len(args) != 1 {
+			return errors.New("expected baseline VERSION")
+		}
+		recorded, err := migrator.Baseline(ctx, args[0])
+		for _, m := range recorded {
+			fmt.Fprintf(w, "Recorded %s_%s without running it\n", m.Version, m.Name)
+		}
+		if err == nil && len(recorded) == 0 {
+			fmt.Fprintln(w, "Migrations up to", args[0], "were already recorded")
+		}
+		return err
+	case "upgrade":
+		if len(args) != 0 {
+			return errors.New("upgrade takes no arguments")
+		}
+		recorded, err := migrator.UpgradeInitSQL(ctx)
+		for _, m := range recorded {
+			fmt.Fprintf(w, "Upgraded the scripts/init.sql schema to %s_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		// Apply the rest at once, so the erasure log is not left without
+		// its append-only trigger
+		applied, err := migrator.Up(ctx, 0)
+		for _, m := range applied {
+			fmt.Fprintf(w, "Applied %s_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		if len(args) != 0 {
+			return errors.New("status takes no arguments")
+		}
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(status)
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownMigrateCommand, command)
+	}
+}
+
+// MigrationCount parses the optional count argument of up and down.
+func MigrationCount(args []string, fallback int) (int, error) {
+	switch len(args) {
+	case 0:
+		return fallback, nil
+	case 1:
+	default:
+		return 0, fmt.Errorf("expected at most one migration count, got %d arguments", len(args))
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid migration count %q", args[0])
+	}
+	return n, nil
+}
@@ -0,0 +1,64 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"iohk-golang-backend/internal/infra/db"
+)
+
+func TestRunMigrateCommandArguments(t *testing.T) {
+	testCases := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "No command", args: nil, wantErr: "expected up [N], down [N], baseline VERSION, upgrade or status"},
+		{name: "Unknown command", args: []string{"sideways"}, wantErr: `unknown migrate command "sideways"`},
+		{name: "Count that is not a number", args: []string{"up", "all"}, wantErr: `invalid migration count "all"`},
+		{name: "Zero count", args: []string{"down", "0"}, wantErr: `invalid migration count "0"`},
+		{name: "Two counts", args: []string{"up", "1", "2"}, wantErr: "expected at most one migration count, got 2 arguments"},
+		{name: "Baseline without version", args: []string{"baseline"}, wantErr: "expected baseline VERSION"},
+		{name: "Upgrade with arguments", args: []string{"upgrade", "now"}, wantErr: "upgrade takes no arguments"},
+		{name: "Status with arguments", args: []string{"status", "json"}, wantErr: "status takes no arguments"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := db.RunMigrateCommand(context.Background(), io.Discard, nil, tc.args, nil)
+
+			// Assert
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestMigrationCount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		fallback int
+		want     int
+	}{
+		{name: "Fallback without argument", args: nil, fallback: 1, want: 1},
+		{name: "Explicit count", args: []string{"3"}, fallback: 0, want: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			n, err := db.MigrationCount(tc.args, tc.fallback)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, n)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+//go:build integration && testcoverage
+// +build integration,testcoverage
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entmigrate "iohk-golang-backend/ent/migrate"
+	"iohk-golang-backend/ent/migrate/migrations"
+	"iohk-golang-backend/internal/config"
+	"iohk-golang-backend/internal/infra/db"
+	"iohk-golang-backend/internal/infra/piicrypto"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestMigrationsIntegration(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pool, loaded := setupMigrationDatabase(ctx, t)
+	migrator := db.NewMigrator(pool, loaded)
+
+	// Act
+	pendingBefore, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	applied, err := migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	pendingAfter, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	_, updateErr := pool.Exec(ctx, "UPDATE erasure_logs SET requested_by = 'someone else'")
+	reapplied, err := migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	driftAfterUp, err := db.DetectDrift(ctx, pool, entmigrate.Tables)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "ALTER TABLE customers ALTER COLUMN gender TYPE varchar(15)")
+	require.NoError(t, err)
+	driftAltered, err := db.DetectDrift(ctx, pool, entmigrate.Tables)
+	require.NoError(t, err)
+	rolledBack, err := migrator.Down(ctx, len(loaded))
+	require.NoError(t, err)
+	var tables int
+	require.NoError(t, pool.QueryRow(ctx,
+		"SELECT count(*) FROM pg_tables WHERE schemaname = 'public' AND tablename IN ('customers', 'erasure_logs')").Scan(&tables))
+
+	// Assert
+	assert.Len(t, pendingBefore, len(loaded))
+	assert.Equal(t, loaded, applied)
+	assert.Empty(t, pendingAfter)
+	assert.ErrorContains(t, updateErr, "erasure_logs is append-only")
+	assert.Empty(t, reapplied)
+	assert.Empty(t, driftAfterUp, "migrations create the ent schema")
+	assert.Equal(t, []db.Drift{{Kind: db.SizeMismatch, Table: "customers", Object: "gender", Expected: "character varying", Actual: "character varying(15)"}}, driftAltered)
+	assert.Len(t, rolledBack, len(loaded))
+	assert.Equal(t, loaded[len(loaded)-1], rolledBack[0], "newest migration is rolled back first")
+	assert.Zero(t, tables)
+}
+
+func TestMigrationBaselineIntegration(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pool, loaded := setupMigrationDatabase(ctx, t)
+	migrator := db.NewMigrator(pool, loaded)
+	_, err := pool.Exec(ctx, "CREATE TABLE customers (id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY, name TEXT NOT NULL)")
+	require.NoError(t, err)
+	latest := loaded[len(loaded)-1].Version
+
+	// Act
+	unversioned, err := migrator.Unversioned(ctx)
+	require.NoError(t, err)
+	applied, upErr := migrator.Up(ctx, 0)
+	_, unknownErr := migrator.Baseline(ctx, "19700101000000")
+	mismatched, mismatchErr := migrator.Baseline(ctx, latest)
+	// Recreate the schema as the migrations would, without recording them
+	_, err = pool.Exec(ctx, "DROP TABLE customers")
+	require.NoError(t, err)
+	for _, m := range loaded {
+		_, err = pool.Exec(ctx, m.Up)
+		require.NoError(t, err)
+	}
+	recorded, err := migrator.Baseline(ctx, latest)
+	require.NoError(t, err)
+	rerecorded, err := migrator.Baseline(ctx, latest)
+	require.NoError(t, err)
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	unversionedAfter, err := migrator.Unversioned(ctx)
+	require.NoError(t, err)
+	reapplied, err := migrator.Up(ctx, 0)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, unversioned)
+	assert.ErrorIs(t, upErr, db.ErrUnversionedSchema)
+	assert.Empty(t, applied)
+	assert.ErrorIs(t, unknownErr, db.ErrUnknownMigration)
+	assert.ErrorIs(t, mismatchErr, db.ErrSchemaMismatch)
+	assert.Empty(t, mismatched)
+	assert.Equal(t, loaded, recorded)
+	assert.Empty(t, rerecorded)
+	assert.Empty(t, pending)
+	assert.False(t, unversionedAfter)
+	assert.Empty(t, reapplied, "baselined migrations are not run")
+}
+
+// originalInitSQL is the schema of the first scripts/init.sql, before
+// encryption and the erasure log.
+const originalInitSQL = `
+CREATE TABLE customers (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    surname VARCHAR(100) NOT NULL,
+    number INT NOT NULL,
+    gender VARCHAR(15) NOT NULL CHECK (gender IN ('Male', 'Female')),
+    country VARCHAR(50) NOT NULL,
+    dependants INT NOT NULL DEFAULT 0 CHECK (dependants >= 0),
+    birth_date DATE NOT NULL CHECK (birth_date <= CURRENT_DATE)
+);
+INSERT INTO customers (name, surname, number, gender, country, dependants, birth_date)
+VALUES (' Jack', 'Front', 123, 'Male', 'USA', 5, TO_DATE('10/3/1981', 'MM/DD/YYYY'));`
+
+// encryptedInitSQL is the schema of the last scripts/init.sql, with blind
+// indexes and the erasure log but INT identifiers and sized columns.
+const encryptedInitSQL = `
+CREATE TABLE customers (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    name_bidx VARCHAR(255),
+    surname TEXT NOT NULL,
+    surname_bidx VARCHAR(255),
+    number INT NOT NULL,
+    gender VARCHAR(15) NOT NULL CHECK (gender IN ('Male', 'Female')),
+    country VARCHAR(50) NOT NULL,
+    dependants INT NOT NULL DEFAULT 0 CHECK (dependants >= 0),
+    birth_date TEXT NOT NULL
+);
+CREATE INDEX customer_name_bidx ON customers (name_bidx);
+CREATE INDEX customer_surname_bidx ON customers (surname_bidx);
+CREATE TABLE erasure_logs (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    customer_id INT NOT NULL CHECK (customer_id > 0),
+    mode VARCHAR(15) NOT NULL CHECK (mode IN ('hard_delete', 'anonymise')),
+    requested_by VARCHAR(100) NOT NULL,
+    erased_at TIMESTAMPTZ NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL,
+    hash VARCHAR(64) NOT NULL
+);
+CREATE UNIQUE INDEX erasurelog_prev_hash ON erasure_logs (prev_hash);
+CREATE INDEX erasurelog_customer_id ON erasure_logs (customer_id);
+CREATE FUNCTION erasure_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'erasure_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER erasure_logs_append_only
+    BEFORE UPDATE OR DELETE ON erasure_logs
+    FOR EACH STATEMENT EXECUTE FUNCTION erasure_logs_append_only();
+INSERT INTO customers (name, surname, number, gender, country, dependants, birth_date)
+VALUES (' Jack', 'Front', 123, 'Male', 'USA', 5, '1981-10-03');
+UPDATE customers SET
+    name_bidx = encode(sha256(convert_to(lower(trim(name)), 'UTF8')), 'hex'),
+    surname_bidx = encode(sha256(convert_to(lower(trim(surname)), 'UTF8')), 'hex');`
+
+func TestMigrationUpgradeInitSQLIntegration(t *testing.T) {
+	testCases := []struct {
+		name   string
+		schema string
+	}{
+		{name: "Original scripts/init.sql", schema: originalInitSQL},
+		{name: "Encrypted scripts/init.sql", schema: encryptedInitSQL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			pool, loaded := setupMigrationDatabase(ctx, t)
+			migrator := db.NewMigrator(pool, loaded)
+			_, err := pool.Exec(ctx, tc.schema)
+			require.NoError(t, err)
+
+			// Act
+			_, baselineErr := migrator.Baseline(ctx, loaded[0].Version)
+			recorded, err := migrator.UpgradeInitSQL(ctx)
+			require.NoError(t, err)
+			_, reupgradeErr := migrator.UpgradeInitSQL(ctx)
+			applied, err := migrator.Up(ctx, 0)
+			require.NoError(t, err)
+			drift, err := db.DetectDrift(ctx, pool, entmigrate.Tables)
+			require.NoError(t, err)
+			var birthDate, surnameBidx string
+			require.NoError(t, pool.QueryRow(ctx,
+				"SELECT birth_date, surname_bidx FROM customers").Scan(&birthDate, &surnameBidx))
+			var id int64
+			require.NoError(t, pool.QueryRow(ctx,
+				"INSERT INTO customers (name, surname, number, gender, country, birth_date) VALUES ('Jill', 'Human', 654, 'Female', 'Spain', '1983-06-02') RETURNING id").Scan(&id))
+			_, err = pool.Exec(ctx,
+				"INSERT INTO erasure_logs (customer_id, mode, requested_by, erased_at, prev_hash, hash) VALUES ($1, 'hard_delete', 'admin', now(), '', 'h')", id)
+			require.NoError(t, err)
+			_, updateErr := pool.Exec(ctx, "UPDATE erasure_logs SET requested_by = 'someone else'")
+
+			// Assert
+			assert.ErrorIs(t, baselineErr, db.ErrSchemaMismatch, "the old schema is not baselined as is")
+			assert.Equal(t, loaded[:1], recorded)
+			assert.ErrorIs(t, reupgradeErr, db.ErrVersionedSchema)
+			assert.Equal(t, loaded[1:], applied)
+			assert.Empty(t, drift, "the upgraded schema matches the migrations")
+			assert.Equal(t, "1981-10-03", birthDate)
+			assert.Equal(t, piicrypto.BlindIndex("Front"), surnameBidx)
+			assert.Equal(t, int64(2), id)
+			assert.ErrorContains(t, updateErr, "erasure_logs is append-only")
+		})
+	}
+}
+
+// setupMigrationDatabase starts an empty Postgres container and returns a
+// pool on it with the embedded migrations.
+func setupMigrationDatabase(ctx context.Context, t *testing.T) (*pgxpool.Pool, []db.Migration) {
+	t.Helper()
+	pgContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := pgContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+	dsn, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	pool, err := db.NewDBPool(ctx, &config.Config{
+		DatabaseURL:         dsn,
+		DBMaxConns:          5,
+		DBMinConns:          1,
+		DBMaxConnLifetime:   time.Hour,
+		DBMaxConnIdleTime:   time.Minute * 30,
+		DBHealthCheckPeriod: time.Minute,
+	})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	loaded, err := db.LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	return pool, loaded
+}
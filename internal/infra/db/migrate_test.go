@@ -0,0 +1,108 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"ariga.io/atlas/sql/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"iohk-golang-backend/ent/migrate/migrations"
+	"iohk-golang-backend/internal/infra/db"
+)
+
+// withSum adds the atlas.sum of the .sql files in fsys.
+func withSum(t *testing.T, fsys fstest.MapFS) fstest.MapFS {
+	names, err := fs.Glob(fsys, "*.sql")
+	require.NoError(t, err)
+	var files []migrate.File
+	for _, name := range names {
+		files = append(files, migrate.NewLocalFile(name, fsys[name].Data))
+	}
+	sum, err := migrate.NewHashFile(files)
+	require.NoError(t, err)
+	b, err := sum.MarshalText()
+	require.NoError(t, err)
+	fsys[migrate.HashFileName] = &fstest.MapFile{Data: b}
+	return fsys
+}
+
+func TestLoadMigrations(t *testing.T) {
+	testCases := []struct {
+		name          string
+		fsys          func(t *testing.T) fstest.MapFS
+		expected      []db.Migration
+		expectedError string
+	}{
+		{
+			name: "Ordered by version, down files optional",
+			fsys: func(t *testing.T) fstest.MapFS {
+				return withSum(t, fstest.MapFS{
+					"20240102000000_add_email.up.sql":  {Data: []byte("ALTER TABLE t ADD email text;")},
+					"20240101000000_init.up.sql":       {Data: []byte("CREATE TABLE t (id int);")},
+					"20240101000000_init.down.sql":     {Data: []byte("DROP TABLE t;")},
+					"20240103000000_backfill.up.sql":   {Data: []byte("UPDATE t SET email = '';")},
+					"20240103000000_backfill.down.sql": {Data: []byte("")},
+				})
+			},
+			expected: []db.Migration{
+				{Version: "20240101000000", Name: "init", Up: "CREATE TABLE t (id int);", Down: "DROP TABLE t;"},
+				{Version: "20240102000000", Name: "add_email", Up: "ALTER TABLE t ADD email text;"},
+				{Version: "20240103000000", Name: "backfill", Up: "UPDATE t SET email = '';"},
+			},
+		},
+		{
+			name: "File edited after generation",
+			fsys: func(t *testing.T) fstest.MapFS {
+				fsys := withSum(t, fstest.MapFS{
+					"20240101000000_init.up.sql": {Data: []byte("CREATE TABLE t (id int);")},
+				})
+				fsys["20240101000000_init.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE t (id bigint);")}
+				return fsys
+			},
+			expectedError: "invalid migration directory: checksum mismatch",
+		},
+		{
+			name: "Missing atlas.sum",
+			fsys: func(t *testing.T) fstest.MapFS {
+				return fstest.MapFS{
+					"20240101000000_init.up.sql": {Data: []byte("CREATE TABLE t (id int);")},
+				}
+			},
+			expectedError: "open atlas.sum: file does not exist",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			migrations, err := db.LoadMigrations(tc.fsys(t))
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, migrations)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	// Act
+	loaded, err := db.LoadMigrations(migrations.FS)
+
+	// Assert
+	require.NoError(t, err, "run `migrate new` instead of editing migrations, or regenerate atlas.sum")
+	require.NotEmpty(t, loaded)
+	assert.Equal(t, "init", loaded[0].Name)
+	for _, m := range loaded {
+		assert.NotEmpty(t, m.Down, "migration %s_%s has no down file", m.Version, m.Name)
+	}
+}
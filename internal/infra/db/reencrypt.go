@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/ent/customer"
+)
+
+// ReencryptCustomers rewrites the encrypted columns of every customer, one
+// batch per transaction. Values are decrypted with whichever key sealed them
+// and written back with the active key, which also encrypts legacy plaintext
+// rows and refreshes their blind indexes.
+func ReencryptCustomers(ctx context.Context, client *ent.Client, batchSize int) (int, error) {
+	lastID, total := 0, 0
+	for {
+		n, next, err := reencryptBatch(ctx, client, lastID, batchSize)
+		if err != nil {
+			return total, err
+		}
+		if n == 0 {
+			return total, nil
+		}
+		lastID = next
+		total += n
+	}
+}
+
+// reencryptBatch rewrites up to batchSize customers after lastID and returns
+// how many it rewrote and the last ID. The batch is read in the same
+// transaction that writes it, with its rows locked, so an update committed
+// in between is not overwritten with the values read before it.
+func reencryptBatch(ctx context.Context, client *ent.Client, lastID, batchSize int) (int, int, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to start transaction: %w", err)
+	}
+	batch, err := tx.Customer.Query().
+		Where(customer.IDGT(lastID), forUpdate).
+		Order(ent.Asc(customer.FieldID)).
+		Limit(batchSize).
+		All(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, 0, fmt.Errorf("unable to read customers after id %d: %w", lastID, err)
+	}
+	if len(batch) == 0 {
+		return 0, 0, tx.Rollback()
+	}
+	for _, c := range batch {
+		err := tx.Customer.UpdateOneID(c.ID).
+			SetName(c.Name).
+			SetSurname(c.Surname).
+			SetBirthDate(c.BirthDate).
+			Exec(ctx)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, 0, fmt.Errorf("unable to re-encrypt customer %d: %w", c.ID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return len(batch), batch[len(batch)-1].ID, nil
+}
+
+// forUpdate locks the selected rows until the transaction ends. SQLite has
+// no row locks and serialises writing transactions instead, so it is left
+// out there.
+func forUpdate(s *entsql.Selector) {
+	if s.Dialect() == dialect.Postgres {
+		s.ForUpdate()
+	}
+}
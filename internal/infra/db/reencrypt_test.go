@@ -0,0 +1,74 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"iohk-golang-backend/ent/customer"
+	"iohk-golang-backend/ent/enttest"
+	"iohk-golang-backend/internal/infra/db"
+	"iohk-golang-backend/internal/infra/piicrypto"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestReencryptCustomers(t *testing.T) {
+	// Arrange
+	dsn := "file:reencrypt?mode=memory&cache=shared&_fk=1"
+	client := enttest.Open(t, "sqlite3", dsn)
+	defer client.Close()
+	raw, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	defer raw.Close()
+
+	for _, surname := range []string{"Front", "Human", "Pullman"} {
+		_, err := client.Customer.Create().
+			SetName("Jack").
+			SetSurname(surname).
+			SetNumber(123).
+			SetGender(customer.GenderMale).
+			SetCountry("USA").
+			SetBirthDate(time.Date(1981, 10, 3, 0, 0, 0, 0, time.UTC)).
+			Save(context.Background())
+		require.NoError(t, err)
+	}
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	keyring, err := piicrypto.NewKeyring("1:"+key, 0, key)
+	require.NoError(t, err)
+	piicrypto.SetDefault(keyring)
+	defer piicrypto.SetDefault(nil)
+
+	// Act
+	count, err := db.ReencryptCustomers(context.Background(), client, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	rows, err := raw.Query("SELECT surname, surname_bidx FROM customers")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var surname, bidx string
+		require.NoError(t, rows.Scan(&surname, &bidx))
+		assert.True(t, strings.HasPrefix(surname, "enc:v1:"), "expected ciphertext, got %q", surname)
+		assert.Contains(t, []string{keyring.BlindIndex("Front"), keyring.BlindIndex("Human"), keyring.BlindIndex("Pullman")}, bidx)
+	}
+
+	customers, err := client.Customer.Query().Where(customer.SurnameBidx(keyring.BlindIndex("Human"))).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, customers, 1)
+	assert.Equal(t, "Human", customers[0].Surname)
+}
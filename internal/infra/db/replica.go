@@ -0,0 +1,257 @@
+package db
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/internal/logging"
+)
+
+// Router picks the ent client for a statement. Writes go to the primary.
+// Reads go to the read replica while its health checks pass, unless the
+// session already wrote, so clients read their own writes despite
+// replication lag.
+type Router struct {
+	primary *ent.Client
+	replica *ent.Client
+	ping    func(context.Context) error
+	healthy atomic.Bool
+}
+
+// NewRouter returns a Router over primary and replica, which ping checks. With
+// a nil replica everything goes to the primary. The replica is considered
+// unhealthy until the first Check passes.
+func NewRouter(primary, replica *ent.Client, ping func(context.Context) error) *Router {
+	return &Router{primary: primary, replica: replica, ping: ping}
+}
+
+// Reader returns the client for a read in ctx.
+func (r *Router) Reader(ctx context.Context) *ent.Client {
+	if r.replica == nil || !r.healthy.Load() || PinnedToPrimary(ctx) {
+		return r.primary
+	}
+	return r.replica
+}
+
+// Writer returns the primary and pins the session of ctx to it.
+func (r *Router) Writer(ctx context.Context) *ent.Client {
+	PinPrimary(ctx)
+	return r.primary
+}
+
+// Primary returns the primary without pinning the session, for reads that
+// must not lag behind.
+func (r *Router) Primary() *ent.Client {
+	return r.primary
+}
+
+// Healthy reports whether reads may go to the replica.
+func (r *Router) Healthy() bool {
+	return r.replica != nil && r.healthy.Load()
+}
+
+// Check pings the replica with the deadline of ctx and logs when it becomes
+// unavailable or available again.
+func (r *Router) Check(ctx context.Context) {
+	if r.replica == nil {
+		return
+	}
+	err := r.ping(ctx)
+	healthy := err == nil
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		logging.FromContext(ctx).Info("Read replica is available, sending reads to it")
+	} else {
+		logging.FromContext(ctx).Warn("Read replica is unavailable, sending reads to the primary", "error", err)
+	}
+}
+
+// Watch runs Check every interval until ctx is cancelled. Each check may take
+// up to interval.
+func (r *Router) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		r.Check(checkCtx)
+		cancel()
+	}
+}
+
+type sessionKey struct{}
+
+// session records whether its reads go to the primary, and whether anything
+// in it wrote to the primary.
+type session struct {
+	pinned atomic.Bool
+	wrote  atomic.Bool
+}
+
+// WithSession returns a context starting a new session. Reads in it go to the
+// primary once PinPrimary was called with it or a context derived from it.
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+// PinPrimary sends the remaining reads of the session of ctx to the primary.
+// It does nothing outside a session.
+func PinPrimary(ctx context.Context) {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok {
+		s.pinned.Store(true)
+		s.wrote.Store(true)
+	}
+}
+
+// PinnedToPrimary reports whether the session of ctx wrote to the primary.
+func PinnedToPrimary(ctx context.Context) bool {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	return ok && s.pinned.Load()
+}
+
+// Middleware starts a session for every request. For a websocket the
+// session lasts as long as the connection, so it covers all its operations.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithSession(r.Context())))
+	})
+}
+
+// PinCookie and PinHeader carry the signed time of a client's last write, so
+// its requests within the pin window after it read from the primary too.
+// Browsers return the cookie, other clients can send the header back.
+const (
+	PinCookie = "primary_pin"
+	PinHeader = "X-Primary-Pin"
+)
+
+// SessionPinning extends sessions across requests. After a request wrote, the
+// response carries a token signed with key, and requests presenting it read
+// from the primary until window has passed since the write, so a client
+// reads its own writes in its next requests despite replication lag.
+type SessionPinning struct {
+	key    []byte
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewSessionPinning returns a SessionPinning signing tokens with key. Every
+// instance behind a load balancer needs the same key.
+func NewSessionPinning(key []byte, window time.Duration) *SessionPinning {
+	return &SessionPinning{key: key, window: window, now: time.Now}
+}
+
+// Middleware starts a session for every request like the Middleware
+// function, pinned to the primary if the request presents a valid token from
+// a write less than the window ago.
+func (p *SessionPinning) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := &session{}
+		if p.pinned(r) {
+			s.pinned.Store(true)
+		}
+		// The token has to be added before the headers are sent, which
+		// happens when the response is first written
+		var issued bool
+		issue := func() {
+			if issued || !s.wrote.Load() {
+				return
+			}
+			issued = true
+			p.issue(w, r)
+		}
+		hooked := httpsnoop.Wrap(w, httpsnoop.Hooks{
+			WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return func(code int) {
+					issue()
+					next(code)
+				}
+			},
+			Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+				return func(b []byte) (int, error) {
+					issue()
+					return next(b)
+				}
+			},
+		})
+		next.ServeHTTP(hooked, r.WithContext(context.WithValue(r.Context(), sessionKey{}, s)))
+	})
+}
+
+// pinned reports whether r presents a valid token from a write within the
+// window. The header takes precedence over the cookie.
+func (p *SessionPinning) pinned(r *http.Request) bool {
+	token := r.Header.Get(PinHeader)
+	if token == "" {
+		if c, err := r.Cookie(PinCookie); err == nil {
+			token = c.Value
+		}
+	}
+	wrote, ok := p.verify(token)
+	if !ok {
+		return false
+	}
+	age := p.now().Sub(wrote)
+	return age >= 0 && age < p.window
+}
+
+// issue adds a token stamped with the current time to the response.
+func (p *SessionPinning) issue(w http.ResponseWriter, r *http.Request) {
+	token := p.sign(p.now())
+	w.Header().Set(PinHeader, token)
+	http.SetCookie(w, &http.Cookie{
+		Name:     PinCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(math.Ceil(p.window.Seconds())),
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// sign returns "<unix milliseconds>.<HMAC-SHA256 of them>".
+func (p *SessionPinning) sign(t time.Time) string {
+	payload := strconv.FormatInt(t.UnixMilli(), 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(p.mac(payload))
+}
+
+// verify returns the time in token if its signature is valid.
+func (p *SessionPinning) verify(token string) (time.Time, bool) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return time.Time{}, false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, p.mac(payload)) {
+		return time.Time{}, false
+	}
+	millis, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
+func (p *SessionPinning) mac(payload string) []byte {
+	h := hmac.New(sha256.New, p.key)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,220 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/internal/infra/db"
+)
+
+func TestRouter(t *testing.T) {
+	testCases := []struct {
+		name            string
+		withReplica     bool
+		pingErr         error
+		pinned          bool
+		expectedReplica bool
+		expectedHealthy bool
+	}{
+		{
+			name:            "Healthy replica serves reads",
+			withReplica:     true,
+			expectedReplica: true,
+			expectedHealthy: true,
+		},
+		{
+			name:            "Unhealthy replica falls back to the primary",
+			withReplica:     true,
+			pingErr:         errors.New("connection refused"),
+			expectedReplica: false,
+		},
+		{
+			name:            "Session pinned after a write",
+			withReplica:     true,
+			pinned:          true,
+			expectedReplica: false,
+			expectedHealthy: true,
+		},
+		{
+			name:            "No replica configured",
+			withReplica:     false,
+			expectedReplica: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			primary := ent.NewClient()
+			var replica *ent.Client
+			if tc.withReplica {
+				replica = ent.NewClient()
+			}
+			router := db.NewRouter(primary, replica, func(context.Context) error { return tc.pingErr })
+			ctx := db.WithSession(context.Background())
+			router.Check(ctx)
+			if tc.pinned {
+				router.Writer(ctx)
+			}
+
+			// Act
+			reader := router.Reader(ctx)
+
+			// Assert
+			if tc.expectedReplica {
+				assert.Same(t, replica, reader)
+			} else {
+				assert.Same(t, primary, reader)
+			}
+			assert.Equal(t, tc.expectedHealthy, router.Healthy())
+			assert.Same(t, primary, router.Writer(ctx))
+		})
+	}
+}
+
+func TestRouterRecovers(t *testing.T) {
+	// Arrange
+	primary, replica := ent.NewClient(), ent.NewClient()
+	pingErr := errors.New("connection refused")
+	router := db.NewRouter(primary, replica, func(context.Context) error { return pingErr })
+	ctx := context.Background()
+
+	// Act
+	router.Check(ctx)
+	before := router.Reader(ctx)
+	pingErr = nil
+	router.Check(ctx)
+
+	// Assert
+	assert.Same(t, primary, before)
+	assert.Same(t, replica, router.Reader(ctx))
+}
+
+func TestPinPrimary(t *testing.T) {
+	// Arrange
+	var pinnedInHandler bool
+	handler := db.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		derived, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		db.PinPrimary(derived)
+		pinnedInHandler = db.PinnedToPrimary(r.Context())
+	}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/query", nil))
+	db.PinPrimary(context.Background())
+
+	// Assert
+	assert.True(t, pinnedInHandler, "a write in a derived context pins the request")
+	assert.False(t, db.PinnedToPrimary(context.Background()), "there is nothing to pin outside a session")
+	assert.False(t, db.PinnedToPrimary(db.WithSession(context.Background())), "new sessions start unpinned")
+}
+
+func TestSessionPinning(t *testing.T) {
+	// Arrange
+	window := 200 * time.Millisecond
+	pinning := db.NewSessionPinning([]byte("secret"), window)
+	other := db.NewSessionPinning([]byte("other secret"), window)
+	serve := func(p *db.SessionPinning, write bool, req *http.Request) (*httptest.ResponseRecorder, bool) {
+		var pinned bool
+		handler := p.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pinned = db.PinnedToPrimary(r.Context())
+			if write {
+				db.PinPrimary(r.Context())
+			}
+			w.Write([]byte(`{"data":{}}`))
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec, pinned
+	}
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/query", nil)
+	}
+
+	// Act
+	readRec, readPinned := serve(pinning, false, newRequest())
+	writeRec, _ := serve(pinning, true, newRequest())
+	token := writeRec.Header().Get(db.PinHeader)
+	cookies := writeRec.Result().Cookies()
+
+	withCookie := newRequest()
+	for _, c := range cookies {
+		withCookie.AddCookie(c)
+	}
+	cookieRec, cookiePinned := serve(pinning, false, withCookie)
+	withHeader := newRequest()
+	withHeader.Header.Set(db.PinHeader, token)
+	_, headerPinned := serve(pinning, false, withHeader)
+	withForeignToken := newRequest()
+	withForeignToken.Header.Set(db.PinHeader, token)
+	_, foreignPinned := serve(other, false, withForeignToken)
+	forged := newRequest()
+	forged.Header.Set(db.PinHeader, strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10)+token[strings.Index(token, "."):])
+	_, forgedPinned := serve(pinning, false, forged)
+
+	time.Sleep(window)
+	expired := newRequest()
+	expired.Header.Set(db.PinHeader, token)
+	_, expiredPinned := serve(pinning, false, expired)
+
+	// Assert
+	assert.False(t, readPinned, "requests without a token read from the replica")
+	assert.Empty(t, readRec.Header().Get(db.PinHeader), "reads issue no token")
+	assert.Empty(t, readRec.Result().Cookies())
+	assert.NotEmpty(t, token, "writes issue a token")
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, db.PinCookie, cookies[0].Name)
+		assert.Equal(t, token, cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, 1, cookies[0].MaxAge)
+	}
+	assert.True(t, cookiePinned, "the next request with the cookie reads from the primary")
+	assert.Empty(t, cookieRec.Header().Get(db.PinHeader), "a read keeps the window of the write")
+	assert.True(t, headerPinned, "the next request with the header reads from the primary")
+	assert.False(t, foreignPinned, "tokens signed with another key are ignored")
+	assert.False(t, forgedPinned, "tokens with a changed time are ignored")
+	assert.False(t, expiredPinned, "the pin ends after the window")
+}
+
+func TestSessionPinningIgnoresMalformedTokens(t *testing.T) {
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{name: "Empty", token: ""},
+		{name: "No signature", token: "1700000000000"},
+		{name: "Invalid base64", token: "1700000000000.!!!"},
+		{name: "Wrong signature", token: "1700000000000.c2lnbmF0dXJl"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var pinned bool
+			handler := db.NewSessionPinning([]byte("secret"), time.Hour).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pinned = db.PinnedToPrimary(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			req.AddCookie(&http.Cookie{Name: db.PinCookie, Value: tc.token})
+
+			// Act
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			// Assert
+			assert.False(t, pinned)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"iohk-golang-backend/internal/logging"
+
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 driver
+)
+
+// NewSQLiteDB opens the SQLite database file at path, creating it if it does
+// not exist, with foreign keys enforced. SQLite allows one writer at a time,
+// so the pool has a single connection and concurrent requests queue for it
+// instead of failing with "database is locked". The driver needs cgo.
+func NewSQLiteDB(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_fk=1&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to open SQLite database %s: %w", path, err)
+	}
+	logging.FromContext(ctx).Info("Opened SQLite database", "path", path)
+	return db, nil
+}
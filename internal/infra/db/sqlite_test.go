@@ -0,0 +1,72 @@
+//go:build testcoverage
+// +build testcoverage
+
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"iohk-golang-backend/ent"
+	entcustomer "iohk-golang-backend/ent/customer"
+)
+
+func openSQLiteClient(t *testing.T, path string) *ent.Client {
+	sqlDB, err := NewSQLiteDB(context.Background(), path)
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, sqlDB)))
+	require.NoError(t, client.Schema.Create(context.Background()))
+	return client
+}
+
+func TestNewSQLiteDB(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "customers.db")
+	client := openSQLiteClient(t, path)
+	var wg sync.WaitGroup
+
+	// Act
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Customer.Create().
+				SetName("Jill").SetSurname("Human").SetNumber(1).SetGender(entcustomer.GenderFemale).
+				SetCountry("Spain").SetBirthDate(time.Date(1983, 6, 2, 0, 0, 0, 0, time.UTC)).
+				Save(ctx)
+			assert.NoError(t, err, "concurrent writes wait for the connection")
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, client.Close())
+	reopened := openSQLiteClient(t, path)
+	count, err := reopened.Customer.Query().Count(ctx)
+	require.NoError(t, err)
+	require.NoError(t, reopened.Close())
+	sqlDB, err := NewSQLiteDB(ctx, path)
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	var foreignKeys int
+	require.NoError(t, sqlDB.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys))
+
+	// Assert
+	assert.Equal(t, 20, count, "customers are kept in the file")
+	assert.Equal(t, 1, foreignKeys)
+}
+
+func TestNewSQLiteDBFails(t *testing.T) {
+	// Act
+	_, err := NewSQLiteDB(context.Background(), filepath.Join(t.TempDir(), "missing", "customers.db"))
+
+	// Assert
+	assert.ErrorContains(t, err, "unable to open SQLite database")
+}
@@ -0,0 +1,42 @@
+-- Upgrades a database created by the former scripts/init.sql, in either of
+-- its versions, to the schema of 20261019000000_init. The later erasure log
+-- trigger is dropped, since 20261019000001_erasure_logs_append_only creates
+-- it again.
+SET LOCAL datestyle = 'ISO';
+-- drop the birth date check, which the application enforces instead
+ALTER TABLE "customers" DROP CONSTRAINT IF EXISTS "customers_birth_date_check";
+-- modify "customers" table
+ALTER TABLE "customers"
+    ALTER COLUMN "id" TYPE bigint,
+    ALTER COLUMN "id" SET GENERATED BY DEFAULT,
+    ALTER COLUMN "name" TYPE text,
+    ALTER COLUMN "surname" TYPE text,
+    ALTER COLUMN "number" TYPE bigint,
+    ALTER COLUMN "gender" TYPE character varying,
+    ALTER COLUMN "country" TYPE character varying,
+    ALTER COLUMN "dependants" TYPE bigint,
+    ALTER COLUMN "birth_date" TYPE text USING "birth_date"::text;
+ALTER TABLE "customers" ADD COLUMN IF NOT EXISTS "name_bidx" character varying NULL;
+ALTER TABLE "customers" ADD COLUMN IF NOT EXISTS "surname_bidx" character varying NULL;
+ALTER TABLE "customers"
+    ALTER COLUMN "name_bidx" TYPE character varying,
+    ALTER COLUMN "surname_bidx" TYPE character varying;
+-- backfill the unkeyed surname blind index of plaintext rows; reencrypt
+-- rebuilds it with PII_BLIND_INDEX_KEY, and a later migration drops name_bidx
+UPDATE "customers" SET "surname_bidx" = encode(sha256(convert_to(lower(btrim("surname")), 'UTF8')), 'hex') WHERE "surname_bidx" IS NULL;
+CREATE INDEX IF NOT EXISTS "customer_name_bidx" ON "customers" ("name_bidx");
+CREATE INDEX IF NOT EXISTS "customer_surname_bidx" ON "customers" ("surname_bidx");
+-- create or modify "erasure_logs" table
+DROP TRIGGER IF EXISTS "erasure_logs_append_only" ON "erasure_logs";
+DROP FUNCTION IF EXISTS "erasure_logs_append_only"();
+CREATE TABLE IF NOT EXISTS "erasure_logs" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "customer_id" bigint NOT NULL, "mode" character varying NOT NULL, "requested_by" character varying NOT NULL, "erased_at" timestamptz NOT NULL, "prev_hash" character varying NOT NULL, "hash" character varying NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "erasure_logs_customer_id_check" CHECK (customer_id > 0), CONSTRAINT "erasure_logs_mode_check" CHECK (mode IN ('hard_delete', 'anonymise')));
+ALTER TABLE "erasure_logs"
+    ALTER COLUMN "id" TYPE bigint,
+    ALTER COLUMN "id" SET GENERATED BY DEFAULT,
+    ALTER COLUMN "customer_id" TYPE bigint,
+    ALTER COLUMN "mode" TYPE character varying,
+    ALTER COLUMN "requested_by" TYPE character varying,
+    ALTER COLUMN "prev_hash" TYPE character varying,
+    ALTER COLUMN "hash" TYPE character varying;
+CREATE UNIQUE INDEX IF NOT EXISTS "erasurelog_prev_hash" ON "erasure_logs" ("prev_hash");
+CREATE INDEX IF NOT EXISTS "erasurelog_customer_id" ON "erasure_logs" ("customer_id");
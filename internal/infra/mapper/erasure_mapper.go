@@ -0,0 +1,103 @@
+package mapper
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/ent/erasurelog"
+	"iohk-golang-backend/graph/model"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+)
+
+func ErasureModeToEnt(m domainmodel.ErasureMode) erasurelog.Mode {
+	switch m {
+	case domainmodel.ErasureModeHardDelete:
+		return erasurelog.ModeHardDelete
+	case domainmodel.ErasureModeAnonymise:
+		return erasurelog.ModeAnonymise
+	default:
+		return erasurelog.Mode("")
+	}
+}
+
+func EntToErasureRecord(e *ent.ErasureLog) *domainmodel.ErasureRecord {
+	return &domainmodel.ErasureRecord{
+		ID:          e.ID,
+		CustomerID:  e.CustomerID,
+		Mode:        entErasureModeToDomain(e.Mode),
+		RequestedBy: e.RequestedBy,
+		ErasedAt:    e.ErasedAt.UTC(),
+		PrevHash:    e.PrevHash,
+		Hash:        e.Hash,
+	}
+}
+
+func ErasureRecordToGraphQL(r *domainmodel.ErasureRecord) *model.ErasureRecord {
+	return &model.ErasureRecord{
+		ID:          strconv.Itoa(r.ID),
+		CustomerID:  strconv.Itoa(r.CustomerID),
+		Mode:        model.ErasureMode(r.Mode),
+		RequestedBy: r.RequestedBy,
+		ErasedAt:    r.ErasedAt.Format(time.RFC3339),
+		Hash:        r.Hash,
+	}
+}
+
+func ErasureLogVerificationToGraphQL(v *domainmodel.ErasureLogVerification) *model.ErasureLogVerification {
+	result := &model.ErasureLogVerification{
+		Valid:   v.Valid,
+		Entries: v.Entries,
+	}
+	if !v.Valid {
+		result.FirstInvalidID = stringPtr(strconv.Itoa(v.FirstInvalidID))
+	}
+	return result
+}
+
+func DataExportToGraphQL(e *domainmodel.CustomerDataExport) (*model.CustomerDataExport, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CustomerDataExport{
+		CustomerID: strconv.Itoa(e.Customer.ID),
+		ExportedAt: e.ExportedAt.Format(time.RFC3339),
+		Data:       string(data),
+	}, nil
+}
+
+func DomainToCustomerExport(c *domainmodel.Customer) domainmodel.CustomerExport {
+	return domainmodel.CustomerExport{
+		ID:         c.ID,
+		Name:       c.Name,
+		Surname:    c.Surname,
+		Number:     c.Number,
+		Gender:     c.Gender,
+		Country:    c.Country,
+		Dependants: c.Dependants,
+		BirthDate:  c.BirthDate.Format("2006-01-02"),
+	}
+}
+
+func ErasureRecordToExport(r *domainmodel.ErasureRecord) domainmodel.ErasureRecordExport {
+	return domainmodel.ErasureRecordExport{
+		ID:          r.ID,
+		Mode:        r.Mode,
+		RequestedBy: r.RequestedBy,
+		ErasedAt:    r.ErasedAt,
+		Hash:        r.Hash,
+	}
+}
+
+func entErasureModeToDomain(m erasurelog.Mode) domainmodel.ErasureMode {
+	switch m {
+	case erasurelog.ModeHardDelete:
+		return domainmodel.ErasureModeHardDelete
+	case erasurelog.ModeAnonymise:
+		return domainmodel.ErasureModeAnonymise
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,101 @@
+//go:build testcoverage
+// +build testcoverage
+
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"iohk-golang-backend/ent"
+	"iohk-golang-backend/ent/erasurelog"
+	"iohk-golang-backend/graph/model"
+	domainmodel "iohk-golang-backend/internal/domain/model"
+)
+
+func TestErasureModeToEnt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    domainmodel.ErasureMode
+		expected erasurelog.Mode
+	}{
+		{name: "Hard delete", input: domainmodel.ErasureModeHardDelete, expected: erasurelog.ModeHardDelete},
+		{name: "Anonymise", input: domainmodel.ErasureModeAnonymise, expected: erasurelog.ModeAnonymise},
+		{name: "Unknown", input: domainmodel.ErasureMode("SHRED"), expected: erasurelog.Mode("")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := ErasureModeToEnt(tc.input)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestEntToErasureRecord(t *testing.T) {
+	// Arrange
+	erasedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := &ent.ErasureLog{
+		ID:          3,
+		CustomerID:  7,
+		Mode:        erasurelog.ModeAnonymise,
+		RequestedBy: "dpo",
+		ErasedAt:    erasedAt,
+		PrevHash:    "prev",
+		Hash:        "hash",
+	}
+
+	// Act
+	result := EntToErasureRecord(entry)
+
+	// Assert
+	assert.Equal(t, &domainmodel.ErasureRecord{
+		ID:          3,
+		CustomerID:  7,
+		Mode:        domainmodel.ErasureModeAnonymise,
+		RequestedBy: "dpo",
+		ErasedAt:    erasedAt,
+		PrevHash:    "prev",
+		Hash:        "hash",
+	}, result)
+}
+
+func TestErasureRecordToGraphQL(t *testing.T) {
+	// Arrange
+	record := &domainmodel.ErasureRecord{
+		ID:          3,
+		CustomerID:  7,
+		Mode:        domainmodel.ErasureModeHardDelete,
+		RequestedBy: "dpo",
+		ErasedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Hash:        "hash",
+	}
+
+	// Act
+	result := ErasureRecordToGraphQL(record)
+
+	// Assert
+	assert.Equal(t, &model.ErasureRecord{
+		ID:          "3",
+		CustomerID:  "7",
+		Mode:        model.ErasureModeHardDelete,
+		RequestedBy: "dpo",
+		ErasedAt:    "2024-01-02T03:04:05Z",
+		Hash:        "hash",
+	}, result)
+}
+
+func TestErasureLogVerificationToGraphQL(t *testing.T) {
+	// Act
+	valid := ErasureLogVerificationToGraphQL(&domainmodel.ErasureLogVerification{Valid: true, Entries: 2})
+	invalid := ErasureLogVerificationToGraphQL(&domainmodel.ErasureLogVerification{Valid: false, Entries: 2, FirstInvalidID: 2})
+
+	// Assert
+	assert.Nil(t, valid.FirstInvalidID)
+	assert.Equal(t, "2", *invalid.FirstInvalidID)
+}
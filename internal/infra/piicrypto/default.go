@@ -0,0 +1,38 @@
+package piicrypto
+
+import "sync/atomic"
+
+// The ent schema's value scanners are static, so the keyring they use is
+// installed once at startup rather than passed through the ent client.
+var defaultKeyring atomic.Pointer[Keyring]
+
+// SetDefault installs the keyring used by the ent value scanners. A nil
+// keyring disables encryption and new values are stored in plaintext.
+func SetDefault(k *Keyring) {
+	defaultKeyring.Store(k)
+}
+
+// Default returns the installed keyring, which may be nil.
+func Default() *Keyring {
+	return defaultKeyring.Load()
+}
+
+// Encrypt encrypts with the default keyring, or returns the plaintext
+// unchanged when encryption is disabled.
+func Encrypt(plaintext, column string) (string, error) {
+	k := Default()
+	if k == nil {
+		return plaintext, nil
+	}
+	return k.Encrypt(plaintext, column)
+}
+
+// Decrypt decrypts with the default keyring.
+func Decrypt(stored, column string) (string, error) {
+	return Default().Decrypt(stored, column)
+}
+
+// BlindIndex computes a blind index with the default keyring.
+func BlindIndex(value string) string {
+	return Default().BlindIndex(value)
+}
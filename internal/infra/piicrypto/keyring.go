@@ -0,0 +1,169 @@
+package piicrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// prefix marks a column value as ciphertext. Values without it are treated as
+// legacy plaintext so existing rows stay readable until they are re-encrypted.
+const prefix = "enc:v"
+
+// minSealed is the length of a GCM nonce and tag. A value with the prefix is
+// only ciphertext if its payload decodes to at least this, so plaintext that
+// happens to start with the prefix is still read as plaintext.
+const minSealed = 12 + 16
+
+// Keyring holds every known AES-256-GCM key by version, the version used for
+// new writes and the HMAC key for blind indexes.
+type Keyring struct {
+	keys     map[int]cipher.AEAD
+	active   int
+	blindKey []byte
+}
+
+// NewKeyring builds a keyring from a comma-separated list of "version:base64key"
+// entries. An active version of 0 selects the highest configured version.
+// An empty key list returns a nil keyring, which disables encryption.
+func NewKeyring(rawKeys string, active int, rawBlindKey string) (*Keyring, error) {
+	if strings.TrimSpace(rawKeys) == "" {
+		return nil, nil
+	}
+
+	k := &Keyring{keys: make(map[int]cipher.AEAD), active: active}
+	for _, entry := range strings.Split(rawKeys, ",") {
+		version, key, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid encryption key entry, expected version:base64key")
+		}
+		v, err := strconv.Atoi(version)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("invalid encryption key version %q", version)
+		}
+		if _, exists := k.keys[v]; exists {
+			return nil, fmt.Errorf("duplicate encryption key version %d", v)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key version %d: %w", v, err)
+		}
+		k.keys[v] = aead
+		if active == 0 && v > k.active {
+			k.active = v
+		}
+	}
+	if _, ok := k.keys[k.active]; !ok {
+		return nil, fmt.Errorf("active encryption key version %d is not configured", k.active)
+	}
+
+	if rawBlindKey == "" {
+		return nil, errors.New("a blind index key is required when encryption is enabled")
+	}
+	blindKey, err := base64.StdEncoding.DecodeString(rawBlindKey)
+	if err != nil || len(blindKey) < 32 {
+		return nil, errors.New("blind index key must be at least 32 base64-encoded bytes")
+	}
+	k.blindKey = blindKey
+
+	return k, nil
+}
+
+func newAEAD(rawKey string) (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ActiveVersion returns the key version used for new writes.
+func (k *Keyring) ActiveVersion() int {
+	return k.active
+}
+
+// Encrypt seals plaintext with the active key. The column name is bound as
+// additional data so ciphertext cannot be moved between columns.
+func (k *Keyring) Encrypt(plaintext, column string) (string, error) {
+	aead := k.keys[k.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("unable to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(column))
+	return prefix + strconv.Itoa(k.active) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt with whichever key version sealed
+// it. Values that are not encrypted are returned unchanged.
+func (k *Keyring) Decrypt(stored, column string) (string, error) {
+	version, sealed, encrypted := parse(stored)
+	if !encrypted {
+		return stored, nil
+	}
+	if k == nil {
+		return "", errors.New("encrypted value found but no encryption keys are configured")
+	}
+	aead, ok := k.keys[version]
+	if !ok {
+		return "", fmt.Errorf("encryption key version %d is not configured", version)
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt column %s: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a deterministic HMAC-SHA256 of the normalised value, so
+// exact-match lookups work without decrypting every row. Without a keyring it
+// falls back to an unkeyed SHA-256, which is only acceptable because the
+// column itself is stored in plaintext in that case.
+func (k *Keyring) BlindIndex(value string) string {
+	normalised := []byte(strings.ToLower(strings.TrimSpace(value)))
+	if k == nil {
+		sum := sha256.Sum256(normalised)
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, k.blindKey)
+	mac.Write(normalised)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parse splits a value produced by Encrypt into its key version and sealed
+// bytes. Anything else, including plaintext starting with the prefix, is not
+// encrypted.
+func parse(stored string) (version int, sealed []byte, encrypted bool) {
+	rest, ok := strings.CutPrefix(stored, prefix)
+	if !ok {
+		return 0, nil, false
+	}
+	v, payload, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, nil, false
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil || version <= 0 || strconv.Itoa(version) != v {
+		return 0, nil, false
+	}
+	sealed, err = base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < minSealed {
+		return 0, nil, false
+	}
+	return version, sealed, true
+}
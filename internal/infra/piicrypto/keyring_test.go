@@ -0,0 +1,225 @@
+//go:build testcoverage
+// +build testcoverage
+
+package piicrypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestNewKeyring(t *testing.T) {
+	testCases := []struct {
+		name           string
+		keys           string
+		active         int
+		blindKey       string
+		expectedActive int
+		expectedError  string
+	}{
+		{
+			name:           "Highest version is active by default",
+			keys:           "1:" + testKey(1) + ",2:" + testKey(2),
+			blindKey:       testKey(9),
+			expectedActive: 2,
+		},
+		{
+			name:           "Explicit active version",
+			keys:           "1:" + testKey(1) + ",2:" + testKey(2),
+			active:         1,
+			blindKey:       testKey(9),
+			expectedActive: 1,
+		},
+		{
+			name:          "Active version not configured",
+			keys:          "1:" + testKey(1),
+			active:        3,
+			blindKey:      testKey(9),
+			expectedError: "active encryption key version 3 is not configured",
+		},
+		{
+			name:          "Short key",
+			keys:          "1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+			blindKey:      testKey(9),
+			expectedError: "encryption key version 1: key must be 32 bytes, got 5",
+		},
+		{
+			name:          "Invalid version",
+			keys:          "v1:" + testKey(1),
+			blindKey:      testKey(9),
+			expectedError: `invalid encryption key version "v1"`,
+		},
+		{
+			name:          "Duplicate version",
+			keys:          "1:" + testKey(1) + ",1:" + testKey(2),
+			blindKey:      testKey(9),
+			expectedError: "duplicate encryption key version 1",
+		},
+		{
+			name:          "Missing blind index key",
+			keys:          "1:" + testKey(1),
+			expectedError: "a blind index key is required when encryption is enabled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			keyring, err := NewKeyring(tc.keys, tc.active, tc.blindKey)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, keyring)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedActive, keyring.ActiveVersion())
+			}
+		})
+	}
+}
+
+func TestNewKeyringDisabled(t *testing.T) {
+	// Act
+	keyring, err := NewKeyring("", 0, "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, keyring)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	// Arrange
+	keyring, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+	require.NoError(t, err)
+
+	// Act
+	ciphertext, err := keyring.Encrypt("Smith", "surname")
+	require.NoError(t, err)
+	plaintext, err := keyring.Decrypt(ciphertext, "surname")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Smith", plaintext)
+	assert.True(t, strings.HasPrefix(ciphertext, "enc:v1:"))
+	assert.NotContains(t, ciphertext, "Smith")
+}
+
+func TestDecryptAfterRotation(t *testing.T) {
+	// Arrange
+	oldKeyring, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+	require.NoError(t, err)
+	rotated, err := NewKeyring("1:"+testKey(1)+",2:"+testKey(2), 0, testKey(9))
+	require.NoError(t, err)
+	oldCiphertext, err := oldKeyring.Encrypt("Smith", "surname")
+	require.NoError(t, err)
+
+	// Act
+	plaintext, err := rotated.Decrypt(oldCiphertext, "surname")
+	newCiphertext, encErr := rotated.Encrypt(plaintext, "surname")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, encErr)
+	assert.Equal(t, "Smith", plaintext)
+	assert.True(t, strings.HasPrefix(newCiphertext, "enc:v2:"))
+}
+
+func TestDecryptErrors(t *testing.T) {
+	keyring, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+	require.NoError(t, err)
+	ciphertext, err := keyring.Encrypt("Smith", "surname")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		keyring       *Keyring
+		stored        string
+		column        string
+		expectedError string
+	}{
+		{
+			name:          "Ciphertext moved to another column",
+			keyring:       keyring,
+			stored:        ciphertext,
+			column:        "name",
+			expectedError: "unable to decrypt column name: cipher: message authentication failed",
+		},
+		{
+			name:          "Unknown key version",
+			keyring:       keyring,
+			stored:        strings.Replace(ciphertext, "enc:v1:", "enc:v7:", 1),
+			column:        "surname",
+			expectedError: "encryption key version 7 is not configured",
+		},
+		{
+			name:          "Encrypted value without keys",
+			keyring:       nil,
+			stored:        ciphertext,
+			column:        "surname",
+			expectedError: "encrypted value found but no encryption keys are configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := tc.keyring.Decrypt(tc.stored, tc.column)
+
+			// Assert
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestDecryptLegacyPlaintext(t *testing.T) {
+	keyring, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		keyring *Keyring
+		stored  string
+	}{
+		{name: "Plain value", keyring: keyring, stored: "Smith"},
+		{name: "Prefix without version", keyring: keyring, stored: "enc:vintage"},
+		{name: "Prefix with a non-numeric version", keyring: keyring, stored: "enc:vip:Smith"},
+		{name: "Prefix with a payload that is not base64", keyring: keyring, stored: "enc:v1:Smith & Sons"},
+		{name: "Prefix with a payload too short to be sealed", keyring: keyring, stored: "enc:v1:U21pdGg="},
+		{name: "Prefix without keys", keyring: nil, stored: "enc:v2:Smith"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			plaintext, err := tc.keyring.Decrypt(tc.stored, "surname")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.stored, plaintext)
+		})
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	// Arrange
+	keyring, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+	require.NoError(t, err)
+	otherKeyring, err := NewKeyring("1:"+testKey(1), 0, testKey(8))
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.Equal(t, keyring.BlindIndex("Smith"), keyring.BlindIndex(" smith "))
+	assert.NotEqual(t, keyring.BlindIndex("Smith"), keyring.BlindIndex("Smyth"))
+	assert.NotEqual(t, keyring.BlindIndex("Smith"), otherKeyring.BlindIndex("Smith"))
+	assert.Len(t, (*Keyring)(nil).BlindIndex("Smith"), 64)
+}
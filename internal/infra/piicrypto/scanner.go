@@ -0,0 +1,67 @@
+package piicrypto
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/schema/field"
+)
+
+// dateLayout matches the Postgres date format used before encryption, so
+// legacy plaintext rows parse the same way as decrypted ones.
+const dateLayout = "2006-01-02"
+
+// EncryptedString is an ent value scanner that stores a string column encrypted.
+type EncryptedString struct {
+	Column string
+}
+
+func (s EncryptedString) Value(v string) (driver.Value, error) {
+	return Encrypt(v, s.Column)
+}
+
+func (EncryptedString) ScanValue() field.ValueScanner {
+	return &sql.NullString{}
+}
+
+func (s EncryptedString) FromValue(v driver.Value) (string, error) {
+	ns, ok := v.(*sql.NullString)
+	if !ok {
+		return "", fmt.Errorf("unexpected input for FromValue: %T", v)
+	}
+	if !ns.Valid {
+		return "", nil
+	}
+	return Decrypt(ns.String, s.Column)
+}
+
+// EncryptedDate is an ent value scanner that stores a date encrypted as text.
+// Only the date part is kept, matching the previous Postgres date column.
+type EncryptedDate struct {
+	Column string
+}
+
+func (s EncryptedDate) Value(v time.Time) (driver.Value, error) {
+	return Encrypt(v.Format(dateLayout), s.Column)
+}
+
+func (EncryptedDate) ScanValue() field.ValueScanner {
+	return &sql.NullString{}
+}
+
+func (s EncryptedDate) FromValue(v driver.Value) (time.Time, error) {
+	ns, ok := v.(*sql.NullString)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unexpected input for FromValue: %T", v)
+	}
+	if !ns.Valid {
+		return time.Time{}, nil
+	}
+	plaintext, err := Decrypt(ns.String, s.Column)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(dateLayout, plaintext)
+}
@@ -0,0 +1,82 @@
+//go:build testcoverage
+// +build testcoverage
+
+package piicrypto
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedStringRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name    string
+		keyring func(t *testing.T) *Keyring
+	}{
+		{
+			name:    "Encryption disabled",
+			keyring: func(t *testing.T) *Keyring { return nil },
+		},
+		{
+			name: "Encryption enabled",
+			keyring: func(t *testing.T) *Keyring {
+				k, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+				require.NoError(t, err)
+				return k
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			SetDefault(tc.keyring(t))
+			defer SetDefault(nil)
+			scanner := EncryptedString{Column: "name"}
+
+			// Act
+			stored, err := scanner.Value("Alice")
+			require.NoError(t, err)
+			value, err := scanner.FromValue(&sql.NullString{String: stored.(string), Valid: true})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "Alice", value)
+		})
+	}
+}
+
+func TestEncryptedDateRoundTrip(t *testing.T) {
+	// Arrange
+	k, err := NewKeyring("1:"+testKey(1), 0, testKey(9))
+	require.NoError(t, err)
+	SetDefault(k)
+	defer SetDefault(nil)
+	scanner := EncryptedDate{Column: "birth_date"}
+	birthDate := time.Date(1990, 5, 17, 13, 45, 0, 0, time.UTC)
+
+	// Act
+	stored, err := scanner.Value(birthDate)
+	require.NoError(t, err)
+	value, err := scanner.FromValue(&sql.NullString{String: stored.(string), Valid: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), value)
+}
+
+func TestEncryptedDateLegacyPlaintext(t *testing.T) {
+	// Arrange
+	scanner := EncryptedDate{Column: "birth_date"}
+
+	// Act
+	value, err := scanner.FromValue(&sql.NullString{String: "1981-10-03", Valid: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1981, 10, 3, 0, 0, 0, 0, time.UTC), value)
+}
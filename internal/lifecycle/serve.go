@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"iohk-golang-backend/internal/logging"
+)
+
+// Serve runs server on ln until ctx is cancelled, then stops accepting
+// connections and waits up to drainTimeout for in-flight requests and
+// websocket connections to finish. Connections still open after the timeout
+// are closed. The server serves HTTPS if its TLSConfig is set. It returns nil
+// after a shutdown and the serve error otherwise.
+func Serve(ctx context.Context, server *http.Server, ln net.Listener, websockets *Websockets, drainTimeout time.Duration) error {
+	server.RegisterOnShutdown(websockets.Close)
+
+	errCh := make(chan error, 1)
+	go func() {
+		if server.TLSConfig != nil {
+			errCh <- server.ServeTLS(ln, "", "")
+			return
+		}
+		errCh <- server.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	logger := logging.FromContext(ctx)
+	logger.Info("Shutting down, draining connections", "timeout", drainTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("Failed to drain HTTP connections", "error", err)
+		server.Close()
+	}
+	if err := websockets.Wait(shutdownCtx); err != nil {
+		logger.Warn("Failed to close websocket connections", "error", err)
+	}
+	logger.Info("HTTP server stopped")
+	return nil
+}
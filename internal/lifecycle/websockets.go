@@ -0,0 +1,82 @@
+// Package lifecycle coordinates the shutdown of the HTTP server.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+type trackedKey struct{}
+
+var errShuttingDown = errors.New("server is shutting down")
+
+// Websockets closes GraphQL websocket connections on shutdown.
+// http.Server.Shutdown neither closes nor waits for hijacked connections, so
+// without this subscriptions are cut off when the process exits. Set InitFunc
+// and CloseFunc on the websocket transport and call Close from
+// http.Server.RegisterOnShutdown.
+type Websockets struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	closing bool
+}
+
+func NewWebsockets() *Websockets {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Websockets{ctx: ctx, cancel: cancel}
+}
+
+// InitFunc tracks a connection and ties its context to the shutdown, so that
+// gqlgen closes it with a normal closure once Close is called. Connections
+// initialised after Close are rejected.
+func (w *Websockets) InitFunc(ctx context.Context, _ transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closing {
+		return nil, nil, errShuttingDown
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, trackedKey{}, true))
+	stop := context.AfterFunc(w.ctx, cancel)
+	context.AfterFunc(ctx, func() { stop() })
+
+	w.wg.Add(1)
+	return ctx, nil, nil
+}
+
+// CloseFunc marks a connection tracked by InitFunc as closed.
+func (w *Websockets) CloseFunc(ctx context.Context, _ int) {
+	if ctx.Value(trackedKey{}) != nil {
+		w.wg.Done()
+	}
+}
+
+// Close asks every open connection to close.
+func (w *Websockets) Close() {
+	w.mu.Lock()
+	w.closing = true
+	w.mu.Unlock()
+	w.cancel()
+}
+
+// Wait blocks until every connection has closed or ctx is done.
+func (w *Websockets) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,85 @@
+//go:build testcoverage
+// +build testcoverage
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketsClose(t *testing.T) {
+	// Arrange
+	w := NewWebsockets()
+	connCtx, err := initConnection(w)
+	require.NoError(t, err)
+
+	// Act
+	w.Close()
+
+	// Assert
+	select {
+	case <-connCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("connection context was not cancelled by Close")
+	}
+	assert.ErrorIs(t, waitFor(w, 10*time.Millisecond), context.DeadlineExceeded)
+
+	w.CloseFunc(connCtx, 1000)
+	assert.NoError(t, waitFor(w, time.Second))
+}
+
+func TestWebsocketsRejectAfterClose(t *testing.T) {
+	// Arrange
+	w := NewWebsockets()
+	w.Close()
+
+	// Act
+	_, err := initConnection(w)
+
+	// Assert
+	assert.ErrorIs(t, err, errShuttingDown)
+	assert.NoError(t, waitFor(w, time.Second))
+}
+
+func TestWebsocketsUntrackedClose(t *testing.T) {
+	// Arrange
+	w := NewWebsockets()
+
+	// Act
+	w.CloseFunc(context.Background(), 1000)
+
+	// Assert
+	assert.NoError(t, waitFor(w, time.Second))
+}
+
+func TestWebsocketsConnectionEndsBeforeShutdown(t *testing.T) {
+	// Arrange
+	w := NewWebsockets()
+	parent, cancel := context.WithCancel(context.Background())
+	connCtx, _, err := w.InitFunc(parent, nil)
+	require.NoError(t, err)
+
+	// Act
+	cancel()
+	w.CloseFunc(connCtx, 1000)
+	w.Close()
+
+	// Assert
+	assert.NoError(t, waitFor(w, time.Second))
+}
+
+func initConnection(w *Websockets) (context.Context, error) {
+	ctx, _, err := w.InitFunc(context.Background(), nil)
+	return ctx, err
+}
+
+func waitFor(w *Websockets, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return w.Wait(ctx)
+}
@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"iohk-golang-backend/internal/auth"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Redacted replaces PII values in logged GraphQL variables.
+const Redacted = "[REDACTED]"
+
+// piiFields are the argument and input field names, in lower case, whose
+// values are never logged. Extend it when the schema gets new PII fields.
+var piiFields = map[string]bool{
+	"name":      true,
+	"surname":   true,
+	"number":    true,
+	"birthdate": true,
+}
+
+func isPII(name string) bool {
+	return piiFields[strings.ToLower(name)]
+}
+
+// Extension is a gqlgen extension adding the operation name and principal to
+// the request logger and logging every operation with redacted variables.
+type Extension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = Extension{}
+
+func (Extension) ExtensionName() string {
+	return "Logging"
+}
+
+func (Extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	name, opType := oc.OperationName, "unknown"
+	if oc.Operation != nil {
+		name, opType = oc.Operation.Name, string(oc.Operation.Operation)
+	}
+	if name == "" {
+		name = "anonymous"
+	}
+	principal := "anonymous"
+	if p := auth.FromContext(ctx); p != nil {
+		principal = p.Name
+	}
+
+	ctx = With(ctx, "operation", name, "principal", principal)
+	logger := FromContext(ctx)
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		logger.DebugContext(ctx, "GraphQL operation started",
+			"type", opType,
+			"variables", RedactVariables(oc.Operation, oc.Variables),
+		)
+	}
+
+	handler := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		resp := handler(ctx)
+		if resp == nil {
+			return nil
+		}
+
+		attrs := []slog.Attr{
+			slog.String("type", opType),
+			slog.Duration("duration", time.Since(oc.Stats.OperationStart)),
+		}
+		if len(resp.Errors) == 0 {
+			logger.LogAttrs(ctx, slog.LevelInfo, "GraphQL operation completed", attrs...)
+			return resp
+		}
+
+		messages := make([]string, len(resp.Errors))
+		for i, err := range resp.Errors {
+			messages[i] = err.Message
+		}
+		attrs = append(attrs, slog.Any("errors", messages))
+		logger.LogAttrs(ctx, slog.LevelWarn, "GraphQL operation completed with errors", attrs...)
+		return resp
+	}
+}
+
+// RedactVariables returns a copy of vars that is safe to log. Variables
+// passed to PII arguments or input fields are replaced whatever their name,
+// and PII fields inside input objects are replaced as well.
+func RedactVariables(op *ast.OperationDefinition, vars map[string]interface{}) map[string]interface{} {
+	bound := map[string]bool{}
+	if op != nil {
+		collectPIIVariables(op.SelectionSet, bound, map[string]bool{})
+	}
+
+	redacted := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		if bound[name] || isPII(name) {
+			redacted[name] = Redacted
+			continue
+		}
+		redacted[name] = redactValue(value)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if isPII(key) {
+				redacted[key] = Redacted
+				continue
+			}
+			redacted[key] = redactValue(field)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// collectPIIVariables marks the variables used as the value of a PII
+// argument or input field anywhere in selections.
+func collectPIIVariables(selections ast.SelectionSet, bound, visited map[string]bool) {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			for _, arg := range sel.Arguments {
+				collectPIIValue(arg.Name, arg.Value, bound)
+			}
+			collectPIIVariables(sel.SelectionSet, bound, visited)
+		case *ast.InlineFragment:
+			collectPIIVariables(sel.SelectionSet, bound, visited)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil && !visited[sel.Name] {
+				visited[sel.Name] = true
+				collectPIIVariables(sel.Definition.SelectionSet, bound, visited)
+			}
+		}
+	}
+}
+
+func collectPIIValue(name string, value *ast.Value, bound map[string]bool) {
+	if value == nil {
+		return
+	}
+	if value.Kind == ast.Variable {
+		if isPII(name) {
+			bound[value.Raw] = true
+		}
+		return
+	}
+	for _, child := range value.Children {
+		childName := child.Name
+		if value.Kind == ast.ListValue {
+			childName = name
+		}
+		collectPIIValue(childName, child.Value, bound)
+	}
+}
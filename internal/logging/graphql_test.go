@@ -0,0 +1,119 @@
+//go:build testcoverage
+// +build testcoverage
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"iohk-golang-backend/internal/auth"
+)
+
+var testSchema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+	type Customer { id: ID! name: String! }
+	input CreateCustomerInput { name: String! surname: String! number: Int! gender: String! }
+	type Query { customer(id: ID!): Customer customersBySurname(surname: String!): [Customer!]! }
+	type Mutation { createCustomer(input: CreateCustomerInput!): Customer! }
+`})
+
+func TestRedactVariables(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		vars     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "Non PII argument",
+			query:    `query($id: ID!) { customer(id: $id) { id } }`,
+			vars:     map[string]interface{}{"id": "1"},
+			expected: map[string]interface{}{"id": "1"},
+		},
+		{
+			name:     "PII argument with unrelated variable name",
+			query:    `query($s: String!) { customersBySurname(surname: $s) { id } }`,
+			vars:     map[string]interface{}{"s": "Doe"},
+			expected: map[string]interface{}{"s": Redacted},
+		},
+		{
+			name:  "Input object",
+			query: `mutation($input: CreateCustomerInput!) { createCustomer(input: $input) { id } }`,
+			vars: map[string]interface{}{"input": map[string]interface{}{
+				"name": "Jane", "surname": "Doe", "number": 42, "gender": "FEMALE",
+			}},
+			expected: map[string]interface{}{"input": map[string]interface{}{
+				"name": Redacted, "surname": Redacted, "number": Redacted, "gender": "FEMALE",
+			}},
+		},
+		{
+			name:     "Inline input object",
+			query:    `mutation($n: String!) { createCustomer(input: {name: $n, surname: "Doe", number: 1, gender: "MALE"}) { id } }`,
+			vars:     map[string]interface{}{"n": "Jane"},
+			expected: map[string]interface{}{"n": Redacted},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			doc, errs := gqlparser.LoadQuery(testSchema, tc.query)
+			require.Empty(t, errs)
+
+			// Act
+			result := RedactVariables(doc.Operations[0], tc.vars)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestExtension(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger, err := New(&buf, "debug", FormatJSON)
+	require.NoError(t, err)
+	doc, errs := gqlparser.LoadQuery(testSchema, `query Find($s: String!) { customersBySurname(surname: $s) { id } }`)
+	require.Empty(t, errs)
+
+	ctx := WithLogger(context.Background(), logger)
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Name: "support"})
+	ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{
+		Operation: doc.Operations[0],
+		Variables: map[string]interface{}{"s": "Doe"},
+		Stats:     graphql.Stats{OperationStart: time.Now()},
+	})
+	var resolverLogger map[string]interface{}
+	next := func(ctx context.Context) graphql.ResponseHandler {
+		FromContext(ctx).Info("resolving")
+		return graphql.OneShot(&graphql.Response{Errors: gqlerror.List{{Message: "customer not found"}}})
+	}
+
+	// Act
+	Extension{}.InterceptOperation(ctx, next)(ctx)
+
+	// Assert
+	assert.NotContains(t, buf.String(), "Doe")
+	decoder := json.NewDecoder(&buf)
+	var started, completed map[string]interface{}
+	require.NoError(t, decoder.Decode(&started))
+	require.NoError(t, decoder.Decode(&resolverLogger))
+	require.NoError(t, decoder.Decode(&completed))
+	assert.Equal(t, "GraphQL operation started", started["msg"])
+	assert.Equal(t, map[string]interface{}{"s": Redacted}, started["variables"])
+	assert.Equal(t, "Find", resolverLogger["operation"])
+	assert.Equal(t, "support", resolverLogger["principal"])
+	assert.Equal(t, "WARN", completed["level"])
+	assert.Equal(t, []interface{}{"customer not found"}, completed["errors"])
+}
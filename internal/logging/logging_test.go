@@ -0,0 +1,77 @@
+//go:build testcoverage
+// +build testcoverage
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name          string
+		level         string
+		format        string
+		expectedDebug bool
+		expectedError string
+	}{
+		{name: "JSON info", level: "info", format: FormatJSON},
+		{name: "Text debug", level: "debug", format: FormatText, expectedDebug: true},
+		{name: "Upper case", level: "WARN", format: "JSON"},
+		{name: "Invalid level", level: "verbose", format: FormatJSON, expectedError: `invalid log level "verbose"`},
+		{name: "Invalid format", level: "info", format: "xml", expectedError: `invalid log format "xml"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			logger, err := New(&bytes.Buffer{}, tc.level, tc.format)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDebug, logger.Enabled(context.Background(), slog.LevelDebug))
+		})
+	}
+}
+
+func TestNewWithLevelVar(t *testing.T) {
+	// Arrange
+	var level slog.LevelVar
+	logger, err := NewWithLevel(&bytes.Buffer{}, &level, FormatJSON)
+	require.NoError(t, err)
+
+	// Act
+	level.Set(slog.LevelDebug)
+
+	// Assert
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelDebug))
+}
+
+func TestFromContext(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", FormatJSON)
+	require.NoError(t, err)
+
+	// Act
+	ctx := With(WithLogger(context.Background(), logger), "operation", "GetCustomers")
+	FromContext(ctx).Info("hello")
+
+	// Assert
+	assert.Equal(t, slog.Default(), FromContext(context.Background()))
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "hello", entry["msg"])
+	assert.Equal(t, "GetCustomers", entry["operation"])
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+
+	"iohk-golang-backend/internal/requestid"
+
+	"github.com/felixge/httpsnoop"
+)
+
+// Middleware gives every request its own logger, carrying the request ID set
+// by requestid.Middleware, and logs the request once it has been served.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestid.FromContext(r.Context())
+		if id == "" {
+			id = requestid.New()
+		}
+		logger := FromContext(r.Context()).With("requestId", id)
+		ctx := WithLogger(r.Context(), logger)
+
+		// httpsnoop keeps the optional interfaces of w, such as the
+		// http.Hijacker websocket upgrades need
+		m := httpsnoop.CaptureMetricsFn(w, func(w http.ResponseWriter) {
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+
+		level := slog.LevelInfo
+		if m.Code >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "HTTP request served",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", m.Code),
+			slog.Duration("duration", m.Duration),
+		)
+	})
+}
@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PgxTracer records how long pgxpool.Pool.Acquire waits for a connection.
+// Set it as the pool's ConnConfig.Tracer, it does not trace queries itself.
+type PgxTracer struct {
+	Metrics *Metrics
+}
+
+var _ interface {
+	pgx.QueryTracer
+	pgxpool.AcquireTracer
+} = PgxTracer{}
+
+type acquireStartKey struct{}
+
+func (t PgxTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+func (t PgxTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireEndData) {
+	if start, ok := ctx.Value(acquireStartKey{}).(time.Time); ok {
+		t.Metrics.acquireDuration.Observe(time.Since(start).Seconds())
+	}
+}
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (PgxTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// RegisterPool exports the connection counts of pool, labelled with its
+// name, e.g. "primary" or "replica".
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool, name string) {
+	m.registry.MustRegister(poolCollector{pool: pool, name: name})
+}
+
+// RegisterReplicaHealth exports whether reads go to the read replica, as
+// reported by healthy.
+func (m *Metrics) RegisterReplicaHealth(healthy func() bool) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "db_replica_healthy",
+		Help: "Whether the read replica passes its health checks and serves reads (1) or reads fall back to the primary (0).",
+	}, func() float64 {
+		if healthy() {
+			return 1
+		}
+		return 0
+	}))
+}
+
+var (
+	poolConnsDesc = prometheus.NewDesc("db_pool_connections",
+		"Connections in the pool by state.", []string{"pool", "state"}, nil)
+	poolMaxConnsDesc = prometheus.NewDesc("db_pool_max_connections",
+		"Maximum size of the pool.", []string{"pool"}, nil)
+	poolAcquiresDesc = prometheus.NewDesc("db_pool_acquires_total",
+		"Connections acquired from the pool.", []string{"pool"}, nil)
+	poolEmptyAcquiresDesc = prometheus.NewDesc("db_pool_empty_acquires_total",
+		"Acquires that had to wait for a connection because the pool was empty.", []string{"pool"}, nil)
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+	name string
+}
+
+func (c poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolConnsDesc
+	ch <- poolMaxConnsDesc
+	ch <- poolAcquiresDesc
+	ch <- poolEmptyAcquiresDesc
+}
+
+func (c poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolConnsDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()), c.name, "acquired")
+	ch <- prometheus.MustNewConstMetric(poolConnsDesc, prometheus.GaugeValue, float64(stat.IdleConns()), c.name, "idle")
+	ch <- prometheus.MustNewConstMetric(poolConnsDesc, prometheus.GaugeValue, float64(stat.ConstructingConns()), c.name, "constructing")
+	ch <- prometheus.MustNewConstMetric(poolMaxConnsDesc, prometheus.GaugeValue, float64(stat.MaxConns()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolAcquiresDesc, prometheus.CounterValue, float64(stat.AcquireCount()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquiresDesc, prometheus.CounterValue, float64(stat.EmptyAcquireCount()), c.name)
+}
+
+// InstrumentDriver wraps an ent driver to record the duration of every
+// statement, including those run in transactions.
+func (m *Metrics) InstrumentDriver(drv dialect.Driver) dialect.Driver {
+	return &instrumentedDriver{Driver: drv, m: m}
+}
+
+type instrumentedDriver struct {
+	dialect.Driver
+	m *Metrics
+}
+
+func (d *instrumentedDriver) Exec(ctx context.Context, query string, args, v any) error {
+	defer d.m.observeQuery(query, time.Now())
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+func (d *instrumentedDriver) Query(ctx context.Context, query string, args, v any) error {
+	defer d.m.observeQuery(query, time.Now())
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+func (d *instrumentedDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx, m: d.m}, nil
+}
+
+// BeginTx keeps ent.Client.BeginTx working for drivers that support it.
+func (d *instrumentedDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return d.Tx(ctx)
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx, m: d.m}, nil
+}
+
+type instrumentedTx struct {
+	dialect.Tx
+	m *Metrics
+}
+
+func (t *instrumentedTx) Exec(ctx context.Context, query string, args, v any) error {
+	defer t.m.observeQuery(query, time.Now())
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+func (t *instrumentedTx) Query(ctx context.Context, query string, args, v any) error {
+	defer t.m.observeQuery(query, time.Now())
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+func (m *Metrics) observeQuery(query string, start time.Time) {
+	m.queryDuration.WithLabelValues(statementType(query)).Observe(time.Since(start).Seconds())
+}
+
+// statementType returns the lower case SQL verb of query, keeping the label
+// cardinality bounded.
+func statementType(query string) string {
+	verb, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	switch verb = strings.ToLower(verb); verb {
+	case "select", "insert", "update", "delete", "with":
+		return verb
+	default:
+		return "other"
+	}
+}
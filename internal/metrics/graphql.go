@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// codeUnknown labels errors without an extensions.code, such as errors
+// returned by resolvers.
+const codeUnknown = "UNKNOWN"
+
+// operationOther labels operations whose name is not in
+// Extension.OperationNames.
+const operationOther = "other"
+
+// Extension is a gqlgen extension recording operation latency and errors.
+type Extension struct {
+	Metrics *Metrics
+	// OperationNames are the operation names recorded as they are, usually
+	// those of the allowlist. Clients choose operation names, so any other
+	// name is recorded as "other" to keep the number of series bounded.
+	OperationNames map[string]bool
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = Extension{}
+
+func (Extension) ExtensionName() string {
+	return "Metrics"
+}
+
+func (Extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse observes every response, including those for operations
+// rejected before execution, which have no operation context.
+func (e Extension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp == nil {
+		return nil
+	}
+
+	for _, err := range resp.Errors {
+		code, _ := err.Extensions["code"].(string)
+		if code == "" {
+			code = codeUnknown
+		}
+		e.Metrics.errors.WithLabelValues(code).Inc()
+	}
+
+	if graphql.HasOperationContext(ctx) {
+		oc := graphql.GetOperationContext(ctx)
+		name, opType := oc.OperationName, "unknown"
+		if oc.Operation != nil {
+			name = oc.Operation.Name
+			opType = string(oc.Operation.Operation)
+		}
+		switch {
+		case name == "":
+			name = "anonymous"
+		case !e.OperationNames[name]:
+			name = operationOther
+		}
+		e.Metrics.operationDuration.WithLabelValues(name, opType).
+			Observe(time.Since(oc.Stats.OperationStart).Seconds())
+	}
+	return resp
+}
@@ -0,0 +1,100 @@
+// Package metrics exposes Prometheus metrics for GraphQL operations, the
+// database and the Go runtime.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics owns a registry with the application metrics and the Go runtime
+// and process collectors.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	operationDuration *prometheus.HistogramVec
+	errors            *prometheus.CounterVec
+	inFlight          prometheus.Gauge
+	acquireDuration   prometheus.Histogram
+	queryDuration     *prometheus.HistogramVec
+	configReloads     *prometheus.CounterVec
+	configReloadTime  prometheus.Gauge
+}
+
+// Results of configuration reloads.
+const (
+	ReloadApplied  = "applied"
+	ReloadRejected = "rejected"
+	ReloadFailed   = "failed"
+)
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "graphql_operation_duration_seconds",
+			Help:    "Duration of GraphQL operations from receiving the request to writing the response.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation_name", "operation_type"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "graphql_errors_total",
+			Help: "GraphQL errors returned to clients, including resolver errors, by error code.",
+		}, []string{"code"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "graphql_requests_in_flight",
+			Help: "GraphQL HTTP requests currently being served.",
+		}),
+		acquireDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "db_pool_acquire_duration_seconds",
+			Help:    "Time spent waiting to acquire a connection from the pool.",
+			Buckets: []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5},
+		}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ent_query_duration_seconds",
+			Help:    "Duration of SQL statements run by ent, by statement type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"statement"}),
+		configReloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "config_reloads_total",
+			Help: "Configuration reloads by result: applied, rejected for needing a restart, or failed to load.",
+		}, []string{"result"}),
+		configReloadTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "config_last_reload_timestamp_seconds",
+			Help: "Unix time of the last applied configuration reload.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.operationDuration,
+		m.errors,
+		m.inFlight,
+		m.acquireDuration,
+		m.queryDuration,
+		m.configReloads,
+		m.configReloadTime,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// InFlight counts the requests next is serving.
+func (m *Metrics) InFlight(next http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerInFlight(m.inFlight, next)
+}
+
+// ObserveConfigReload counts a configuration reload with the given result.
+func (m *Metrics) ObserveConfigReload(result string) {
+	m.configReloads.WithLabelValues(result).Inc()
+	if result == ReloadApplied {
+		m.configReloadTime.SetToCurrentTime()
+	}
+}
@@ -0,0 +1,162 @@
+//go:build testcoverage
+// +build testcoverage
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestExtension(t *testing.T) {
+	// Arrange
+	m := New()
+	ext := Extension{Metrics: m, OperationNames: map[string]bool{"GetCustomers": true}}
+	operation := func(name string) context.Context {
+		return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+			Operation: &ast.OperationDefinition{Name: name, Operation: ast.Query},
+			Stats:     graphql.Stats{OperationStart: time.Now().Add(-time.Second)},
+		})
+	}
+	next := func(ctx context.Context) *graphql.Response {
+		return &graphql.Response{Errors: gqlerror.List{
+			{Message: "customer not found"},
+			{Message: "forbidden", Extensions: map[string]interface{}{"code": "FORBIDDEN"}},
+		}}
+	}
+
+	// Act
+	ext.InterceptResponse(operation("GetCustomers"), next)
+	ext.InterceptResponse(operation("Random1"), next)
+	ext.InterceptResponse(operation("Random2"), next)
+	ext.InterceptResponse(operation(""), next)
+	ext.InterceptResponse(context.Background(), next)
+
+	// Assert
+	assert.Equal(t, 3, testutil.CollectAndCount(m.operationDuration))
+	assert.Equal(t, 5.0, testutil.ToFloat64(m.errors.WithLabelValues(codeUnknown)))
+	assert.Equal(t, 5.0, testutil.ToFloat64(m.errors.WithLabelValues("FORBIDDEN")))
+	expected := `
+		# HELP graphql_operation_duration_seconds Duration of GraphQL operations from receiving the request to writing the response.
+		# TYPE graphql_operation_duration_seconds histogram
+		graphql_operation_duration_seconds_count{operation_name="GetCustomers",operation_type="query"} 1
+		graphql_operation_duration_seconds_count{operation_name="anonymous",operation_type="query"} 1
+		graphql_operation_duration_seconds_count{operation_name="other",operation_type="query"} 2
+	`
+	assert.NoError(t, testutil.CollectAndCompare(m.operationDuration, strings.NewReader(expected), "graphql_operation_duration_seconds_count"))
+}
+
+func TestInstrumentDriver(t *testing.T) {
+	// Arrange
+	m := New()
+	sqlDrv, err := entsql.Open(dialect.SQLite, "file:metrics?mode=memory&cache=shared")
+	require.NoError(t, err)
+	drv := m.InstrumentDriver(sqlDrv)
+	defer drv.Close()
+	ctx := context.Background()
+
+	// Act
+	require.NoError(t, drv.Exec(ctx, "CREATE TABLE t (id INTEGER)", []any{}, nil))
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Exec(ctx, "INSERT INTO t (id) VALUES (1)", []any{}, nil))
+	require.NoError(t, tx.Commit())
+	var rows entsql.Rows
+	require.NoError(t, drv.Query(ctx, "SELECT id FROM t", []any{}, &rows))
+	rows.Close()
+
+	// Assert
+	expected := `
+		# HELP ent_query_duration_seconds Duration of SQL statements run by ent, by statement type.
+		# TYPE ent_query_duration_seconds histogram
+		ent_query_duration_seconds_count{statement="insert"} 1
+		ent_query_duration_seconds_count{statement="other"} 1
+		ent_query_duration_seconds_count{statement="select"} 1
+	`
+	assert.NoError(t, testutil.CollectAndCompare(m.queryDuration, strings.NewReader(expected), "ent_query_duration_seconds_count"))
+}
+
+func TestHandler(t *testing.T) {
+	// Arrange
+	m := New()
+	inFlight := 0.0
+	handler := m.InFlight(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight = testutil.ToFloat64(m.inFlight)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/query", nil))
+	rec := httptest.NewRecorder()
+
+	// Act
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Assert
+	assert.Equal(t, 1.0, inFlight)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "graphql_requests_in_flight 0")
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
+
+func TestObserveConfigReload(t *testing.T) {
+	// Arrange
+	m := New()
+
+	// Act
+	m.ObserveConfigReload(ReloadRejected)
+	m.ObserveConfigReload(ReloadApplied)
+	m.ObserveConfigReload(ReloadApplied)
+
+	// Assert
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.configReloads.WithLabelValues(ReloadApplied)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.configReloads.WithLabelValues(ReloadRejected)))
+	assert.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(m.configReloadTime), 5)
+}
+
+func TestRegisterReplicaHealth(t *testing.T) {
+	// Arrange
+	m := New()
+	healthy := true
+	m.RegisterReplicaHealth(func() bool { return healthy })
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	// Act
+	before := scrape()
+	healthy = false
+	after := scrape()
+
+	// Assert
+	assert.Contains(t, before, "db_replica_healthy 1")
+	assert.Contains(t, after, "db_replica_healthy 0")
+}
+
+func TestStatementType(t *testing.T) {
+	testCases := map[string]string{
+		`SELECT "customers"."id" FROM "customers"`: "select",
+		"  insert into t values (1)":               "insert",
+		"UPDATE t SET a = 1":                       "update",
+		"DELETE FROM t":                            "delete",
+		"BEGIN":                                    "other",
+	}
+
+	for query, expected := range testCases {
+		assert.Equal(t, expected, statementType(query), query)
+	}
+}
@@ -0,0 +1,82 @@
+package querylimit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// CodeDepthLimitExceeded is the error code of operations rejected by DepthLimit.
+const CodeDepthLimitExceeded = "DEPTH_LIMIT_EXCEEDED"
+
+// DepthLimit rejects operations whose selections nest deeper than MaxDepth.
+// Fragments count towards the depth of the field they are spread into and
+// introspection fields are not counted.
+type DepthLimit struct {
+	MaxDepth int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = DepthLimit{}
+
+func (DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (DepthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, oc *graphql.OperationContext) *gqlerror.Error {
+	op := oc.Doc.Operations.ForName(oc.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	if depth := Depth(op.SelectionSet); depth > d.MaxDepth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.MaxDepth)
+		errcode.Set(err, CodeDepthLimitExceeded)
+		return err
+	}
+	return nil
+}
+
+// Depth returns the number of nested field levels in selections.
+func Depth(selections ast.SelectionSet) int {
+	return depth(selections, map[string]bool{})
+}
+
+func depth(selections ast.SelectionSet, visiting map[string]bool) int {
+	max := 0
+	for _, sel := range selections {
+		var d int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			d = 1 + depth(sel.SelectionSet, visiting)
+		case *ast.InlineFragment:
+			d = depth(sel.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			// Validation rejects fragment cycles before this runs, the guard
+			// only keeps a malformed document from recursing forever.
+			if sel.Definition == nil || visiting[sel.Name] {
+				continue
+			}
+			visiting[sel.Name] = true
+			d = depth(sel.Definition.SelectionSet, visiting)
+			delete(visiting, sel.Name)
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
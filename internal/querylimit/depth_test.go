@@ -0,0 +1,56 @@
+//go:build testcoverage
+// +build testcoverage
+
+package querylimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var testSchema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+	type Query { node: Node }
+	type Node { id: ID! parent: Node children: [Node!]! }
+`})
+
+func TestDepthLimit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedDepth int
+	}{
+		{name: "Single field", query: `{ node { id } }`, expectedDepth: 2},
+		{name: "Nested fields", query: `{ node { parent { parent { id } } } }`, expectedDepth: 4},
+		{name: "Deepest branch counts", query: `{ node { id children { parent { id } } } }`, expectedDepth: 4},
+		{name: "Fragment spread", query: `{ node { ...F } } fragment F on Node { parent { id } }`, expectedDepth: 3},
+		{name: "Inline fragment", query: `{ node { ... on Node { parent { id } } } }`, expectedDepth: 3},
+		{name: "Introspection ignored", query: `{ __schema { types { fields { type { ofType { name } } } } } node { id } }`, expectedDepth: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			doc, errs := gqlparser.LoadQuery(testSchema, tc.query)
+			require.Empty(t, errs)
+			oc := &graphql.OperationContext{Doc: doc}
+
+			// Act
+			depth := Depth(doc.Operations[0].SelectionSet)
+			withinErr := DepthLimit{MaxDepth: tc.expectedDepth}.MutateOperationContext(context.Background(), oc)
+			overErr := DepthLimit{MaxDepth: tc.expectedDepth - 1}.MutateOperationContext(context.Background(), oc)
+
+			// Assert
+			assert.Equal(t, tc.expectedDepth, depth)
+			assert.Nil(t, withinErr)
+			require.NotNil(t, overErr)
+			assert.Contains(t, overErr.Message, "operation has depth")
+			assert.Equal(t, CodeDepthLimitExceeded, overErr.Extensions["code"])
+		})
+	}
+}
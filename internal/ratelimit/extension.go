@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+
+	"iohk-golang-backend/internal/requestid"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// CostExtension charges expensive GraphQL operations extra tokens on top of
+// the one the middleware took for the request. The cost is the complexity
+// computed by the complexity limit extension, which must be registered too.
+type CostExtension struct {
+	// TokenComplexity is the complexity one token pays for. An operation
+	// costs its complexity divided by it, rounded up, and at least one token.
+	TokenComplexity int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = CostExtension{}
+
+func (CostExtension) ExtensionName() string {
+	return "RateLimitCost"
+}
+
+func (CostExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e CostExtension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	s := stateFromContext(ctx)
+	extra := e.cost(ctx) - 1
+	if s == nil || extra <= 0 {
+		return next(ctx)
+	}
+
+	if ok, wait := s.limiter.Take(s.key, float64(extra)); !ok {
+		s.reject(wait)
+		extensions := map[string]interface{}{"code": CodeRateLimited}
+		if seconds, ok := retryAfterSeconds(wait); ok {
+			extensions["retryAfter"] = seconds
+		}
+		if id := requestid.FromContext(ctx); id != "" {
+			extensions["requestId"] = id
+		}
+		return graphql.OneShot(&graphql.Response{
+			Errors: gqlerror.List{{Message: "rate limit exceeded", Extensions: extensions}},
+		})
+	}
+	return next(ctx)
+}
+
+func (e CostExtension) cost(ctx context.Context) int {
+	stats := extension.GetComplexityStats(ctx)
+	if stats == nil || e.TokenComplexity <= 0 {
+		return 1
+	}
+	return max(1, (stats.Complexity+e.TokenComplexity-1)/e.TokenComplexity)
+}
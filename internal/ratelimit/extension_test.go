@@ -0,0 +1,65 @@
+//go:build testcoverage
+// +build testcoverage
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostExtension(t *testing.T) {
+	testCases := []struct {
+		name            string
+		complexity      *int
+		tokensAvailable float64
+		expectedLimited bool
+	}{
+		{name: "Cheap operation costs one token", complexity: ptr(9), tokensAvailable: 0, expectedLimited: false},
+		{name: "Operation without complexity costs one token", complexity: nil, tokensAvailable: 0, expectedLimited: false},
+		{name: "Complexity rounded up within budget", complexity: ptr(161), tokensAvailable: 6, expectedLimited: false},
+		{name: "Complexity rounded up over budget", complexity: ptr(161), tokensAvailable: 5, expectedLimited: true},
+		{name: "Exact multiple within budget", complexity: ptr(150), tokensAvailable: 5, expectedLimited: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			l, _ := newTestLimiter(1, 10)
+			l.Take("a", 10-tc.tokensAvailable)
+			s := &state{limiter: l, key: "a"}
+			ctx := context.WithValue(context.Background(), stateKey{}, s)
+			oc := &graphql.OperationContext{}
+			if tc.complexity != nil {
+				// The key the complexity limit extension stores its stats under
+				oc.Stats.SetExtension("ComplexityLimit", &extension.ComplexityStats{Complexity: *tc.complexity, ComplexityLimit: 1000})
+			}
+			ctx = graphql.WithOperationContext(ctx, oc)
+			called := false
+			next := func(ctx context.Context) graphql.ResponseHandler {
+				called = true
+				return graphql.OneShot(&graphql.Response{})
+			}
+
+			// Act
+			resp := CostExtension{TokenComplexity: 25}.InterceptOperation(ctx, next)(ctx)
+
+			// Assert
+			assert.Equal(t, tc.expectedLimited, s.limited)
+			assert.Equal(t, !tc.expectedLimited, called)
+			if tc.expectedLimited {
+				assert.Len(t, resp.Errors, 1)
+				assert.Equal(t, CodeRateLimited, resp.Errors[0].Extensions["code"])
+				assert.Equal(t, 1, resp.Errors[0].Extensions["retryAfter"])
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
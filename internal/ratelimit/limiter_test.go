@@ -0,0 +1,106 @@
+//go:build testcoverage
+// +build testcoverage
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(rate float64, burst int) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(rate, burst)
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestTake(t *testing.T) {
+	// Arrange
+	l, clock := newTestLimiter(1, 3)
+
+	// Act & Assert
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Take("a", 1)
+		assert.True(t, ok, "request %d should fit in the burst", i+1)
+	}
+	ok, wait := l.Take("a", 1)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	ok, _ = l.Take("b", 1)
+	assert.True(t, ok, "buckets are independent per key")
+
+	clock.Advance(time.Second)
+	ok, _ = l.Take("a", 1)
+	assert.True(t, ok, "a token refills after one second")
+}
+
+func TestTakeWeighted(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cost         float64
+		expectedOK   bool
+		expectedWait time.Duration
+	}{
+		{name: "Cost within remaining tokens", cost: 2, expectedOK: true},
+		{name: "Cost above remaining tokens", cost: 4, expectedOK: false, expectedWait: 500 * time.Millisecond},
+		{name: "Cost above burst", cost: 10, expectedOK: false, expectedWait: time.Duration(1<<63 - 1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			l, _ := newTestLimiter(2, 5)
+			l.Take("a", 2)
+
+			// Act
+			ok, wait := l.Take("a", tc.cost)
+
+			// Assert
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedWait, wait)
+		})
+	}
+}
+
+func TestSweepEvictsIdleBuckets(t *testing.T) {
+	// Arrange
+	l, clock := newTestLimiter(1, 5)
+	l.Take("idle", 1)
+	clock.Advance(2 * sweepInterval)
+
+	// Act
+	l.Take("active", 1)
+
+	// Assert
+	assert.NotContains(t, l.buckets, "idle")
+	assert.Contains(t, l.buckets, "active")
+}
+
+func TestSetLimits(t *testing.T) {
+	// Arrange
+	l, clock := newTestLimiter(1, 10)
+	l.Take("a", 10)
+
+	// Act
+	l.SetLimits(5, 2)
+	clock.Advance(time.Second)
+
+	// Assert
+	ok, _ := l.Take("a", 2)
+	assert.True(t, ok, "bucket should refill at the new rate")
+	ok, wait := l.Take("a", 1)
+	assert.False(t, ok, "bucket should be capped at the new burst")
+	assert.Equal(t, 200*time.Millisecond, wait)
+}
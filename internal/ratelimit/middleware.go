@@ -0,0 +1,185 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"iohk-golang-backend/internal/auth"
+	"iohk-golang-backend/internal/requestid"
+)
+
+// CodeRateLimited is the GraphQL error extension code for rejected requests.
+const CodeRateLimited = "RATE_LIMITED"
+
+// KeyFunc derives the bucket key for a request.
+type KeyFunc func(r *http.Request) string
+
+// KeyByPrincipalOrIP keys authenticated callers by principal, which covers
+// both API keys and users, and everyone else by client IP. X-Forwarded-For is
+// only trusted when the server runs behind a proxy that appends to it.
+func KeyByPrincipalOrIP(trustProxy bool) KeyFunc {
+	return func(r *http.Request) string {
+		if p := auth.FromContext(r.Context()); p != nil && p != auth.Anonymous {
+			return "principal:" + p.Name
+		}
+		return "ip:" + clientIP(r, trustProxy)
+	}
+}
+
+// KeyByIP keys every request by client IP, whether it is authenticated or
+// not. It limits requests before authentication, so failed attempts to guess
+// API keys are limited too.
+func KeyByIP(trustProxy bool) KeyFunc {
+	return func(r *http.Request) string {
+		return "ip:" + clientIP(r, trustProxy)
+	}
+}
+
+// clientIP returns the address of the peer, or behind a trusted proxy the
+// rightmost X-Forwarded-For entry. That is the one the proxy appended, while
+// the entries before it come from the client and can be forged.
+func clientIP(r *http.Request, trustProxy bool) string {
+	if forwarded := r.Header.Values("X-Forwarded-For"); trustProxy && len(forwarded) > 0 {
+		last := forwarded[len(forwarded)-1]
+		if i := strings.LastIndexByte(last, ','); i >= 0 {
+			last = last[i+1:]
+		}
+		if last = strings.TrimSpace(last); last != "" {
+			return last
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// state is shared between the middleware and the GraphQL extension for one
+// request, so the extension can charge the same bucket and turn its own
+// rejections into an HTTP 429.
+type state struct {
+	limiter    *Limiter
+	key        string
+	mu         sync.Mutex
+	retryAfter time.Duration
+	limited    bool
+}
+
+func (s *state) reject(retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limited = true
+	s.retryAfter = retryAfter
+}
+
+type stateKey struct{}
+
+func stateFromContext(ctx context.Context) *state {
+	s, _ := ctx.Value(stateKey{}).(*state)
+	return s
+}
+
+// Middleware charges one token per request and rejects the request with
+// HTTP 429 when the caller's bucket is empty.
+func (l *Limiter) Middleware(keyFunc KeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := keyFunc(r)
+			if ok, wait := l.Take(key, 1); !ok {
+				writeRateLimited(w, r, wait)
+				return
+			}
+
+			s := &state{limiter: l, key: key}
+			ctx := context.WithValue(r.Context(), stateKey{}, s)
+			next.ServeHTTP(&limitedWriter{ResponseWriter: w, state: s}, r.WithContext(ctx))
+		})
+	}
+}
+
+func writeRateLimited(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	setRetryAfter(w, wait)
+	extensions := map[string]interface{}{"code": CodeRateLimited}
+	if id := requestid.FromContext(r.Context()); id != "" {
+		extensions["requestId"] = id
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"message":    "rate limit exceeded",
+			"extensions": extensions,
+		}},
+	})
+}
+
+func setRetryAfter(w http.ResponseWriter, wait time.Duration) {
+	if seconds, ok := retryAfterSeconds(wait); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+}
+
+// retryAfterSeconds rounds the wait up to whole seconds. It reports false for
+// requests that can never succeed because they cost more than the burst.
+func retryAfterSeconds(wait time.Duration) (int, bool) {
+	if wait == time.Duration(math.MaxInt64) {
+		return 0, false
+	}
+	return int(math.Ceil(wait.Seconds())), true
+}
+
+// limitedWriter swaps the status for 429 when the GraphQL extension rejected
+// the operation, since gqlgen writes GraphQL errors with a 200.
+type limitedWriter struct {
+	http.ResponseWriter
+	state       *state
+	wroteHeader bool
+}
+
+func (w *limitedWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	w.state.mu.Lock()
+	limited, wait := w.state.limited, w.state.retryAfter
+	w.state.mu.Unlock()
+	if limited {
+		setRetryAfter(w.ResponseWriter, wait)
+		status = http.StatusTooManyRequests
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *limitedWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *limitedWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the websocket transport take over the connection.
+func (w *limitedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("ratelimit: response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
@@ -0,0 +1,203 @@
+//go:build testcoverage
+// +build testcoverage
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"iohk-golang-backend/internal/auth"
+	"iohk-golang-backend/internal/requestid"
+)
+
+func TestMiddleware(t *testing.T) {
+	// Arrange
+	l, _ := newTestLimiter(0.5, 1)
+	handler := l.Middleware(KeyByPrincipalOrIP(false))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{}}`))
+	}))
+
+	// Act
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodPost, "/query", nil))
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, httptest.NewRequest(http.MethodPost, "/query", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "2", second.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"errors":[{"message":"rate limit exceeded","extensions":{"code":"RATE_LIMITED"}}]}`, second.Body.String())
+}
+
+func TestMiddlewareIncludesRequestID(t *testing.T) {
+	// Arrange
+	l, _ := newTestLimiter(0.5, 1)
+	l.Take("ip:192.0.2.1", 1)
+	handler := l.Middleware(KeyByPrincipalOrIP(false))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	req = req.WithContext(requestid.WithRequestID(req.Context(), "abc-123"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"errors":[{"message":"rate limit exceeded","extensions":{"code":"RATE_LIMITED","requestId":"abc-123"}}]}`, rec.Body.String())
+}
+
+func TestLimitedWriterRewritesStatus(t *testing.T) {
+	// Arrange
+	l, _ := newTestLimiter(1, 1)
+	handler := l.Middleware(KeyByPrincipalOrIP(false))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stateFromContext(r.Context()).reject(3 * time.Second)
+		w.Write([]byte(`{"errors":[]}`))
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/query", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("Retry-After"))
+}
+
+func TestLimitedWriterHijack(t *testing.T) {
+	// Arrange
+	l, _ := newTestLimiter(1, 1)
+	hijacked := make(chan error, 1)
+	handler := l.Middleware(KeyByPrincipalOrIP(false))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		hijacked <- err
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Act
+	resp, err := http.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	// Assert
+	select {
+	case err := <-hijacked:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not run")
+	}
+}
+
+func TestKeyByPrincipalOrIP(t *testing.T) {
+	testCases := []struct {
+		name       string
+		principal  *auth.Principal
+		forwarded  string
+		trustProxy bool
+		expected   string
+	}{
+		{
+			name:      "Authenticated principal",
+			principal: &auth.Principal{Name: "callcentre"},
+			expected:  "principal:callcentre",
+		},
+		{
+			name:      "Anonymous falls back to IP",
+			principal: auth.Anonymous,
+			expected:  "ip:192.0.2.1",
+		},
+		{
+			name:      "Forwarded header ignored without trusted proxy",
+			forwarded: "203.0.113.5",
+			expected:  "ip:192.0.2.1",
+		},
+		{
+			name:       "Entry appended by trusted proxy",
+			forwarded:  "203.0.113.5",
+			trustProxy: true,
+			expected:   "ip:203.0.113.5",
+		},
+		{
+			name:       "Entries forged by the client are skipped",
+			forwarded:  "198.51.100.7, 203.0.113.5",
+			trustProxy: true,
+			expected:   "ip:203.0.113.5",
+		},
+		{
+			name:       "Empty forwarded entry falls back to peer",
+			forwarded:  "203.0.113.5, ",
+			trustProxy: true,
+			expected:   "ip:192.0.2.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			if tc.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tc.principal))
+			}
+			if tc.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+
+			// Act
+			key := KeyByPrincipalOrIP(tc.trustProxy)(req)
+
+			// Assert
+			assert.Equal(t, tc.expected, key)
+		})
+	}
+}
+
+func TestKeyByIP(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Name: "callcentre"}))
+	req.Header.Set("X-Forwarded-For", "203.0.113.5")
+
+	// Act
+	direct := KeyByIP(false)(req)
+	proxied := KeyByIP(true)(req)
+
+	// Assert
+	assert.Equal(t, "ip:192.0.2.1", direct, "authenticated callers are keyed by IP too")
+	assert.Equal(t, "ip:203.0.113.5", proxied)
+}
+
+func TestMiddlewareBeforeAuthentication(t *testing.T) {
+	// Arrange
+	l, _ := newTestLimiter(0.5, 2)
+	keys := map[string]*auth.Principal{"valid-key": {Name: "callcentre"}}
+	handler := l.Middleware(KeyByIP(false))(auth.Middleware(keys, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{}}`))
+	})))
+	serve := func(key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Act
+	first := serve("guess-1")
+	second := serve("guess-2")
+	third := serve("valid-key")
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, first)
+	assert.Equal(t, http.StatusUnauthorized, second)
+	assert.Equal(t, http.StatusTooManyRequests, third, "failed attempts use up the bucket of the IP")
+}
@@ -0,0 +1,119 @@
+// Package requestid correlates a request across the frontend, the server
+// logs, traces, GraphQL errors and outbound calls through the X-Request-ID
+// header.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Header carries the request ID in requests and responses.
+const Header = "X-Request-ID"
+
+// AttributeKey is the span attribute holding the request ID.
+const AttributeKey = attribute.Key("http.request.id")
+
+// maxLength bounds accepted request IDs, so clients cannot bloat every log line.
+const maxLength = 128
+
+type contextKey struct{}
+
+// New returns a random request ID of 32 hex characters.
+func New() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a copy of ctx carrying id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID of ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Middleware takes the request ID from the X-Request-ID header, or generates
+// one if the header is missing or invalid, and echoes it in the response. The
+// ID is put into the request context and onto the current span.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !valid(id) {
+			id = New()
+		}
+		w.Header().Set(Header, id)
+		trace.SpanFromContext(r.Context()).SetAttributes(AttributeKey.String(id))
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+// valid accepts IDs of letters, digits and the separators UUIDs and common
+// tracing tools use. Anything else could forge log lines or headers.
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':', c == '/', c == '+', c == '=':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorPresenter presents errors like graphql.DefaultErrorPresenter and adds
+// the request ID to their extensions, so errors shown by a client can be
+// matched to the server logs.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	AddToError(ctx, gqlErr)
+	return gqlErr
+}
+
+// AddToError sets extensions.requestId of err, if ctx carries a request ID.
+func AddToError(ctx context.Context, err *gqlerror.Error) {
+	id := FromContext(ctx)
+	if id == "" {
+		return
+	}
+	if err.Extensions == nil {
+		err.Extensions = map[string]interface{}{}
+	}
+	err.Extensions["requestId"] = id
+}
+
+// Transport forwards the request ID of the request context to outbound HTTP
+// calls, such as webhooks. A nil Base uses http.DefaultTransport.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper. It leaves an X-Request-ID header the
+// caller has set alone.
+func (t Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if id := FromContext(req.Context()); id != "" && req.Header.Get(Header) == "" {
+		// A RoundTripper must not modify the request it was given
+		req = req.Clone(req.Context())
+		req.Header.Set(Header, id)
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,147 @@
+//go:build testcoverage
+// +build testcoverage
+
+package requestid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name       string
+		header     string
+		expectKept bool
+	}{
+		{name: "Missing header generates an ID", header: "", expectKept: false},
+		{name: "Valid header is kept", header: "2f1c-support_42", expectKept: true},
+		{name: "UUID header is kept", header: "0b6f7d3e-3c1a-4b8e-9d6a-6f0c2e7a9b51", expectKept: true},
+		{name: "Header with newline is replaced", header: "abc\nlevel=ERROR", expectKept: false},
+		{name: "Header with spaces is replaced", header: "abc def", expectKept: false},
+		{name: "Overlong header is replaced", header: strings.Repeat("a", 129), expectKept: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var seen string
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			if tc.header != "" {
+				req.Header.Set(Header, tc.header)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, seen, rec.Header().Get(Header))
+			if tc.expectKept {
+				assert.Equal(t, tc.header, seen)
+			} else {
+				assert.Len(t, seen, 32)
+			}
+		})
+	}
+}
+
+func TestMiddlewareSetsSpanAttribute(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+	handler := Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	req := httptest.NewRequest(http.MethodPost, "/query", nil).WithContext(ctx)
+	req.Header.Set(Header, "abc-123")
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	span.End()
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), AttributeKey.String("abc-123"))
+}
+
+func TestErrorPresenter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected map[string]interface{}
+	}{
+		{
+			name:     "Request ID added to extensions",
+			ctx:      WithRequestID(context.Background(), "abc-123"),
+			expected: map[string]interface{}{"requestId": "abc-123"},
+		},
+		{
+			name:     "No request ID leaves extensions alone",
+			ctx:      context.Background(),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			gqlErr := ErrorPresenter(tc.ctx, errors.New("customer not found"))
+
+			// Assert
+			assert.Equal(t, "customer not found", gqlErr.Message)
+			assert.Equal(t, tc.expected, gqlErr.Extensions)
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		header   string
+		expected string
+	}{
+		{name: "Request ID forwarded", ctx: WithRequestID(context.Background(), "abc-123"), expected: "abc-123"},
+		{name: "Explicit header kept", ctx: WithRequestID(context.Background(), "abc-123"), header: "set-by-caller", expected: "set-by-caller"},
+		{name: "No request ID", ctx: context.Background(), expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get(Header)
+			}))
+			defer server.Close()
+			client := &http.Client{Transport: Transport{}}
+			req, err := http.NewRequestWithContext(tc.ctx, http.MethodPost, server.URL, nil)
+			require.NoError(t, err)
+			if tc.header != "" {
+				req.Header.Set(Header, tc.header)
+			}
+
+			// Act
+			resp, err := client.Do(req)
+
+			// Assert
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tc.expected, received)
+			assert.Equal(t, tc.header, req.Header.Get(Header))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package servertls serves HTTPS from certificate files that are reloaded when
+// they change on disk, optionally requiring client certificates (mTLS).
+package servertls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"iohk-golang-backend/internal/logging"
+)
+
+// Reloader holds the server certificate and client CA bundle loaded from
+// disk. Handshakes always use the last files that loaded successfully.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	stamp     string
+}
+
+// NewReloader loads the certificate and key, and the client CA bundle if
+// clientCAFile is set. With a client CA bundle every connection must present
+// a client certificate signed by one of its CAs.
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the files again. On error the previous certificate and CAs
+// stay in use.
+func (r *Reloader) Reload() error {
+	stamp, err := r.fileStamp()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return errors.New("client CA bundle contains no certificates")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.stamp = stamp
+	return nil
+}
+
+// Watch checks the files every interval until ctx is cancelled and reloads
+// them once any of them changed. Failed reloads are logged and retried on the
+// next change.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stamp, err := r.fileStamp()
+		r.mu.RLock()
+		changed := err == nil && stamp != r.stamp
+		r.mu.RUnlock()
+		if !changed {
+			continue
+		}
+		if err := r.Reload(); err != nil {
+			logging.FromContext(ctx).Warn("Failed to reload TLS certificate, keeping the previous one", "error", err)
+			r.mu.Lock()
+			r.stamp = stamp
+			r.mu.Unlock()
+			continue
+		}
+		logging.FromContext(ctx).Info("Reloaded TLS certificate", "file", r.certFile)
+	}
+}
+
+// fileStamp identifies the current version of the files by their size and
+// modification time. Certificate managers replace the files, often through
+// a symlink swap, so the stamp changes even if the clock does not move.
+func (r *Reloader) fileStamp() (string, error) {
+	var stamp string
+	for _, name := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return "", err
+		}
+		stamp += fmt.Sprintf("%s:%d:%d;", name, info.Size(), info.ModTime().UnixNano())
+	}
+	return stamp, nil
+}
+
+// GetCertificate returns the current server certificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig returns the server configuration. It picks up reloaded
+// certificates and client CAs on every handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+			if r.clientCAs != nil {
+				cfg.ClientCAs = r.clientCAs
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return cfg, nil
+		},
+	}
+}
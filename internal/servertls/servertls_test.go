@@ -0,0 +1,260 @@
+//go:build testcoverage
+// +build testcoverage
+
+package servertls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues certificates for the tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key for commonName, valid for
+// 127.0.0.1 and for client authentication.
+func (ca *testCA) issue(t *testing.T, commonName string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, name string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(name, data, 0o600))
+}
+
+// startServer serves TLS with the reloader and returns the server URL.
+func startServer(t *testing.T, r *Reloader) string {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if len(req.TLS.VerifiedChains) > 0 {
+			w.Write([]byte(req.TLS.VerifiedChains[0][0].Subject.CommonName))
+		}
+	}))
+	server.TLS = r.TLSConfig()
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newClient(ca *testCA, clientCert *tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	cfg := &tls.Config{RootCAs: roots}
+	if clientCert != nil {
+		cfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg, DisableKeepAlives: true}}
+}
+
+func serverCommonName(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.TLS.PeerCertificates[0].Subject.CommonName
+}
+
+func TestNewReloader(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "server")
+	writeFile(t, filepath.Join(dir, "tls.crt"), certPEM)
+	writeFile(t, filepath.Join(dir, "tls.key"), keyPEM)
+	writeFile(t, filepath.Join(dir, "empty.pem"), nil)
+
+	testCases := []struct {
+		name          string
+		certFile      string
+		clientCAFile  string
+		expectedError string
+	}{
+		{name: "Valid certificate", certFile: "tls.crt"},
+		{name: "Missing certificate", certFile: "missing.crt", expectedError: "no such file or directory"},
+		{name: "Empty client CA bundle", certFile: "tls.crt", clientCAFile: "empty.pem", expectedError: "client CA bundle contains no certificates"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			clientCAFile := ""
+			if tc.clientCAFile != "" {
+				clientCAFile = filepath.Join(dir, tc.clientCAFile)
+			}
+
+			// Act
+			_, err := NewReloader(filepath.Join(dir, tc.certFile), filepath.Join(dir, "tls.key"), clientCAFile)
+
+			// Assert
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestWatchReloadsCertificate(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "first")
+	writeFile(t, certFile, certPEM)
+	writeFile(t, keyFile, keyPEM)
+	r, err := NewReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+	url := startServer(t, r)
+	client := newClient(ca, nil)
+	require.Equal(t, "first", serverCommonName(t, client, url))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	// Act
+	certPEM, keyPEM = ca.issue(t, "second")
+	writeFile(t, keyFile, keyPEM)
+	writeFile(t, certFile, certPEM)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		return serverCommonName(t, client, url) == "second"
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestWatchKeepsCertificateOnError(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "first")
+	writeFile(t, certFile, certPEM)
+	writeFile(t, keyFile, keyPEM)
+	r, err := NewReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+
+	// Act
+	writeFile(t, certFile, []byte("not a certificate"))
+	err = r.Reload()
+
+	// Assert
+	assert.Error(t, err)
+	cert, _ := r.GetCertificate(nil)
+	leaf, parseErr := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, parseErr)
+	assert.Equal(t, "first", leaf.Subject.CommonName)
+}
+
+func TestMutualTLS(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "server")
+	writeFile(t, filepath.Join(dir, "tls.crt"), certPEM)
+	writeFile(t, filepath.Join(dir, "tls.key"), keyPEM)
+	writeFile(t, filepath.Join(dir, "ca.pem"), ca.pem)
+	r, err := NewReloader(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.pem"))
+	require.NoError(t, err)
+	url := startServer(t, r)
+
+	clientPEM, clientKeyPEM := ca.issue(t, "billing")
+	clientCert, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+	require.NoError(t, err)
+	otherCA := newTestCA(t)
+	otherPEM, otherKeyPEM := otherCA.issue(t, "intruder")
+	otherCert, err := tls.X509KeyPair(otherPEM, otherKeyPEM)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		clientCert   *tls.Certificate
+		expectedName string
+		expectError  bool
+	}{
+		{name: "Trusted client certificate", clientCert: &clientCert, expectedName: "billing"},
+		{name: "No client certificate", clientCert: nil, expectError: true},
+		{name: "Untrusted client certificate", clientCert: &otherCert, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			resp, err := newClient(ca, tc.clientCert).Get(url)
+
+			// Assert
+			if tc.expectError {
+				if err == nil {
+					resp.Body.Close()
+				}
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body := make([]byte, 64)
+			n, _ := resp.Body.Read(body)
+			assert.Equal(t, tc.expectedName, string(body[:n]))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Extension is a gqlgen extension creating a span for every operation and
+// a child span for every field backed by a resolver.
+type Extension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+	graphql.FieldInterceptor
+} = Extension{}
+
+func (Extension) ExtensionName() string {
+	return "Tracing"
+}
+
+func (Extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	opType, name := "unknown", oc.OperationName
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+		name = oc.Operation.Name
+	}
+
+	spanName := opType
+	if name != "" {
+		spanName += " " + name
+	}
+	ctx, span := Start(ctx, spanName,
+		attribute.String("graphql.operation.type", opType),
+		attribute.String("graphql.operation.name", name),
+	)
+
+	handler := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		// Resolvers run in the context passed to the response handler, so it
+		// has to carry the operation span for field spans to nest under it.
+		resp := handler(trace.ContextWithSpan(ctx, span))
+		if resp == nil {
+			span.End()
+			return nil
+		}
+		if len(resp.Errors) > 0 {
+			span.SetAttributes(attribute.Int("graphql.errors.count", len(resp.Errors)))
+			span.SetStatus(codes.Error, resp.Errors[0].Message)
+		}
+		// Subscriptions keep producing responses until the handler returns nil
+		if oc.Operation == nil || oc.Operation.Operation != ast.Subscription {
+			span.End()
+		}
+		return resp
+	}
+}
+
+func (Extension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := Start(ctx, fmt.Sprintf("%s.%s", fc.Object, fc.Field.Name),
+		attribute.String("graphql.field.path", fc.Path().String()),
+		attribute.String("graphql.field.name", fc.Field.Name),
+	)
+	res, err := next(ctx)
+	End(span, err)
+	return res, err
+}
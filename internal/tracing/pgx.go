@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxTracer creates a span for every SQL statement run by pgx. Only the
+// statement text is recorded, never its arguments, which may contain PII.
+type PgxTracer struct{}
+
+var _ pgx.QueryTracer = PgxTracer{}
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := operationName(data.SQL)
+	ctx, _ = tracer().Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	return ctx
+}
+
+func (PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	End(trace.SpanFromContext(ctx), data.Err)
+}
+
+// operationName returns the upper case SQL verb of query.
+func operationName(query string) string {
+	verb, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	return strings.ToUpper(verb)
+}
@@ -0,0 +1,200 @@
+//go:build testcoverage
+// +build testcoverage
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestSetup(t *testing.T) {
+	testCases := []struct {
+		name          string
+		exporter      string
+		expectedError string
+	}{
+		{name: "Disabled", exporter: ExporterNone},
+		{name: "Stdout", exporter: ExporterStdout},
+		{name: "Unknown exporter", exporter: "zipkin", expectedError: `unknown trace exporter "zipkin"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			previous := otel.GetTracerProvider()
+			t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+			// Act
+			shutdown, err := Setup(context.Background(), tc.exporter, 1)
+
+			// Assert
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestExtensionOperationSpan(t *testing.T) {
+	// Arrange
+	recorder := newRecorder(t)
+	ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		Operation: &ast.OperationDefinition{Name: "GetCustomers", Operation: ast.Query},
+	})
+	next := func(ctx context.Context) graphql.ResponseHandler {
+		return func(ctx context.Context) *graphql.Response {
+			_, span := Start(ctx, "child")
+			span.End()
+			return &graphql.Response{Errors: gqlerror.List{{Message: "customer not found"}}}
+		}
+	}
+
+	// Act
+	Extension{}.InterceptOperation(ctx, next)(context.Background())
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, op := spans[0], spans[1]
+	assert.Equal(t, "query GetCustomers", op.Name())
+	assert.Equal(t, op.SpanContext().SpanID(), child.Parent().SpanID())
+	assert.Equal(t, codes.Error, op.Status().Code)
+	assert.Equal(t, "customer not found", op.Status().Description)
+	assert.Equal(t, "GetCustomers", attributes(op)["graphql.operation.name"].AsString())
+	assert.Equal(t, "query", attributes(op)["graphql.operation.type"].AsString())
+}
+
+func TestExtensionFieldSpan(t *testing.T) {
+	testCases := []struct {
+		name          string
+		isResolver    bool
+		err           error
+		expectedSpans int
+	}{
+		{name: "Resolver", isResolver: true, expectedSpans: 1},
+		{name: "Resolver error", isResolver: true, err: errors.New("boom"), expectedSpans: 1},
+		{name: "Plain field", isResolver: false, expectedSpans: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			recorder := newRecorder(t)
+			ctx := graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+				Object:     "Query",
+				Field:      graphql.CollectedField{Field: &ast.Field{Name: "customers", Alias: "customers"}},
+				IsResolver: tc.isResolver,
+			})
+
+			// Act
+			_, err := Extension{}.InterceptField(ctx, func(ctx context.Context) (interface{}, error) {
+				return nil, tc.err
+			})
+
+			// Assert
+			assert.Equal(t, tc.err, err)
+			spans := recorder.Ended()
+			require.Len(t, spans, tc.expectedSpans)
+			if tc.expectedSpans == 0 {
+				return
+			}
+			assert.Equal(t, "Query.customers", spans[0].Name())
+			assert.Equal(t, "customers", attributes(spans[0])["graphql.field.path"].AsString())
+			if tc.err != nil {
+				assert.Equal(t, codes.Error, spans[0].Status().Code)
+			}
+		})
+	}
+}
+
+func TestPgxTracer(t *testing.T) {
+	// Arrange
+	recorder := newRecorder(t)
+	query := `SELECT "customers"."id" FROM "customers" WHERE "customers"."id" = $1`
+
+	// Act
+	ctx := PgxTracer{}.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: query, Args: []any{"secret"}})
+	PgxTracer{}.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("timeout")})
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "SELECT", spans[0].Name())
+	assert.Equal(t, query, attributes(spans[0])["db.query.text"].AsString())
+	assert.Equal(t, "postgresql", attributes(spans[0])["db.system"].AsString())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	for _, kv := range spans[0].Attributes() {
+		assert.NotContains(t, kv.Value.Emit(), "secret")
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		expectedSpans int
+	}{
+		{name: "GraphQL request", path: "/query", expectedSpans: 1},
+		{name: "Health check", path: "/healthz", expectedSpans: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			recorder := newRecorder(t)
+			_, err := Setup(context.Background(), ExporterNone, 1)
+			require.NoError(t, err)
+			handler := HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+			// Act
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			// Assert
+			spans := recorder.Ended()
+			require.Len(t, spans, tc.expectedSpans)
+			if tc.expectedSpans == 0 {
+				return
+			}
+			assert.Equal(t, "POST "+tc.path, spans[0].Name())
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+			assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+		})
+	}
+}
@@ -1,46 +0,0 @@
--- Create the customer table
-CREATE TABLE IF NOT EXISTS customers (
-    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
-    name VARCHAR(100) NOT NULL,
-    surname VARCHAR(100) NOT NULL,
-    number INT NOT NULL,
-    gender VARCHAR(15) NOT NULL CHECK (gender IN ('Male', 'Female')),
-    country VARCHAR(50) NOT NULL,
-    dependants INT NOT NULL DEFAULT 0 CHECK (dependants >= 0),
-    birth_date DATE NOT NULL CHECK (birth_date <= CURRENT_DATE)
-);
-
--- Insert sample data into the customer table using TO_DATE to handle the MM/DD/YYYY date format
-INSERT INTO customers (name, surname, number, gender, country, dependants, birth_date) VALUES
-('Jack', 'Front', 123, 'Male', 'USA', 5, TO_DATE('10/3/1981', 'MM/DD/YYYY')),
-('Jill', 'Human', 654, 'Female', 'Spain', 0, TO_DATE('6/2/1983', 'MM/DD/YYYY')),
-('Robert', 'Pullman', 456, 'Male', 'Germany', 2, TO_DATE('5/4/1999', 'MM/DD/YYYY')),
-('Chun Li', 'Suzuki', 987, 'Female', 'China', 1, TO_DATE('11/9/2001', 'MM/DD/YYYY')),
-('Sarah', 'Van Que', 587, 'Female', 'USA', 4, TO_DATE('6/22/1989', 'MM/DD/YYYY')),
-('Michael', 'Johnson', 111, 'Male', 'USA', 3, TO_DATE('2/15/1945', 'MM/DD/YYYY')),
-('Anna', 'Smith', 222, 'Female', 'Canada', 0, TO_DATE('8/19/1975', 'MM/DD/YYYY')),
-('Liam', 'Kova', 333, 'Male', 'USA', 1, TO_DATE('12/14/1990', 'MM/DD/YYYY')),
-('Sophia', 'Muller', 444, 'Female', 'Germany', 1, TO_DATE('9/5/1950', 'MM/DD/YYYY')),
-('Ethan', 'Brown', 555, 'Male', 'Australia', 2, TO_DATE('3/28/2003', 'MM/DD/YYYY')),
-('Isabella', 'Martinez', 666, 'Female', 'Spain', 6, TO_DATE('7/12/2008', 'MM/DD/YYYY')),
-('Noah', 'Garcia', 777, 'Male', 'Spain', 10, TO_DATE('11/30/1987', 'MM/DD/YYYY')),
-('Olivia', 'Rossi', 888, 'Female', 'Italy', 2, TO_DATE('1/6/1992', 'MM/DD/YYYY')),
-('Mason', 'Smith', 999, 'Male', 'UK', 4, TO_DATE('5/22/1997', 'MM/DD/YYYY')),
-('Emily', 'Ivanova', 101, 'Female', 'Russia', 1, TO_DATE('4/15/1984', 'MM/DD/YYYY')),
-('Lucas', 'Anderson', 202, 'Male', 'Australia', 3, TO_DATE('6/18/1965', 'MM/DD/YYYY')),
-('Ava', 'Williams', 303, 'Female', 'USA', 0, TO_DATE('10/20/2012', 'MM/DD/YYYY')),
-('James', 'Chen', 404, 'Male', 'China', 9, TO_DATE('9/3/2000', 'MM/DD/YYYY')),
-('Emma', 'Schmidt', 505, 'Female', 'Germany', 2, TO_DATE('12/25/1982', 'MM/DD/YYYY')),
-('Benjamin', 'Nguyen', 606, 'Male', 'USA', 4, TO_DATE('7/14/1994', 'MM/DD/YYYY')),
-('Mia', 'Lopez', 707, 'Female', 'Spain', 3, TO_DATE('2/8/1988', 'MM/DD/YYYY')),
-('Elijah', 'Patel', 808, 'Male', 'India', 2, TO_DATE('3/9/2002', 'MM/DD/YYYY')),
-('Amelia', 'Wilson', 909, 'Female', 'Australia', 1, TO_DATE('5/30/2010', 'MM/DD/YYYY')),
-('Ryan', 'Cooper', 102, 'Male', 'USA', 10, TO_DATE('2/19/1935', 'MM/DD/YYYY')),
-('Natalie', 'Perez', 203, 'Female', 'Spain', 8, TO_DATE('11/17/1968', 'MM/DD/YYYY')),
-('Henry', 'Moore', 304, 'Male', 'UK', 9, TO_DATE('3/5/1989', 'MM/DD/YYYY')),
-('Zoe', 'Kim', 405, 'Female', 'China', 2, TO_DATE('9/27/1998', 'MM/DD/YYYY')),
-('Daniel', 'Lee', 506, 'Male', 'USA', 4, TO_DATE('6/15/1952', 'MM/DD/YYYY')),
-('Grace', 'Thompson', 607, 'Female', 'Australia', 9, TO_DATE('1/10/1987', 'MM/DD/YYYY')),
-('William', 'Parker', 708, 'Male', 'Canada', 7, TO_DATE('4/8/1960', 'MM/DD/YYYY')),
-('Helen', 'Davis', 809, 'Female', 'UK', 1, TO_DATE('10/5/1948', 'MM/DD/YYYY')),
-('George', 'Nelson', 910, 'Male', 'USA', 4, TO_DATE('12/11/1930', 'MM/DD/YYYY'));
\ No newline at end of file